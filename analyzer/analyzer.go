@@ -0,0 +1,155 @@
+// Package analyzer defines an Analyzer which reports calls to the Get method
+// of optional values whose set flag is discarded, such as
+//
+//	name, _ := user.Name.Get()
+//
+// and the first use of the value obtained this way, which is the zero value
+// whenever the optional is unset. GetOrZero and GetOr say the same thing
+// explicitly, and checking the flag handles the unset case.
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const optionalPath = "github.com/heucuva/optional"
+
+// Analyzer reports unchecked calls to Get on optional values
+var Analyzer = &analysis.Analyzer{
+	Name:     "optionalget",
+	Doc:      "report calls to Get on optional values whose set flag is discarded",
+	URL:      "https://pkg.go.dev/github.com/heucuva/optional/analyzer",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	filter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.ExprStmt)(nil),
+	}
+	ins.WithStack(filter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) == 2 && len(n.Rhs) == 1 {
+				checkAssign(pass, stack, n.Lhs[0], n.Lhs[1], n.Rhs[0])
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) == 2 && len(n.Values) == 1 {
+				checkAssign(pass, stack, n.Names[0], n.Names[1], n.Values[0])
+			}
+		case *ast.ExprStmt:
+			if call, ok := getCall(pass, n.X); ok {
+				pass.Reportf(call.Pos(), "result of %s is discarded", callName(call))
+			}
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// checkAssign reports value, flag := x.Get() where the flag is blank, along
+// with the first later use of value
+func checkAssign(pass *analysis.Pass, stack []ast.Node, value, flag, rhs ast.Expr) {
+	call, ok := getCall(pass, rhs)
+	if !ok || !isBlank(flag) {
+		return
+	}
+	pass.Reportf(call.Pos(), "set flag of %s is discarded; use GetOrZero or GetOr, or check the flag", callName(call))
+
+	id, ok := value.(*ast.Ident)
+	if !ok || isBlank(id) {
+		return
+	}
+	obj := pass.TypesInfo.ObjectOf(id)
+	body := enclosingBody(stack)
+	if obj == nil || body == nil {
+		return
+	}
+	if use := firstUse(pass, body, obj, call.End()); use != nil {
+		pass.Reportf(use.Pos(), "%s may be the zero value of an unset optional, as the set flag of %s was not checked", use.Name, callName(call))
+	}
+}
+
+// getCall returns expr as a call to the Get method of an optional type, one
+// returning its value and set flag
+func getCall(pass *analysis.Pass, expr ast.Expr) (*ast.CallExpr, bool) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil, false
+	}
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Get" {
+		return nil, false
+	}
+	selection, ok := pass.TypesInfo.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal {
+		return nil, false
+	}
+	fn, ok := selection.Obj().(*types.Func)
+	if !ok || fn.Pkg() == nil || !isOptionalPackage(fn.Pkg().Path()) {
+		return nil, false
+	}
+	results := fn.Type().(*types.Signature).Results()
+	if results.Len() != 2 {
+		return nil, false
+	}
+	if basic, ok := results.At(1).Type().(*types.Basic); !ok || basic.Kind() != types.Bool {
+		return nil, false
+	}
+	return call, true
+}
+
+func isOptionalPackage(path string) bool {
+	return path == optionalPath || strings.HasPrefix(path, optionalPath+"/")
+}
+
+func isBlank(expr ast.Expr) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == "_"
+}
+
+func callName(call *ast.CallExpr) string {
+	sel := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	return types.ExprString(ast.Unparen(sel.X)) + ".Get"
+}
+
+// enclosingBody returns the body of the innermost function in stack
+func enclosingBody(stack []ast.Node) *ast.BlockStmt {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			return fn.Body
+		case *ast.FuncLit:
+			return fn.Body
+		}
+	}
+	return nil
+}
+
+// firstUse returns the first use of obj in body after pos
+func firstUse(pass *analysis.Pass, body *ast.BlockStmt, obj types.Object, pos token.Pos) *ast.Ident {
+	var use *ast.Ident
+	ast.Inspect(body, func(n ast.Node) bool {
+		if use != nil {
+			return false
+		}
+		if id, ok := n.(*ast.Ident); ok && id.Pos() > pos && pass.TypesInfo.Uses[id] == obj {
+			use = id
+		}
+		return true
+	})
+	return use
+}
@@ -0,0 +1,14 @@
+// Command optionalget reports calls to Get on optional values whose set flag
+// is discarded. It can also be run through go vet:
+//
+//	go vet -vettool=$(which optionalget) ./...
+package main
+
+import (
+	"github.com/heucuva/optional/analyzer"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
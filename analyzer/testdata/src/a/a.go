@@ -0,0 +1,44 @@
+package a
+
+import "github.com/heucuva/optional"
+
+type user struct {
+	Name   optional.Value[string]
+	Age    optional.Value[int]
+	Strict optional.Strict[int]
+}
+
+type other struct{}
+
+func (other) Get() (int, bool) { return 0, false }
+
+func checked(u user) string {
+	if name, ok := u.Name.Get(); ok {
+		return name
+	}
+	return u.Name.GetOrZero()
+}
+
+func unchecked(u user) int {
+	name, _ := u.Name.Get() // want `set flag of u.Name.Get is discarded`
+	println(len(name))      // want `name may be the zero value of an unset optional`
+	println(name)
+
+	var age, _ = u.Age.Get() // want `set flag of u.Age.Get is discarded`
+	_ = age                  // want `age may be the zero value`
+
+	_, _ = u.Age.Get() // want `set flag of u.Age.Get is discarded`
+	u.Age.Get()        // want `result of u.Age.Get is discarded`
+
+	strict, _ := u.Strict.Get()
+	n, _ := other{}.Get()
+	return strict + n
+}
+
+func literal(u user) func() int {
+	return func() int {
+		var age int
+		age, _ = (u.Age).Get() // want `set flag of u.Age.Get is discarded`
+		return age             // want `age may be the zero value`
+	}
+}
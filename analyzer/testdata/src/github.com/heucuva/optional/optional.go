@@ -0,0 +1,24 @@
+// Package optional is a stub of github.com/heucuva/optional for the
+// analyzer tests
+package optional
+
+type Value[T any] struct {
+	set   bool
+	value T
+}
+
+func (o Value[T]) Get() (T, bool) {
+	return o.value, o.set
+}
+
+func (o Value[T]) GetOrZero() T {
+	return o.value
+}
+
+type Strict[T any] struct {
+	v Value[T]
+}
+
+func (s Strict[T]) Get() (T, error) {
+	return s.v.value, nil
+}
@@ -0,0 +1,181 @@
+package optional
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// AsAny returns the value as an `any` along with its set flag, for code that
+// needs to inspect an optional without knowing its type at compile time
+func (o Value[T]) AsAny() (any, bool) {
+	return o.value, o.set
+}
+
+// AsAny returns the value as an `any` along with its set flag, for code that
+// needs to inspect an optional without knowing its type at compile time
+func (o Boxed[T]) AsAny() (any, bool) {
+	v, set := o.Get()
+	return v, set
+}
+
+// AsAny returns the value as an `any` along with its set flag, for code that
+// needs to inspect an optional without knowing its type at compile time
+func (r ReadOnly[T]) AsAny() (any, bool) {
+	return r.v.AsAny()
+}
+
+// Option is implemented by pointers to the optional containers in this
+// package (e.g. *Value[int]), apart from ReadOnly, so that frameworks such
+// as middleware and encoders can handle optional struct fields uniformly,
+// without knowing their element types:
+//
+//	if opt, ok := field.(optional.Option); ok && !opt.IsSet() {
+//		opt.SetAny(defaultValue)
+//	}
+type Option interface {
+	// IsSet returns true if the value is set
+	IsSet() bool
+	// AsAny returns the value as an `any` along with its set flag
+	AsAny() (any, bool)
+	// SetAny sets the value to val, which must be assignable to the element
+	// type, or be a number it can represent. a nil val resets the value,
+	// as with FromAny
+	SetAny(val any) error
+}
+
+// FromAny constructs an optional of type typ (e.g. the type of a Value[int])
+// holding val, for code that needs to build an optional without knowing its
+// type at compile time. The result can be type-asserted to typ.
+//
+// A nil val produces an unset optional. Otherwise, val must be assignable to
+// the optional's element type, or be a number it can represent without
+// overflowing or being truncated.
+func FromAny(val any, typ reflect.Type) (any, error) {
+	if !IsOptionalType(typ) {
+		return nil, fmt.Errorf("optional: %v is not an optional type", typ)
+	}
+
+	ptr := reflect.New(typ)
+	if val != nil {
+		if err := ptr.Interface().(anySetter).setAny(val); err != nil {
+			return nil, err
+		}
+	}
+	return ptr.Elem().Interface(), nil
+}
+
+// SetAny sets the value to val, converting it to T as FromAny does.
+// a nil val resets the value
+func (o *Value[T]) SetAny(val any) error {
+	if val == nil {
+		o.Reset()
+		return nil
+	}
+	return o.setAny(val)
+}
+
+func (o *Value[T]) setAny(val any) error {
+	v, err := convertAny[T](val)
+	if err != nil {
+		return err
+	}
+	o.Set(v)
+	return nil
+}
+
+// SetAny sets the value to val, converting it to T as FromAny does.
+// a nil val resets the value
+func (o *Boxed[T]) SetAny(val any) error {
+	if val == nil {
+		o.Reset()
+		return nil
+	}
+	return o.setAny(val)
+}
+
+func (o *Boxed[T]) setAny(val any) error {
+	v, err := convertAny[T](val)
+	if err != nil {
+		return err
+	}
+	o.Set(v)
+	return nil
+}
+
+func (r *ReadOnly[T]) setAny(val any) error {
+	return r.v.setAny(val)
+}
+
+// convertAny converts val to T, if it is assignable or a number T can represent
+func convertAny[T any](val any) (T, error) {
+	if v, ok := val.(T); ok {
+		return v, nil
+	}
+
+	var empty T
+	dst := reflect.TypeOf((*T)(nil)).Elem()
+	rv := reflect.ValueOf(val)
+	if !rv.IsValid() {
+		return empty, nil
+	}
+	if rv.Type().AssignableTo(dst) {
+		// the types may differ, e.g. []int and a named slice type
+		reflect.ValueOf(&empty).Elem().Set(rv)
+		return empty, nil
+	}
+	if isNumberKind(rv.Kind()) && isNumberKind(dst.Kind()) {
+		converted, err := convertNumber(rv, dst)
+		if err != nil {
+			return empty, err
+		}
+		return converted.Interface().(T), nil
+	}
+	return empty, fmt.Errorf("optional: cannot use %v as %v", rv.Type(), dst)
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// convertNumber converts the number rv to the numeric type t, failing if the
+// value overflows t or, for an integer t, is truncated by it. converting to a
+// float may still round the value, as floats are approximate anyway
+func convertNumber(rv reflect.Value, t reflect.Type) (reflect.Value, error) {
+	out := rv.Convert(t)
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if math.IsInf(out.Float(), 0) && !isInfValue(rv) {
+			return reflect.Value{}, fmt.Errorf("optional: %v overflows %v", rv.Interface(), t)
+		}
+		return out, nil
+	}
+	if out.Convert(rv.Type()).Interface() != rv.Interface() || isNegativeValue(out) != isNegativeValue(rv) {
+		return reflect.Value{}, fmt.Errorf("optional: %v cannot be represented as %v", rv.Interface(), t)
+	}
+	return out, nil
+}
+
+func isInfValue(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return math.IsInf(rv.Float(), 0)
+	}
+	return false
+}
+
+func isNegativeValue(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() < 0
+	}
+	return false
+}
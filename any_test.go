@@ -0,0 +1,160 @@
+package optional_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestAsAny(t *testing.T) {
+	t.Run("Set", func(t *testing.T) {
+		value, set := optional.NewValue(5).AsAny()
+		expect(t, "set", true, set)
+		expect(t, "value", 5, value.(int))
+	})
+	t.Run("Unset", func(t *testing.T) {
+		_, set := optional.Value[string]{}.AsAny()
+		expect(t, "set", false, set)
+	})
+	t.Run("Boxed", func(t *testing.T) {
+		value, set := optional.NewBoxed("Foo").AsAny()
+		expect(t, "set", true, set)
+		expect(t, "value", "Foo", value.(string))
+	})
+}
+
+func TestFromAny(t *testing.T) {
+	valueType := reflect.TypeOf(optional.Value[int64]{})
+
+	t.Run("Assignable", func(t *testing.T) {
+		observed, err := optional.FromAny(int64(5), valueType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, set := observed.(optional.Value[int64]).Get()
+		expect(t, "set", true, set)
+		expect(t, "value", int64(5), value)
+	})
+	t.Run("Convertible", func(t *testing.T) {
+		observed, err := optional.FromAny(5, valueType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, _ := observed.(optional.Value[int64]).Get()
+		expect(t, "value", int64(5), value)
+	})
+	t.Run("Nil", func(t *testing.T) {
+		observed, err := optional.FromAny(nil, valueType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "set", false, observed.(optional.Value[int64]).IsSet())
+	})
+	t.Run("Boxed", func(t *testing.T) {
+		observed, err := optional.FromAny("Foo", reflect.TypeOf(optional.Boxed[string]{}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		value, _ := observed.(optional.Boxed[string]).Get()
+		expect(t, "value", "Foo", value)
+	})
+	t.Run("NamedType", func(t *testing.T) {
+		type testInts []int
+		type testLabels map[string]string
+		observed, err := optional.FromAny([]int{1, 2}, reflect.TypeOf(optional.Value[testInts]{}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "len", 2, len(observed.(optional.Value[testInts]).MustGet()))
+
+		var labels optional.Boxed[testLabels]
+		if err := labels.SetAny(map[string]string{"a": "b"}); err != nil {
+			t.Fatal(err)
+		}
+		value, _ := labels.Get()
+		expect(t, "label", "b", value["a"])
+	})
+	t.Run("NotRepresentable", func(t *testing.T) {
+		tests := map[string]struct {
+			val any
+			typ reflect.Type
+		}{
+			"Overflow":  {300, reflect.TypeOf(optional.Value[uint8]{})},
+			"Negative":  {-1, reflect.TypeOf(optional.Value[uint]{})},
+			"Truncated": {2.9, valueType},
+			"NaN":       {math.NaN(), valueType},
+			"Infinite":  {1e300, reflect.TypeOf(optional.Value[float32]{})},
+		}
+		for name, tc := range tests {
+			if _, err := optional.FromAny(tc.val, tc.typ); err == nil {
+				t.Errorf("%s: expected failure, but got success", name)
+			}
+		}
+
+		var v optional.Value[int8]
+		expect(t, "SetAny error", true, v.SetAny(128) != nil)
+		expect(t, "SetAny set", false, v.IsSet())
+		expect(t, "SetAny whole float", true, v.SetAny(-12.0) == nil)
+		expect(t, "SetAny value", int8(-12), v.MustGet())
+	})
+	t.Run("Mismatch", func(t *testing.T) {
+		if _, err := optional.FromAny("Foo", valueType); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+	t.Run("NotOptional", func(t *testing.T) {
+		if _, err := optional.FromAny(5, reflect.TypeOf(5)); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+}
+
+var (
+	_ optional.Option = (*optional.Value[int])(nil)
+	_ optional.Option = (*optional.Boxed[int])(nil)
+	_ optional.Option = (*optional.Strict[int])(nil)
+	_ optional.Option = (*optional.Sensitive[int])(nil)
+	_ optional.Option = (*optional.Encoded[int, optional.UnsetNull])(nil)
+)
+
+func TestOption(t *testing.T) {
+	var target struct {
+		Name  optional.Value[string]
+		Count optional.Boxed[int64]
+		Token optional.Sensitive[string]
+		Plain string
+	}
+	target.Token.Set("secret")
+
+	// fill in every unset option from a table, without knowing field types
+	defaults := map[string]any{"Name": "Foo", "Count": 5, "Token": "other"}
+	rv := reflect.ValueOf(&target).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		opt, ok := rv.Field(i).Addr().Interface().(optional.Option)
+		if !ok || opt.IsSet() {
+			continue
+		}
+		if err := opt.SetAny(defaults[rv.Type().Field(i).Name]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expect(t, "name", "Foo", target.Name.MustGet())
+	count, _ := target.Count.Get()
+	expect(t, "count", int64(5), count)
+	token, _ := target.Token.Get()
+	expect(t, "token", "secret", token)
+
+	t.Run("Nil", func(t *testing.T) {
+		var opt optional.Option = &target.Name
+		if err := opt.SetAny(nil); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "set", false, opt.IsSet())
+	})
+	t.Run("Mismatch", func(t *testing.T) {
+		var opt optional.Option = &target.Name
+		expect(t, "error", true, opt.SetAny(5) != nil)
+	})
+}
@@ -0,0 +1,65 @@
+package optional
+
+// NewSlice allocates a slice of n unset values in a single allocation
+func NewSlice[T any](n int) []Value[T] {
+	return make([]Value[T], n)
+}
+
+// SetAll sets every value in values into dst, reusing dst's storage when it
+// has the capacity, and returns the resulting slice of len(values) set values.
+// Passing the previous batch's result as dst avoids allocating per batch.
+func SetAll[T any](dst []Value[T], values []T) []Value[T] {
+	if cap(dst) < len(values) {
+		dst = make([]Value[T], len(values))
+	}
+	dst = dst[:len(values)]
+	for i, value := range values {
+		dst[i] = Value[T]{set: true, value: value}
+	}
+	return dst
+}
+
+// ResetAll resets every value in values, so a batch can be reused
+// without reallocating
+func ResetAll[T any](values []Value[T]) {
+	var empty Value[T]
+	for i := range values {
+		values[i] = empty
+	}
+}
+
+// Values returns the values of the set elements of values, in order,
+// dropping the unset ones
+func Values[T any](values []Value[T]) []T {
+	out := make([]T, 0, len(values))
+	for _, v := range values {
+		if v.set {
+			out = append(out, v.value)
+		}
+	}
+	return out
+}
+
+// All returns a Value holding the values of every element of values, if
+// they are all set. otherwise, it returns an unset Value
+func All[T any](values []Value[T]) Value[[]T] {
+	out := make([]T, len(values))
+	for i, v := range values {
+		if !v.set {
+			return Value[[]T]{}
+		}
+		out[i] = v.value
+	}
+	return NewValue(out)
+}
+
+// Count returns the number of set elements of values
+func Count[T any](values []Value[T]) int {
+	n := 0
+	for _, v := range values {
+		if v.set {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,77 @@
+package optional_test
+
+import (
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestNewSlice(t *testing.T) {
+	values := optional.NewSlice[int](3)
+	expect(t, "len", 3, len(values))
+	for _, v := range values {
+		expect(t, "set", false, v.IsSet())
+	}
+}
+
+func TestSetAll(t *testing.T) {
+	batch := optional.SetAll(nil, []int{1, 2, 3})
+	expect(t, "len", 3, len(batch))
+	expect(t, "batch[2]", 3, batch[2].MustGet())
+
+	t.Run("Reuse", func(t *testing.T) {
+		reused := optional.SetAll(batch, []int{4, 5})
+		expect(t, "len", 2, len(reused))
+		expect(t, "reused[0]", 4, reused[0].MustGet())
+		if &reused[0] != &batch[0] {
+			t.Fatal("expected storage to be reused")
+		}
+	})
+	t.Run("Grow", func(t *testing.T) {
+		grown := optional.SetAll(batch[:1], []int{6, 7, 8, 9})
+		expect(t, "len", 4, len(grown))
+		expect(t, "grown[3]", 9, grown[3].MustGet())
+	})
+	t.Run("ResetAll", func(t *testing.T) {
+		optional.ResetAll(batch)
+		for _, v := range batch {
+			expect(t, "set", false, v.IsSet())
+		}
+	})
+}
+
+func BenchmarkSetAll(b *testing.B) {
+	values := make([]int, 4096)
+	for i := range values {
+		values[i] = i
+	}
+	var batch []optional.Value[int]
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		batch = optional.SetAll(batch, values)
+	}
+}
+
+func TestSliceHelpers(t *testing.T) {
+	partial := []optional.Value[int]{optional.NewValue(1), {}, optional.NewValue(0)}
+	complete := []optional.Value[int]{optional.NewValue(1), optional.NewValue(2)}
+
+	t.Run("Values", func(t *testing.T) {
+		values := optional.Values(partial)
+		expect(t, "len", 2, len(values))
+		expect(t, "values[0]", 1, values[0])
+		expect(t, "values[1]", 0, values[1])
+	})
+	t.Run("All", func(t *testing.T) {
+		expect(t, "partial set", false, optional.All(partial).IsSet())
+		values := optional.All(complete).MustGet()
+		expect(t, "len", 2, len(values))
+		expect(t, "values[1]", 2, values[1])
+		expect(t, "empty set", true, optional.All[int](nil).IsSet())
+	})
+	t.Run("Count", func(t *testing.T) {
+		expect(t, "partial", 2, optional.Count(partial))
+		expect(t, "empty", 0, optional.Count[int](nil))
+	})
+}
@@ -0,0 +1,116 @@
+package optional
+
+import "encoding/json"
+
+// Boxed is an optional value that stores its contents behind a pointer.
+// It behaves identically to Value, but copying a Boxed only copies a
+// pointer, so it's a better fit than Value for embedding very large T
+// (multi-kilobyte structs, big arrays) into structures that get copied often.
+//
+// The boxed memory is never mutated in place - every Set allocates a new box -
+// so copies of a Boxed never observe each other's changes.
+//
+// A Boxed encodes exactly as the equivalent Value does. The one exception is
+// database/sql: Boxed scans columns, but cannot implement driver.Valuer as
+// its Value method returns a Value, so pass that as a query argument instead.
+type Boxed[T any] struct {
+	value *T
+}
+
+// NewBoxed constructs a Boxed structure with a value already set into it
+func NewBoxed[T any](value T) Boxed[T] {
+	var b Boxed[T]
+	b.Set(value)
+	return b
+}
+
+// IsZero returns true if the value is unset. It is used by encoding/json
+// (for omitzero) and the yaml marshallers (for omitempty) to drop unset values
+func (o Boxed[T]) IsZero() bool {
+	return o.value == nil
+}
+
+// Reset clears the memory on the value
+func (o *Boxed[T]) Reset() {
+	o.value = nil
+}
+
+// Set updates the value and sets the set flag
+func (o *Boxed[T]) Set(value T) {
+	o.value = &value
+}
+
+// IsSet returns true if the value is set
+func (o Boxed[T]) IsSet() bool {
+	return o.value != nil
+}
+
+// Get returns the value and its set flag
+func (o Boxed[T]) Get() (T, bool) {
+	if o.value == nil {
+		var empty T
+		return empty, false
+	}
+	return *o.value, true
+}
+
+// Value returns the boxed value as a Value
+func (o Boxed[T]) Value() Value[T] {
+	if o.value == nil {
+		return Value[T]{}
+	}
+	return NewValue(*o.value)
+}
+
+// Box converts a Value into a Boxed
+func Box[T any](value Value[T]) Boxed[T] {
+	if v, set := value.Get(); set {
+		return NewBoxed(v)
+	}
+	return Boxed[T]{}
+}
+
+// MarshalJSON outputs the value of the Boxed, if it is set.
+// otherwise, it returns nil
+func (o Boxed[T]) MarshalJSON() ([]byte, error) {
+	if o.value != nil {
+		return marshalJSONValue(*o.value)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON unmarshals a value out of json and safely into our struct
+func (o *Boxed[T]) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 {
+		o.Reset()
+		return nil
+	}
+	var val T
+	if err := json.Unmarshal(data, &val); err != nil {
+		return err
+	}
+	o.Set(val)
+	notifyDecode[T](string(data) == "null")
+	return nil
+}
+
+// MarshalYAML outputs the value of the Boxed, if it is set.
+// otherwise, it returns nil
+func (o Boxed[T]) MarshalYAML() (any, error) {
+	if o.value != nil {
+		return marshalYAMLValue(*o.value)
+	}
+	return nil, nil
+}
+
+// UnmarshalYAML unmarshals a value out of yaml and safely into our struct
+func (o *Boxed[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	var val T
+	if err := unmarshal(&val); err != nil {
+		return err
+	}
+	o.Set(val)
+	// yaml never hands null nodes to an unmarshaler
+	notifyDecode[T](false)
+	return nil
+}
@@ -0,0 +1,160 @@
+package optional_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestBoxed(t *testing.T) {
+	type largeStruct struct {
+		Data [4096]byte
+		Name string
+	}
+
+	t.Run("Unset", func(t *testing.T) {
+		var target optional.Boxed[largeStruct]
+		encounteredValue, encounteredSet := target.Get()
+		expect(t, "set", false, encounteredSet)
+		expect(t, "value.Name", "", encounteredValue.Name)
+	})
+	t.Run("SetLarge", func(t *testing.T) {
+		var target optional.Boxed[largeStruct]
+		expectedValue := largeStruct{Name: "Foo"}
+		expectedValue.Data[4095] = 0xFF
+		target.Set(expectedValue)
+		encounteredValue, encounteredSet := target.Get()
+		expect(t, "set", true, encounteredSet)
+		expect(t, "value.Name", expectedValue.Name, encounteredValue.Name)
+		expect(t, "value.Data[4095]", expectedValue.Data[4095], encounteredValue.Data[4095])
+	})
+	t.Run("CopyIsolation", func(t *testing.T) {
+		original := optional.NewBoxed(5)
+		copied := original
+		copied.Set(10)
+		originalValue, _ := original.Get()
+		copiedValue, _ := copied.Get()
+		expect(t, "original", 5, originalValue)
+		expect(t, "copied", 10, copiedValue)
+		copied.Reset()
+		expect(t, "original set", true, original.IsSet())
+	})
+	t.Run("Conversion", func(t *testing.T) {
+		boxed := optional.Box(optional.NewValue("Foo"))
+		value, set := boxed.Value().Get()
+		expect(t, "set", true, set)
+		expect(t, "value", "Foo", value)
+		expect(t, "unset", false, optional.Box(optional.Value[string]{}).IsSet())
+	})
+	t.Run("JSON", func(t *testing.T) {
+		type testStruct struct {
+			A optional.Boxed[int] `json:"a"`
+			B optional.Boxed[int] `json:"b"`
+		}
+		blob, err := json.Marshal(testStruct{A: optional.NewBoxed(5)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", `{"a":5,"b":null}`, string(blob))
+
+		var observed testStruct
+		if err := json.Unmarshal([]byte(`{"b":7}`), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "a set", false, observed.A.IsSet())
+		b, _ := observed.B.Get()
+		expect(t, "b", 7, b)
+	})
+}
+
+func TestBoxedCodecs(t *testing.T) {
+	t.Run("Text", func(t *testing.T) {
+		text, err := optional.NewBoxed(5).MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "text", "5", string(text))
+		var observed optional.Boxed[int]
+		if err := observed.UnmarshalText([]byte("7")); err != nil {
+			t.Fatal(err)
+		}
+		value, _ := observed.Get()
+		expect(t, "value", 7, value)
+		if err := observed.UnmarshalText([]byte{}); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "set", false, observed.IsSet())
+		if err := observed.UnmarshalText([]byte("Foo")); err == nil {
+			t.Error("expected failure, but got success")
+		}
+	})
+	t.Run("Gob", func(t *testing.T) {
+		for _, expected := range []optional.Boxed[string]{optional.NewBoxed("Foo"), {}} {
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(expected); err != nil {
+				t.Fatal(err)
+			}
+			var observed optional.Boxed[string]
+			if err := gob.NewDecoder(&buf).Decode(&observed); err != nil {
+				t.Fatal(err)
+			}
+			expect(t, "equal", true, optional.Equal(expected.Value(), observed.Value()))
+		}
+	})
+	t.Run("XML", func(t *testing.T) {
+		type testStruct struct {
+			XMLName xml.Name               `xml:"test"`
+			A       optional.Boxed[int]    `xml:"a"`
+			B       optional.Boxed[int]    `xml:"b"`
+			C       optional.Boxed[string] `xml:"c,attr"`
+		}
+		blob, err := xml.Marshal(testStruct{A: optional.NewBoxed(5), C: optional.NewBoxed("Foo")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "xml", `<test c="Foo"><a>5</a></test>`, string(blob))
+		var observed testStruct
+		if err := xml.Unmarshal(blob, &observed); err != nil {
+			t.Fatal(err)
+		}
+		a, _ := observed.A.Get()
+		expect(t, "a", 5, a)
+		expect(t, "b set", false, observed.B.IsSet())
+		c, _ := observed.C.Get()
+		expect(t, "c", "Foo", c)
+	})
+	t.Run("SQL", func(t *testing.T) {
+		observed := optional.NewBoxed(5)
+		if err := observed.Scan(int64(7)); err != nil {
+			t.Fatal(err)
+		}
+		value, _ := observed.Get()
+		expect(t, "value", 7, value)
+		if err := observed.Scan(nil); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "set", false, observed.IsSet())
+
+		driverValue, err := optional.NewBoxed(5).Value().Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "driver value", int64(5), driverValue.(int64))
+	})
+	t.Run("CBOR", func(t *testing.T) {
+		blob, err := optional.Boxed[int]{}.MarshalCBOR()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "cbor", "\xf6", string(blob))
+		observed := optional.NewBoxed(5)
+		if err := observed.UnmarshalCBOR([]byte{0xf6}); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "set", false, observed.IsSet())
+	})
+}
@@ -0,0 +1,96 @@
+package optional
+
+import "encoding/xml"
+
+// MarshalText outputs the value of the Boxed as text, if it is set.
+// otherwise, it returns empty text
+func (o Boxed[T]) MarshalText() ([]byte, error) {
+	return o.Value().MarshalText()
+}
+
+// UnmarshalText unmarshals a value out of text and safely into our struct.
+// empty text resets the value
+func (o *Boxed[T]) UnmarshalText(text []byte) error {
+	var val Value[T]
+	if err := val.UnmarshalText(text); err != nil {
+		return err
+	}
+	*o = Box(val)
+	return nil
+}
+
+// GobEncode outputs the set flag of the Boxed, followed by the value
+// itself if it is set
+func (o Boxed[T]) GobEncode() ([]byte, error) {
+	return o.Value().GobEncode()
+}
+
+// GobDecode decodes a value out of gob and safely into our struct
+func (o *Boxed[T]) GobDecode(data []byte) error {
+	var val Value[T]
+	if err := val.GobDecode(data); err != nil {
+		return err
+	}
+	*o = Box(val)
+	return nil
+}
+
+// MarshalXML outputs the value of the Boxed as an element, if it is set.
+// otherwise, it outputs nothing at all
+func (o Boxed[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return o.Value().MarshalXML(e, start)
+}
+
+// UnmarshalXML unmarshals a value out of an xml element and safely into our struct
+func (o *Boxed[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var val Value[T]
+	if err := val.UnmarshalXML(d, start); err != nil {
+		return err
+	}
+	*o = Box(val)
+	return nil
+}
+
+// MarshalXMLAttr outputs the value of the Boxed as an attribute, if it is set.
+// otherwise, it returns an empty attribute, which omits it
+func (o Boxed[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return o.Value().MarshalXMLAttr(name)
+}
+
+// UnmarshalXMLAttr unmarshals a value out of an xml attribute and safely into our struct
+func (o *Boxed[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	var val Value[T]
+	if err := val.UnmarshalXMLAttr(attr); err != nil {
+		return err
+	}
+	*o = Box(val)
+	return nil
+}
+
+// Scan reads a database/sql column value safely into our struct.
+// SQL NULL resets the value
+func (o *Boxed[T]) Scan(src any) error {
+	var val Value[T]
+	if err := val.Scan(src); err != nil {
+		return err
+	}
+	*o = Box(val)
+	return nil
+}
+
+// MarshalCBOR outputs the value of the Boxed, if it is set.
+// otherwise, it returns CBOR null
+func (o Boxed[T]) MarshalCBOR() ([]byte, error) {
+	return o.Value().MarshalCBOR()
+}
+
+// UnmarshalCBOR unmarshals a value out of CBOR and safely into our struct.
+// CBOR null and undefined both reset the value
+func (o *Boxed[T]) UnmarshalCBOR(data []byte) error {
+	var val Value[T]
+	if err := val.UnmarshalCBOR(data); err != nil {
+		return err
+	}
+	*o = Box(val)
+	return nil
+}
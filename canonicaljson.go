@@ -0,0 +1,155 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"unicode/utf16"
+)
+
+// MarshalCanonicalJSON marshals v to json in a canonical form, so documents
+// containing optionals encode to the same bytes on every run: object keys
+// are sorted (by UTF-16 code units), numbers use a fixed shortest-form
+// formatting, strings are not HTML-escaped, and no insignificant whitespace
+// is emitted. This follows the rules of RFC 8785 (the JSON Canonicalization
+// Scheme), and is suitable for content addressing and signature computation.
+// As the scheme requires, numbers are written as the float64 closest to
+// them, so integers beyond 2^53 lose precision.
+func MarshalCanonicalJSON(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeCanonicalJSON(&buf, doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeCanonicalJSON(buf *bytes.Buffer, doc any) error {
+	switch v := doc.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		if v {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+	case json.Number:
+		return writeCanonicalNumber(buf, v)
+	case string:
+		return writeCanonicalString(buf, v)
+	case []any:
+		buf.WriteByte('[')
+		for i, elem := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalJSON(buf, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return lessUTF16(keys[i], keys[j])
+		})
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalString(buf, key); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := writeCanonicalJSON(buf, v[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("optional: unexpected json value of type %T", doc)
+	}
+	return nil
+}
+
+// writeCanonicalNumber writes n as ECMAScript formats the float64 closest
+// to it, as RFC 8785 requires, so integers beyond 2^53 are rounded. this is
+// also how encoding/json formats a float64. numbers out of the range of a
+// float64 are an error
+func writeCanonicalNumber(buf *bytes.Buffer, n json.Number) error {
+	f, err := strconv.ParseFloat(n.String(), 64)
+	if err != nil {
+		return fmt.Errorf("optional: number %s cannot be represented canonically: %w", n, err)
+	}
+	if f == 0 {
+		// negative zero is written as 0
+		f = 0
+	}
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
+// writeCanonicalString writes s as a json string, escaping only what
+// RFC 8785 requires: quotes, backslashes, and control characters
+func writeCanonicalString(buf *bytes.Buffer, s string) error {
+	const hex = "0123456789abcdef"
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case r == '\b':
+			buf.WriteString(`\b`)
+		case r == '\t':
+			buf.WriteString(`\t`)
+		case r == '\n':
+			buf.WriteString(`\n`)
+		case r == '\f':
+			buf.WriteString(`\f`)
+		case r == '\r':
+			buf.WriteString(`\r`)
+		case r < 0x20:
+			buf.WriteString(`\u00`)
+			buf.WriteByte(hex[r>>4])
+			buf.WriteByte(hex[r&0xf])
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+	return nil
+}
+
+// lessUTF16 compares two strings by their UTF-16 code units
+func lessUTF16(a, b string) bool {
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
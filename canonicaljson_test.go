@@ -0,0 +1,111 @@
+package optional_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestMarshalCanonicalJSON(t *testing.T) {
+	type testDocument struct {
+		Zeta   optional.Value[string]          `json:"zeta"`
+		Alpha  optional.Value[float64]         `json:"alpha"`
+		Unset  optional.Value[int]             `json:"unset"`
+		Nested map[string]optional.Value[int]  `json:"nested"`
+		Raw    optional.Value[json.RawMessage] `json:"raw"`
+	}
+
+	doc := testDocument{
+		Zeta:  optional.NewValue("<a&b>"),
+		Alpha: optional.NewValue(1e21),
+		Nested: map[string]optional.Value[int]{
+			"b": optional.NewValue(2),
+			"a": {},
+		},
+		Raw: optional.NewValue(json.RawMessage(`{ "y": 1.50, "x": [1.0, -0.0, 12345678901234567890] }`)),
+	}
+
+	observed, err := optional.MarshalCanonicalJSON(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"alpha":1e+21,"nested":{"a":null,"b":2},"raw":{"x":[1,0,12345678901234567000],"y":1.5},"unset":null,"zeta":"<a&b>"}`
+	expect(t, "json", expected, string(observed))
+
+	t.Run("Stable", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			again, err := optional.MarshalCanonicalJSON(doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expect(t, "json", string(observed), string(again))
+		}
+	})
+	t.Run("KeyOrder", func(t *testing.T) {
+		// U+FF61 sorts before U+1F600 by code point, but after it by
+		// UTF-16 code unit, as the latter is encoded as a surrogate pair
+		observed, err := optional.MarshalCanonicalJSON(map[string]int{"\U0001F600": 1, "｡": 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", "{\"\U0001F600\":1,\"｡\":2}", string(observed))
+	})
+	t.Run("Numbers", func(t *testing.T) {
+		// the test vectors of RFC 8785, appendix B
+		tests := map[uint64]string{
+			0x0000000000000000: "0",
+			0x8000000000000000: "0",
+			0x0000000000000001: "5e-324",
+			0x8000000000000001: "-5e-324",
+			0x7fefffffffffffff: "1.7976931348623157e+308",
+			0xffefffffffffffff: "-1.7976931348623157e+308",
+			0x4340000000000000: "9007199254740992",
+			0xc340000000000000: "-9007199254740992",
+			0x4430000000000000: "295147905179352830000",
+			0x44b52d02c7e14af5: "9.999999999999997e+22",
+			0x44b52d02c7e14af6: "1e+23",
+			0x44b52d02c7e14af7: "1.0000000000000001e+23",
+			0x444b1ae4d6e2ef4e: "999999999999999700000",
+			0x444b1ae4d6e2ef4f: "999999999999999900000",
+			0x444b1ae4d6e2ef50: "1e+21",
+			0x3eb0c6f7a0b5ed8c: "9.999999999999997e-7",
+			0x3eb0c6f7a0b5ed8d: "0.000001",
+			0x41b3de4355555553: "333333333.3333332",
+			0x41b3de4355555554: "333333333.33333325",
+			0x41b3de4355555555: "333333333.3333333",
+			0x41b3de4355555556: "333333333.3333334",
+			0x41b3de4355555557: "333333333.33333343",
+			0xbecbf647612f3696: "-0.0000033333333333333333",
+			0x43143ff3c1cb0959: "1424953923781206.2",
+		}
+		for bits, expected := range tests {
+			observed, err := optional.MarshalCanonicalJSON(math.Float64frombits(bits))
+			if err != nil {
+				t.Fatal(err)
+			}
+			expect(t, expected, expected, string(observed))
+		}
+
+		// integers beyond 2^53 round to the closest float64
+		observed, err := optional.MarshalCanonicalJSON(json.RawMessage(`[9007199254740993, -0.0, 1E400]`))
+		if err == nil {
+			t.Fatalf("expected failure, but got %s", observed)
+		}
+		observed, err = optional.MarshalCanonicalJSON(json.RawMessage(`[9007199254740993, -0.0]`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", "[9007199254740992,0]", string(observed))
+	})
+	t.Run("Strings", func(t *testing.T) {
+		// the string of RFC 8785, section 3.2.3, with characters that
+		// encoding/json would escape
+		observed, err := optional.MarshalCanonicalJSON(json.RawMessage(`"\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/<>&\u2028\u2029"`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", "\"€$\\u000f\\nA'B\\\"\\\\\\\\\\\"/<>&\u2028\u2029\"", string(observed))
+	})
+}
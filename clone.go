@@ -0,0 +1,120 @@
+package optional
+
+import "reflect"
+
+// Cloner is implemented by types which know how to deep copy themselves.
+// Clone uses it in preference to copying a value by reflection
+type Cloner[T any] interface {
+	Clone() T
+}
+
+// Clone returns a deep copy of the Value, so that it can be handed to
+// another goroutine or kept as a snapshot without sharing memory with o.
+//
+// The value is copied by T's own Clone method, if T (or *T) implements
+// Cloner[T]. otherwise, slices, maps, pointers, arrays, interfaces, and the
+// exported fields of structs are copied recursively, using the Clone method
+// of any nested value that has one. Unexported struct fields, channels, and
+// functions are copied as they are.
+func (o Value[T]) Clone() Value[T] {
+	if !o.set {
+		return o
+	}
+	if cloner, ok := asInterface[Cloner[T]](&o.value); ok {
+		return NewValue(cloner.Clone())
+	}
+
+	c := deepCopier{seen: make(map[uintptr]reflect.Value)}
+	var value T
+	reflect.ValueOf(&value).Elem().Set(c.clone(reflect.ValueOf(&o.value).Elem()))
+	return NewValue(value)
+}
+
+type deepCopier struct {
+	// seen maps the pointers already copied to their copies, so shared and
+	// cyclic structures are copied once
+	seen map[uintptr]reflect.Value
+}
+
+func (c *deepCopier) clone(v reflect.Value) reflect.Value {
+	if out, ok := c.cloneMethod(v); ok {
+		return out
+	}
+
+	t := v.Type()
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		if out, ok := c.seen[v.Pointer()]; ok {
+			return out
+		}
+		out := reflect.New(t.Elem())
+		c.seen[v.Pointer()] = out
+		out.Elem().Set(c.clone(v.Elem()))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(t).Elem()
+		out.Set(c.clone(v.Elem()))
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(t, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(c.clone(v.Index(i)))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(t).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(c.clone(v.Index(i)))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(t, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(c.clone(iter.Key()), c.clone(iter.Value()))
+		}
+		return out
+	case reflect.Struct:
+		out := reflect.New(t).Elem()
+		out.Set(v)
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				out.Field(i).Set(c.clone(v.Field(i)))
+			}
+		}
+		return out
+	}
+	return v
+}
+
+// cloneMethod copies v with its Clone method, if it has one returning its
+// own type
+func (c *deepCopier) cloneMethod(v reflect.Value) (reflect.Value, bool) {
+	if !v.CanInterface() {
+		return reflect.Value{}, false
+	}
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return reflect.Value{}, false
+	}
+	method := v.MethodByName("Clone")
+	if !method.IsValid() {
+		return reflect.Value{}, false
+	}
+	mt := method.Type()
+	if mt.NumIn() != 0 || mt.NumOut() != 1 || mt.Out(0) != v.Type() {
+		return reflect.Value{}, false
+	}
+	return method.Call(nil)[0], true
+}
@@ -0,0 +1,86 @@
+package optional_test
+
+import (
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+type cloneNode struct {
+	Name     string
+	Tags     []string
+	Attrs    map[string]int
+	Next     *cloneNode
+	Extra    any
+	Optional optional.Value[[]int]
+}
+
+type countingCloner struct {
+	calls *int
+	data  []int
+}
+
+func (c countingCloner) Clone() countingCloner {
+	*c.calls++
+	return countingCloner{calls: c.calls, data: append([]int(nil), c.data...)}
+}
+
+func TestClone(t *testing.T) {
+	t.Run("Unset", func(t *testing.T) {
+		expect(t, "set", false, optional.Value[[]int]{}.Clone().IsSet())
+	})
+	t.Run("NilInterface", func(t *testing.T) {
+		clone := optional.NewValue[any](nil).Clone()
+		expect(t, "set", true, clone.IsSet())
+		expect(t, "nil", true, clone.MustGet() == nil)
+	})
+	t.Run("Slice", func(t *testing.T) {
+		orig := optional.NewValue([]int{1, 2, 3})
+		clone := orig.Clone()
+		clone.MustGet()[0] = 10
+		expect(t, "original", 1, orig.MustGet()[0])
+		expect(t, "clone", 10, clone.MustGet()[0])
+	})
+	t.Run("NilSlice", func(t *testing.T) {
+		clone := optional.NewValue[[]int](nil).Clone()
+		expect(t, "set", true, clone.IsSet())
+		expect(t, "nil", true, clone.MustGet() == nil)
+	})
+	t.Run("Struct", func(t *testing.T) {
+		node := &cloneNode{
+			Name:     "a",
+			Tags:     []string{"x"},
+			Attrs:    map[string]int{"k": 1},
+			Extra:    []string{"e"},
+			Optional: optional.NewValue([]int{1}),
+		}
+		node.Next = node // cycles are copied once
+		orig := optional.NewValue(node)
+		clone := orig.Clone().MustGet()
+
+		clone.Tags[0] = "y"
+		clone.Attrs["k"] = 2
+		clone.Extra.([]string)[0] = "f"
+		clone.Optional.MustGet()[0] = 2
+		expect(t, "tags", "x", node.Tags[0])
+		expect(t, "attrs", 1, node.Attrs["k"])
+		expect(t, "extra", "e", node.Extra.([]string)[0])
+		expect(t, "optional", 1, node.Optional.MustGet()[0])
+		expect(t, "distinct", true, clone != node)
+		expect(t, "cycle", true, clone.Next == clone)
+	})
+	t.Run("Cloner", func(t *testing.T) {
+		calls := 0
+		orig := optional.NewValue(countingCloner{calls: &calls, data: []int{1}})
+		clone := orig.Clone()
+		clone.MustGet().data[0] = 2
+		expect(t, "calls", 1, calls)
+		expect(t, "original", 1, orig.MustGet().data[0])
+	})
+	t.Run("NestedCloner", func(t *testing.T) {
+		calls := 0
+		orig := optional.NewValue([]countingCloner{{calls: &calls, data: []int{1}}})
+		orig.Clone()
+		expect(t, "calls", 1, calls)
+	})
+}
@@ -0,0 +1,74 @@
+// Command optional-migrate rewrites pointer-optional struct fields to
+// optional.Value.
+//
+// Usage:
+//
+//	optional-migrate -fields Type.Field[,Type.Field...] [-w] file.go...
+//
+// The selected fields have their type changed from *T to optional.Value[T],
+// and the most common call-site patterns are updated to match:
+//
+//	x.Field == nil    becomes    !x.Field.IsSet()
+//	x.Field != nil    becomes    x.Field.IsSet()
+//	x.Field = &v      becomes    x.Field.Set(v)
+//	x.Field = nil     becomes    x.Field.Reset()
+//	Type{Field: &v}   becomes    Type{Field: optional.NewValue(v)}
+//	Type{Field: nil}  becomes    Type{}
+//	*x.Field          becomes    x.Field.MustGet()
+//
+// Call sites are matched by field name only, as the tool does not type-check
+// the code it rewrites. Any other use of a selected field, such as an
+// assignment through a dereference, is reported on standard error for
+// manual review.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	fieldList := flag.String("fields", "", "comma-separated list of Type.Field selectors to migrate")
+	write := flag.Bool("w", false, "write result to (source) file instead of stdout")
+	flag.Parse()
+
+	fields, err := parseFieldSet(*fieldList)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "optional-migrate:", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	failed := false
+	for _, filename := range flag.Args() {
+		if err := migrateFile(filename, fields, *write); err != nil {
+			fmt.Fprintln(os.Stderr, "optional-migrate:", err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func migrateFile(filename string, fields fieldSet, write bool) error {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	out, warnings, err := migrate(filename, src, fields)
+	for _, w := range warnings {
+		fmt.Fprintln(os.Stderr, w)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !write {
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+	return os.WriteFile(filename, out, 0o644)
+}
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const importPath = "github.com/heucuva/optional"
+
+// fieldSet is the set of struct fields selected for migration, keyed by
+// struct type name and then by field name
+type fieldSet map[string]map[string]bool
+
+// parseFieldSet parses a comma-separated list of Type.Field selectors
+func parseFieldSet(list string) (fieldSet, error) {
+	fields := make(fieldSet)
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		typeName, fieldName, ok := strings.Cut(item, ".")
+		if !ok || typeName == "" || fieldName == "" {
+			return nil, fmt.Errorf("invalid field selector %q: expected Type.Field", item)
+		}
+		if fields[typeName] == nil {
+			fields[typeName] = make(map[string]bool)
+		}
+		fields[typeName][fieldName] = true
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields selected")
+	}
+	return fields, nil
+}
+
+// has returns true if any selected type contains the named field
+func (f fieldSet) has(fieldName string) bool {
+	for _, names := range f {
+		if names[fieldName] {
+			return true
+		}
+	}
+	return false
+}
+
+type edit struct {
+	start, end int
+	text       string
+}
+
+type migrator struct {
+	fset     *token.FileSet
+	file     *token.File
+	src      []byte
+	fields   fieldSet
+	edits    []edit
+	warnings []string
+}
+
+// migrate rewrites the selected pointer fields in src to optional.Value and
+// updates the nil-checks and assignments that use them. Uses that cannot be
+// rewritten mechanically are returned as warnings, in file:line:col form.
+func migrate(filename string, src []byte, fields fieldSet) ([]byte, []string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := &migrator{
+		fset:   fset,
+		file:   fset.File(f.Pos()),
+		src:    src,
+		fields: fields,
+	}
+	ast.Inspect(f, m.visit)
+
+	if len(m.edits) == 0 {
+		return src, m.warnings, nil
+	}
+	m.addImport(f)
+
+	out, err := format.Source(m.apply())
+	if err != nil {
+		return nil, m.warnings, err
+	}
+	return out, m.warnings, nil
+}
+
+func (m *migrator) visit(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.TypeSpec:
+		st, ok := n.Type.(*ast.StructType)
+		if !ok || m.fields[n.Name.Name] == nil {
+			return true
+		}
+		m.rewriteStruct(n.Name.Name, st)
+		return false
+
+	case *ast.CompositeLit:
+		names := m.fields[typeName(n.Type)]
+		if names == nil {
+			return true
+		}
+		for i, elt := range n.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				ast.Inspect(elt, m.visit)
+				continue
+			}
+			if key, ok := kv.Key.(*ast.Ident); ok && names[key.Name] {
+				if addr, ok := kv.Value.(*ast.UnaryExpr); ok && addr.Op == token.AND {
+					m.replace(kv.Value, "optional.NewValue("+m.text(addr.X)+")")
+					continue
+				}
+				if isNil(kv.Value) {
+					m.removeElt(n, i)
+					continue
+				}
+				m.warn(kv.Value, "cannot rewrite initializer of migrated field %s", key.Name)
+			}
+			ast.Inspect(kv.Value, m.visit)
+		}
+		return false
+
+	case *ast.BinaryExpr:
+		if n.Op != token.EQL && n.Op != token.NEQ {
+			return true
+		}
+		field, other := n.X, n.Y
+		if isNil(field) {
+			field, other = other, field
+		}
+		if !isNil(other) || !m.isField(field) {
+			return true
+		}
+		text := m.text(field) + ".IsSet()"
+		if n.Op == token.EQL {
+			text = "!" + text
+		}
+		m.replace(n, text)
+		return false
+
+	case *ast.AssignStmt:
+		if m.assignsThroughField(n.Lhs...) {
+			m.warn(n, "assignment through migrated field needs manual review")
+			for _, e := range n.Rhs {
+				ast.Inspect(e, m.visit)
+			}
+			return false
+		}
+		if n.Tok != token.ASSIGN || len(n.Lhs) != 1 || len(n.Rhs) != 1 || !m.isField(n.Lhs[0]) {
+			return true
+		}
+		field := m.text(n.Lhs[0])
+		switch rhs := n.Rhs[0].(type) {
+		case *ast.UnaryExpr:
+			if rhs.Op == token.AND {
+				m.replace(n, field+".Set("+m.text(rhs.X)+")")
+				return false
+			}
+		case *ast.Ident:
+			if isNil(rhs) {
+				m.replace(n, field+".Reset()")
+				return false
+			}
+		}
+		m.warn(n, "cannot rewrite assignment to migrated field")
+		ast.Inspect(n.Rhs[0], m.visit)
+		return false
+
+	case *ast.IncDecStmt:
+		if m.assignsThroughField(n.X) {
+			m.warn(n, "assignment through migrated field needs manual review")
+			return false
+		}
+
+	case *ast.UnaryExpr:
+		if n.Op == token.AND && m.assignsThroughField(n.X) {
+			m.warn(n, "address of dereferenced migrated field needs manual review")
+			return false
+		}
+
+	case *ast.StarExpr:
+		if m.isField(n.X) {
+			// reading through a nil pointer panics, and so does MustGet
+			m.replace(n, m.text(n.X)+".MustGet()")
+			return false
+		}
+
+	case *ast.SelectorExpr:
+		if m.isField(n) {
+			m.warn(n, "use of migrated field needs manual review")
+		}
+	}
+	return true
+}
+
+func (m *migrator) rewriteStruct(name string, st *ast.StructType) {
+	for _, field := range st.Fields.List {
+		for _, ident := range field.Names {
+			if !m.fields[name][ident.Name] {
+				continue
+			}
+			ptr, ok := field.Type.(*ast.StarExpr)
+			if !ok {
+				m.warn(field.Type, "field %s.%s is not a pointer", name, ident.Name)
+				continue
+			}
+			if len(field.Names) > 1 {
+				m.warn(field.Type, "field %s.%s shares its declaration with other fields", name, ident.Name)
+				continue
+			}
+			m.replace(field.Type, "optional.Value["+m.text(ptr.X)+"]")
+		}
+	}
+}
+
+// assignsThroughField returns true if any of exprs is a dereference of a
+// migrated field, which cannot be written to once it is an optional.Value
+func (m *migrator) assignsThroughField(exprs ...ast.Expr) bool {
+	for _, e := range exprs {
+		for {
+			paren, ok := e.(*ast.ParenExpr)
+			if !ok {
+				break
+			}
+			e = paren.X
+		}
+		if star, ok := e.(*ast.StarExpr); ok && m.isField(star.X) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeElt removes the i-th element of lit along with its separator
+func (m *migrator) removeElt(lit *ast.CompositeLit, i int) {
+	start := m.file.Offset(lit.Elts[i].Pos())
+	end := m.file.Offset(lit.Elts[i].End())
+	if i+1 < len(lit.Elts) {
+		end = m.file.Offset(lit.Elts[i+1].Pos())
+	} else if rest := m.src[end:m.file.Offset(lit.Rbrace)]; bytes.HasPrefix(bytes.TrimLeft(rest, " \t\r\n"), []byte(",")) {
+		end += bytes.IndexByte(rest, ',') + 1
+	}
+	// a comma left before the closing brace is still valid, and is removed
+	// by formatting
+	m.edits = append(m.edits, edit{start: start, end: end})
+}
+
+func (m *migrator) isField(e ast.Expr) bool {
+	sel, ok := e.(*ast.SelectorExpr)
+	return ok && m.fields.has(sel.Sel.Name)
+}
+
+func (m *migrator) addImport(f *ast.File) {
+	for _, imp := range f.Imports {
+		if path, _ := strconv.Unquote(imp.Path.Value); path == importPath {
+			return
+		}
+	}
+
+	line := strconv.Quote(importPath)
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		if gen.Lparen.IsValid() {
+			pos := m.file.Offset(gen.Lparen) + 1
+			m.edits = append(m.edits, edit{start: pos, end: pos, text: "\n" + line})
+		} else {
+			spec := gen.Specs[0]
+			m.replace(spec, "(\n"+m.text(spec)+"\n\n"+line+"\n)")
+		}
+		return
+	}
+
+	pos := m.file.Offset(f.Name.End())
+	m.edits = append(m.edits, edit{start: pos, end: pos, text: "\n\nimport " + line})
+}
+
+func (m *migrator) apply() []byte {
+	sort.SliceStable(m.edits, func(i, j int) bool {
+		return m.edits[i].start > m.edits[j].start
+	})
+	out := append([]byte(nil), m.src...)
+	for _, e := range m.edits {
+		var buf bytes.Buffer
+		buf.Write(out[:e.start])
+		buf.WriteString(e.text)
+		buf.Write(out[e.end:])
+		out = buf.Bytes()
+	}
+	return out
+}
+
+func (m *migrator) replace(n ast.Node, text string) {
+	m.edits = append(m.edits, edit{
+		start: m.file.Offset(n.Pos()),
+		end:   m.file.Offset(n.End()),
+		text:  text,
+	})
+}
+
+func (m *migrator) text(n ast.Node) string {
+	return string(m.src[m.file.Offset(n.Pos()):m.file.Offset(n.End())])
+}
+
+func (m *migrator) warn(n ast.Node, format string, args ...any) {
+	pos := m.fset.Position(n.Pos())
+	m.warnings = append(m.warnings, fmt.Sprintf("%s: %s", pos, fmt.Sprintf(format, args...)))
+}
+
+func typeName(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	}
+	return ""
+}
+
+func isNil(e ast.Expr) bool {
+	ident, ok := e.(*ast.Ident)
+	return ok && ident.Name == "nil"
+}
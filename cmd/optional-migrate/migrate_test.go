@@ -0,0 +1,238 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFieldSet(t *testing.T) {
+	fields, err := parseFieldSet("Config.Name, Config.Port,Other.Value")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fields["Config"]["Name"] || !fields["Config"]["Port"] || !fields["Other"]["Value"] {
+		t.Fatalf("unexpected field set %v", fields)
+	}
+
+	for _, list := range []string{"", "Config", "Config.", ".Name"} {
+		if _, err := parseFieldSet(list); err == nil {
+			t.Fatalf("expected failure for %q, but got success", list)
+		}
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	const src = `package example
+
+import "fmt"
+
+type Config struct {
+	Name  *string
+	Port  *int
+	Debug bool
+}
+
+func Use(c *Config, name string) {
+	if c.Name == nil {
+		c.Name = &name
+	}
+	if nil != c.Port {
+		fmt.Println(*c.Port)
+	}
+	c.Port = nil
+}
+
+func Build(port int) Config {
+	return Config{Port: &port, Debug: true}
+}
+`
+	const expected = `package example
+
+import (
+	"fmt"
+
+	"github.com/heucuva/optional"
+)
+
+type Config struct {
+	Name  optional.Value[string]
+	Port  optional.Value[int]
+	Debug bool
+}
+
+func Use(c *Config, name string) {
+	if !c.Name.IsSet() {
+		c.Name.Set(name)
+	}
+	if c.Port.IsSet() {
+		fmt.Println(c.Port.MustGet())
+	}
+	c.Port.Reset()
+}
+
+func Build(port int) Config {
+	return Config{Port: optional.NewValue(port), Debug: true}
+}
+`
+	fields, err := parseFieldSet("Config.Name,Config.Port")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, warnings, err := migrate("example.go", []byte(src), fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if observed := string(out); observed != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, observed)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("unexpected warnings %q", warnings)
+	}
+}
+
+func TestMigrateNilInitializers(t *testing.T) {
+	const src = `package example
+
+type Config struct {
+	Name *string
+	Port *int
+}
+
+var (
+	a = Config{Name: nil, Port: nil}
+	b = Config{Port: nil}
+	c = &Config{
+		Port: nil,
+	}
+)
+`
+	const expected = `package example
+
+import "github.com/heucuva/optional"
+
+type Config struct {
+	Name optional.Value[string]
+	Port optional.Value[int]
+}
+
+var (
+	a = Config{}
+	b = Config{}
+	c = &Config{}
+)
+`
+	fields, err := parseFieldSet("Config.Name,Config.Port")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, _, err := migrate("example.go", []byte(src), fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if observed := string(out); observed != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, observed)
+	}
+
+	t.Run("Separators", func(t *testing.T) {
+		const src = `package example
+
+type Config struct {
+	Name *string
+	Port int
+}
+
+var (
+	a = Config{Name: nil, Port: 1}
+	b = Config{Port: 1, Name: nil}
+	c = Config{
+		Port: 1,
+		Name: nil,
+	}
+)
+`
+		out, _, err := migrate("example.go", []byte(src), fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, expected := range []string{
+			"a = Config{Port: 1}\n",
+			"b = Config{Port: 1}\n",
+			"c = Config{\n\t\tPort: 1,\n\t}\n",
+		} {
+			if !strings.Contains(string(out), expected) {
+				t.Fatalf("expected %q in:\n%s", expected, out)
+			}
+		}
+	})
+}
+
+func TestMigrateDereferences(t *testing.T) {
+	const src = `package example
+
+type Config struct {
+	Port *int
+}
+
+func Use(c *Config) int {
+	port := *c.Port + 1
+	*c.Port = port
+	(*c.Port)++
+	p := &*c.Port
+	_ = p
+	return *c.Port
+}
+`
+	fields, err := parseFieldSet("Config.Port")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, warnings, err := migrate("example.go", []byte(src), fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"port := c.Port.MustGet() + 1\n",
+		"*c.Port = port\n",
+		"(*c.Port)++\n",
+		"p := &*c.Port\n",
+		"return c.Port.MustGet()\n",
+	} {
+		if !strings.Contains(string(out), expected) {
+			t.Fatalf("expected %q in:\n%s", expected, out)
+		}
+	}
+	expectedWarnings := []string{
+		"example.go:9:2: assignment through",
+		"example.go:10:2: assignment through",
+		"example.go:11:7: address of",
+	}
+	if len(warnings) != len(expectedWarnings) {
+		t.Fatalf("unexpected warnings %q", warnings)
+	}
+	for i, prefix := range expectedWarnings {
+		if !strings.HasPrefix(warnings[i], prefix) {
+			t.Errorf("expected warning %d to start with %q, got %q", i, prefix, warnings[i])
+		}
+	}
+}
+
+func TestMigrateNoImports(t *testing.T) {
+	const src = `package example
+
+type Config struct {
+	Name *string
+}
+`
+	fields, err := parseFieldSet("Config.Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, _, err := migrate("example.go", []byte(src), fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "import \"github.com/heucuva/optional\"\n") ||
+		!strings.Contains(string(out), "Name optional.Value[string]") {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const importPath = "github.com/heucuva/optional"
+
+// goTypeFor maps a SQL column type to a Go type and the import it requires
+func goTypeFor(sqlType string) (string, string) {
+	base := sqlType
+	if i := strings.IndexByte(base, '('); i >= 0 {
+		base = strings.TrimSpace(base[:i])
+	}
+	if strings.HasSuffix(base, "[]") {
+		elem, imp := goTypeFor(strings.TrimSuffix(base, "[]"))
+		return "[]" + elem, imp
+	}
+
+	switch base {
+	case "bool", "boolean":
+		return "bool", ""
+	case "smallint", "int2", "smallserial":
+		return "int16", ""
+	case "int", "integer", "int4", "serial", "mediumint":
+		return "int32", ""
+	case "bigint", "int8", "bigserial":
+		return "int64", ""
+	case "real", "float4":
+		return "float32", ""
+	case "double", "double precision", "float", "float8":
+		return "float64", ""
+	case "bytea", "blob", "binary", "varbinary", "longblob", "mediumblob", "tinyblob":
+		return "[]byte", ""
+	case "date", "datetime", "time", "timestamp", "timestamptz",
+		"timestamp with time zone", "timestamp without time zone",
+		"time with time zone", "time without time zone":
+		return "time.Time", "time"
+	case "json", "jsonb":
+		return "json.RawMessage", "encoding/json"
+	default:
+		// text, varchar, uuid, numeric and anything unrecognized are
+		// carried as strings to avoid losing precision or information
+		return "string", ""
+	}
+}
+
+// generate renders the tables as Go row structs
+func generate(pkg string, tables []table) ([]byte, error) {
+	imports := make(map[string]bool)
+	var body bytes.Buffer
+	for _, t := range tables {
+		fmt.Fprintf(&body, "\n// %s is a row of the %s table\n", goIdent(t.name), t.name)
+		fmt.Fprintf(&body, "type %s struct {\n", goIdent(t.name))
+		for _, col := range t.columns {
+			goType, imp := goTypeFor(col.sqlType)
+			if imp != "" {
+				imports[imp] = true
+			}
+			if col.nullable {
+				goType = "optional.Value[" + goType + "]"
+				imports[importPath] = true
+			}
+			fmt.Fprintf(&body, "\t%s %s `db:%q json:%q`\n", goIdent(col.name), goType, col.name, col.name)
+		}
+		body.WriteString("}\n")
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by optional-sqlgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n", pkg)
+	if len(imports) > 0 {
+		var std []string
+		for path := range imports {
+			if path != importPath {
+				std = append(std, path)
+			}
+		}
+		sort.Strings(std)
+		out.WriteString("\nimport (\n")
+		for _, path := range std {
+			fmt.Fprintf(&out, "\t%q\n", path)
+		}
+		if imports[importPath] {
+			if len(std) > 0 {
+				out.WriteString("\n")
+			}
+			fmt.Fprintf(&out, "\t%q\n", importPath)
+		}
+		out.WriteString(")\n")
+	}
+	out.Write(body.Bytes())
+	return format.Source(out.Bytes())
+}
+
+// commonInitialisms are rendered in upper case, following Go naming style
+var commonInitialisms = map[string]bool{
+	"ID": true, "URL": true, "URI": true, "UUID": true, "IP": true,
+	"HTTP": true, "JSON": true, "API": true, "SQL": true,
+}
+
+// goIdent converts a snake_case SQL identifier to an exported Go identifier
+func goIdent(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if upper := strings.ToUpper(part); commonInitialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	ident := b.String()
+	if ident == "" || !unicode.IsLetter([]rune(ident)[0]) {
+		ident = "X" + ident
+	}
+	return ident
+}
@@ -0,0 +1,82 @@
+// Command optional-sqlgen generates row structs from a SQL schema, using
+// optional.Value for nullable columns.
+//
+// Usage:
+//
+//	optional-sqlgen [-pkg name] [-format ddl|infoschema] [-o out.go] schema...
+//
+// With -format ddl (the default), the inputs are read as CREATE TABLE
+// statements. With -format infoschema, the inputs are read as CSV dumps of
+// information_schema.columns with a header row naming at least the
+// table_name, column_name, data_type, and is_nullable columns.
+//
+// Each table becomes a struct named after it, with one field per column
+// carrying matching db and json tags. Columns declared NOT NULL (or as part
+// of a PRIMARY KEY) become plain fields; all others become optional.Value.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	pkg := flag.String("pkg", "models", "package name of the generated file")
+	format := flag.String("format", "ddl", "input format: ddl or infoschema")
+	output := flag.String("o", "", "write result to file instead of stdout")
+	flag.Parse()
+
+	var parse func(io.Reader) ([]table, error)
+	switch *format {
+	case "ddl":
+		parse = parseDDL
+	case "infoschema":
+		parse = parseInfoSchema
+	default:
+		fmt.Fprintf(os.Stderr, "optional-sqlgen: unknown format %q\n", *format)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var tables []table
+	for _, filename := range flag.Args() {
+		t, err := parseFile(filename, parse)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "optional-sqlgen:", err)
+			os.Exit(1)
+		}
+		tables = append(tables, t...)
+	}
+
+	out, err := generate(*pkg, tables)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "optional-sqlgen:", err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(out)
+	} else {
+		err = os.WriteFile(*output, out, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "optional-sqlgen:", err)
+		os.Exit(1)
+	}
+}
+
+func parseFile(filename string, parse func(io.Reader) ([]table, error)) ([]table, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tables, err := parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return tables, nil
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+type table struct {
+	name    string
+	columns []column
+}
+
+type column struct {
+	name     string
+	sqlType  string
+	nullable bool
+}
+
+var (
+	lineCommentRE  = regexp.MustCompile(`--[^\n]*`)
+	blockCommentRE = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	createTableRE  = regexp.MustCompile(`(?i)\bCREATE\s+(?:TEMP(?:ORARY)?\s+|UNLOGGED\s+)?TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?([^\s(]+)\s*\(`)
+)
+
+// tableConstraintKeywords start a table-level constraint instead of a
+// column definition
+var tableConstraintKeywords = map[string]bool{
+	"CONSTRAINT": true,
+	"PRIMARY":    true,
+	"UNIQUE":     true,
+	"FOREIGN":    true,
+	"CHECK":      true,
+	"KEY":        true,
+	"INDEX":      true,
+	"EXCLUDE":    true,
+}
+
+// columnConstraintKeywords end the type portion of a column definition
+var columnConstraintKeywords = map[string]bool{
+	"NOT":            true,
+	"NULL":           true,
+	"PRIMARY":        true,
+	"DEFAULT":        true,
+	"REFERENCES":     true,
+	"UNIQUE":         true,
+	"CHECK":          true,
+	"CONSTRAINT":     true,
+	"COLLATE":        true,
+	"GENERATED":      true,
+	"AUTO_INCREMENT": true,
+	"AUTOINCREMENT":  true,
+	"COMMENT":        true,
+}
+
+// parseDDL reads the CREATE TABLE statements out of a SQL schema
+func parseDDL(r io.Reader) ([]table, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := blockCommentRE.ReplaceAllString(lineCommentRE.ReplaceAllString(string(src), ""), "")
+
+	var tables []table
+	for {
+		loc := createTableRE.FindStringSubmatchIndex(text)
+		if loc == nil {
+			break
+		}
+		name := unquoteIdent(lastIdentPart(text[loc[2]:loc[3]]))
+		body, rest, ok := matchParen(text[loc[1]:])
+		if !ok {
+			return nil, fmt.Errorf("unterminated CREATE TABLE %s", name)
+		}
+		text = rest
+
+		t := table{name: name}
+		for _, item := range splitTopLevel(body) {
+			col, ok, err := parseColumn(item)
+			if err != nil {
+				return nil, fmt.Errorf("table %s: %w", name, err)
+			}
+			if ok {
+				t.columns = append(t.columns, col)
+			}
+		}
+		t.applyPrimaryKey(body)
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+// matchParen returns the text up to the parenthesis closing an already
+// opened one, and the text following it
+func matchParen(s string) (string, string, bool) {
+	depth := 1
+	var quote rune
+	for i, c := range s {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return s[:i], s[i+1:], true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// splitTopLevel splits s on the commas not nested in parentheses or quotes
+func splitTopLevel(s string) []string {
+	var (
+		items []string
+		depth int
+		quote rune
+		start int
+	)
+	for i, c := range s {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, s[start:])
+}
+
+func parseColumn(item string) (column, bool, error) {
+	fields := strings.Fields(item)
+	if len(fields) == 0 || tableConstraintKeywords[strings.ToUpper(fields[0])] {
+		return column{}, false, nil
+	}
+	if len(fields) < 2 {
+		return column{}, false, fmt.Errorf("column %s has no type", fields[0])
+	}
+
+	col := column{
+		name:     unquoteIdent(fields[0]),
+		nullable: true,
+	}
+
+	var typeWords []string
+	inType := true
+	for i, word := range fields[1:] {
+		upper := strings.ToUpper(word)
+		if inType && columnConstraintKeywords[upper] {
+			inType = false
+		}
+		if inType {
+			typeWords = append(typeWords, word)
+			continue
+		}
+
+		next := ""
+		if i+2 < len(fields) {
+			next = strings.ToUpper(fields[i+2])
+		}
+		switch {
+		case upper == "NOT" && next == "NULL":
+			col.nullable = false
+		case upper == "PRIMARY" && next == "KEY":
+			col.nullable = false
+		}
+	}
+	col.sqlType = strings.ToLower(strings.Join(typeWords, " "))
+	return col, true, nil
+}
+
+var primaryKeyRE = regexp.MustCompile(`(?i)\bPRIMARY\s+KEY\s*\(([^)]*)\)`)
+
+// applyPrimaryKey marks the columns named by a table-level PRIMARY KEY
+// constraint as not nullable
+func (t *table) applyPrimaryKey(body string) {
+	for _, m := range primaryKeyRE.FindAllStringSubmatch(body, -1) {
+		for _, name := range strings.Split(m[1], ",") {
+			name = unquoteIdent(strings.TrimSpace(name))
+			for i := range t.columns {
+				if t.columns[i].name == name {
+					t.columns[i].nullable = false
+				}
+			}
+		}
+	}
+}
+
+func lastIdentPart(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+func unquoteIdent(name string) string {
+	return strings.Trim(name, "\"`[]")
+}
+
+// parseInfoSchema reads a CSV dump of information_schema.columns
+func parseInfoSchema(r io.Reader) ([]table, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int)
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"table_name", "column_name", "data_type", "is_nullable"} {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("missing %s column", name)
+		}
+	}
+
+	var tables []table
+	byName := make(map[string]int)
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		tableName := record[index["table_name"]]
+		i, ok := byName[tableName]
+		if !ok {
+			i = len(tables)
+			byName[tableName] = i
+			tables = append(tables, table{name: tableName})
+		}
+		tables[i].columns = append(tables[i].columns, column{
+			name:     record[index["column_name"]],
+			sqlType:  strings.ToLower(record[index["data_type"]]),
+			nullable: strings.EqualFold(record[index["is_nullable"]], "YES"),
+		})
+	}
+	return tables, nil
+}
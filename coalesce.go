@@ -16,7 +16,7 @@ func Coalesce[T any](options ...Value[T]) Value[T] {
 // (or returns an unset optional value if none is found).
 func CoalesceZero[T any](options ...Value[T]) Value[T] {
 	for _, option := range options {
-		if !option.IsZero() {
+		if option.hasNonZero() {
 			return option
 		}
 	}
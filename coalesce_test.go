@@ -0,0 +1,30 @@
+package optional_test
+
+import (
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestCoalesce(t *testing.T) {
+	var unset optional.Value[int]
+	zero := optional.NewValue(0)
+	five := optional.NewValue(5)
+
+	t.Run("Coalesce", func(t *testing.T) {
+		expect(t, "value", 0, optional.Coalesce(unset, zero, five).MustGet())
+		expect(t, "set", false, optional.Coalesce(unset, unset).IsSet())
+	})
+	t.Run("CoalesceZero", func(t *testing.T) {
+		expect(t, "value", 5, optional.CoalesceZero(unset, zero, five).MustGet())
+		expect(t, "set", false, optional.CoalesceZero(unset, zero).IsSet())
+	})
+}
+
+func TestValueIsZero(t *testing.T) {
+	expect(t, "unset", true, optional.Value[int]{}.IsZero())
+	expect(t, "set zero", false, optional.NewValue(0).IsZero())
+	expect(t, "set", false, optional.NewValue(5).IsZero())
+	expect(t, "boxed unset", true, optional.Boxed[int]{}.IsZero())
+	expect(t, "boxed set zero", false, optional.NewBoxed(0).IsZero())
+}
@@ -0,0 +1,110 @@
+package optional
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// FieldChange describes a field whose value differs between two structs
+type FieldChange struct {
+	// Path is the field's name, with nested struct fields joined by dots
+	Path string
+	// Old is the field's value in the first struct, unset if it was unset
+	Old Value[any]
+	// New is the field's value in the second struct, unset if it is unset
+	New Value[any]
+}
+
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Path, c.Old, c.New)
+}
+
+// CompareStructs compares two structs of the same type (or pointers to them)
+// and returns a change for every field whose value or presence differs, in
+// field order. Optional fields report their presence, while plain fields are
+// always considered set. Nested structs are compared field by field, and
+// every other field is compared as a whole with reflect.DeepEqual. The
+// values of Sensitive fields are masked in the changes, as they are by Dump.
+func CompareStructs(a, b any) ([]FieldChange, error) {
+	av, err := structValue(a)
+	if err != nil {
+		return nil, err
+	}
+	bv, err := structValue(b)
+	if err != nil {
+		return nil, err
+	}
+	if av.Type() != bv.Type() {
+		return nil, fmt.Errorf("optional: cannot compare %v with %v", av.Type(), bv.Type())
+	}
+
+	var changes []FieldChange
+	compareStructs("", av, true, bv, true, &changes)
+	return changes, nil
+}
+
+// compareStructs compares the fields of the structs a and b. aOK and bOK
+// report whether each side exists at all, as a nil pointer to a nested
+// struct makes all of its fields unset
+func compareStructs(prefix string, a reflect.Value, aOK bool, b reflect.Value, bOK bool, changes *[]FieldChange) {
+	rt := a.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		path := prefix + field.Name
+		af, bf := a.Field(i), b.Field(i)
+		afOK, bfOK := aOK, bOK
+
+		if af.Kind() == reflect.Pointer && isNestedStruct(af.Type().Elem()) {
+			zero := reflect.Zero(af.Type().Elem())
+			if af.IsNil() {
+				af, afOK = zero, false
+			} else {
+				af = af.Elem()
+			}
+			if bf.IsNil() {
+				bf, bfOK = zero, false
+			} else {
+				bf = bf.Elem()
+			}
+		}
+
+		if isNestedStruct(af.Type()) {
+			compareStructs(path+".", af, afOK, bf, bfOK, changes)
+			continue
+		}
+
+		oldValue, newValue := fieldValue(af, afOK), fieldValue(bf, bfOK)
+		if EqualFunc(oldValue, newValue, func(x, y any) bool { return reflect.DeepEqual(x, y) }) {
+			continue
+		}
+		if _, ok := af.Interface().(redactor); ok {
+			oldValue, newValue = redactValue(oldValue), redactValue(newValue)
+		}
+		*changes = append(*changes, FieldChange{Path: path, Old: oldValue, New: newValue})
+	}
+}
+
+func fieldValue(fv reflect.Value, ok bool) Value[any] {
+	if !ok {
+		return Value[any]{}
+	}
+	if IsOptionalType(fv.Type()) {
+		value, set := fv.Interface().(anyOptional).AsAny()
+		if !set {
+			return Value[any]{}
+		}
+		return NewValue(value)
+	}
+	return NewValue(fv.Interface())
+}
+
+// redactValue masks the value of a set v, keeping its presence
+func redactValue(v Value[any]) Value[any] {
+	if !v.set {
+		return v
+	}
+	return NewValue[any](redactedText)
+}
@@ -0,0 +1,112 @@
+package optional_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/heucuva/optional"
+)
+
+func TestCompareStructs(t *testing.T) {
+	type testAddress struct {
+		City optional.Value[string]
+	}
+	type testAccount struct {
+		ID      int
+		Name    optional.Value[string]
+		Email   optional.Value[string]
+		Tags    []string
+		Address testAddress
+		Billing *testAddress
+	}
+
+	before := testAccount{
+		ID:      1,
+		Name:    optional.NewValue("Foo"),
+		Email:   optional.NewValue("foo@example.com"),
+		Tags:    []string{"a"},
+		Address: testAddress{City: optional.NewValue("Bar")},
+	}
+	after := testAccount{
+		ID:      1,
+		Name:    optional.NewValue("Baz"),
+		Tags:    []string{"a"},
+		Address: testAddress{City: optional.NewValue("Bar")},
+		Billing: &testAddress{City: optional.NewValue("Qux")},
+	}
+
+	changes, err := optional.CompareStructs(before, &after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"Name: Some(Foo) -> Some(Baz)",
+		"Email: Some(foo@example.com) -> None",
+		"Billing.City: None -> Some(Qux)",
+	}
+	expect(t, "len", len(expected), len(changes))
+	for i := range expected {
+		expect(t, "change", expected[i], changes[i].String())
+	}
+	expect(t, "old name", "Foo", changes[0].Old.MustGet().(string))
+
+	t.Run("Equal", func(t *testing.T) {
+		changes, err := optional.CompareStructs(before, before)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "len", 0, len(changes))
+	})
+	t.Run("TypeMismatch", func(t *testing.T) {
+		if _, err := optional.CompareStructs(before, testAddress{}); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+	t.Run("Nil", func(t *testing.T) {
+		if _, err := optional.CompareStructs(nil, before); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+		if _, err := optional.CompareStructs(before, nil); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+	t.Run("Sensitive", func(t *testing.T) {
+		type testLogin struct {
+			Password optional.Sensitive[string]
+		}
+		changes, err := optional.CompareStructs(
+			testLogin{Password: optional.NewSensitive("hunter2")},
+			testLogin{Password: optional.NewSensitive("hunter3")},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "len", 1, len(changes))
+		expect(t, "change", "Password: Some(***) -> Some(***)", changes[0].String())
+
+		changes, err = optional.CompareStructs(testLogin{}, testLogin{Password: optional.NewSensitive("hunter2")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "change", "Password: None -> Some(***)", changes[0].String())
+	})
+	t.Run("Time", func(t *testing.T) {
+		type testRecord struct {
+			UpdatedAt time.Time
+			DeletedAt *time.Time
+		}
+		earlier := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		later := earlier.Add(time.Hour)
+		changes, err := optional.CompareStructs(
+			testRecord{UpdatedAt: earlier},
+			testRecord{UpdatedAt: later, DeletedAt: &later},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "len", 2, len(changes))
+		expect(t, "path", "UpdatedAt", changes[0].Path)
+		expect(t, "new", true, changes[0].New.MustGet().(time.Time).Equal(later))
+		expect(t, "path", "DeletedAt", changes[1].Path)
+	})
+}
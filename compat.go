@@ -0,0 +1,53 @@
+package optional
+
+// Presenter is implemented by optional types that report their presence
+// through Present and Get, such as those of github.com/markphelps/optional
+type Presenter[T any] interface {
+	Present() bool
+	Get() (T, error)
+}
+
+// FromPresenter converts a Presenter (e.g. a markphelps/optional.String)
+// into a Value
+func FromPresenter[T any](p Presenter[T]) Value[T] {
+	if !p.Present() {
+		return Value[T]{}
+	}
+	value, err := p.Get()
+	if err != nil {
+		return Value[T]{}
+	}
+	return NewValue(value)
+}
+
+// ToPresenter converts a Value into a Presenter-style optional using its
+// constructor (e.g. markphelps/optional.NewString).
+// an unset Value converts to the zero P, which such types treat as not present
+func ToPresenter[P any, T any](v Value[T], construct func(T) P) P {
+	if !v.set {
+		var empty P
+		return empty
+	}
+	return construct(v.value)
+}
+
+// NullType is implemented by nullable types that can report their value as
+// a pointer, such as those of github.com/guregu/null
+type NullType[T any] interface {
+	Ptr() *T
+}
+
+// FromNull converts a NullType (e.g. a guregu/null.String) into a Value
+func FromNull[T any](n NullType[T]) Value[T] {
+	if p := n.Ptr(); p != nil {
+		return NewValue(*p)
+	}
+	return Value[T]{}
+}
+
+// ToNull converts a Value into a NullType-style type using its
+// constructor (e.g. guregu/null.NewString), which receives the set flag as
+// the validity of the result
+func ToNull[N any, T any](v Value[T], construct func(T, bool) N) N {
+	return construct(v.value, v.set)
+}
@@ -0,0 +1,82 @@
+package optional_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+// testPresenter mimics the API of github.com/markphelps/optional
+type testPresenter struct {
+	value *string
+}
+
+func newTestPresenter(v string) testPresenter {
+	return testPresenter{value: &v}
+}
+
+func (p testPresenter) Present() bool {
+	return p.value != nil
+}
+
+func (p testPresenter) Get() (string, error) {
+	if p.value == nil {
+		return "", errors.New("value not present")
+	}
+	return *p.value, nil
+}
+
+// testNullable mimics the API of github.com/guregu/null
+type testNullable struct {
+	Int   int
+	Valid bool
+}
+
+func newTestNullable(v int, valid bool) testNullable {
+	return testNullable{Int: v, Valid: valid}
+}
+
+func (n testNullable) Ptr() *int {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int
+}
+
+func TestPresenter(t *testing.T) {
+	t.Run("FromSet", func(t *testing.T) {
+		encounteredValue, encounteredSet := optional.FromPresenter[string](newTestPresenter("Foo")).Get()
+		expect(t, "set", true, encounteredSet)
+		expect(t, "value", "Foo", encounteredValue)
+	})
+	t.Run("FromUnset", func(t *testing.T) {
+		expect(t, "set", false, optional.FromPresenter[string](testPresenter{}).IsSet())
+	})
+	t.Run("To", func(t *testing.T) {
+		p := optional.ToPresenter(optional.NewValue("Foo"), newTestPresenter)
+		value, err := p.Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "value", "Foo", value)
+		expect(t, "present", false, optional.ToPresenter(optional.Value[string]{}, newTestPresenter).Present())
+	})
+}
+
+func TestNullType(t *testing.T) {
+	t.Run("FromSet", func(t *testing.T) {
+		encounteredValue, encounteredSet := optional.FromNull[int](newTestNullable(5, true)).Get()
+		expect(t, "set", true, encounteredSet)
+		expect(t, "value", 5, encounteredValue)
+	})
+	t.Run("FromUnset", func(t *testing.T) {
+		expect(t, "set", false, optional.FromNull[int](testNullable{}).IsSet())
+	})
+	t.Run("To", func(t *testing.T) {
+		n := optional.ToNull(optional.NewValue(5), newTestNullable)
+		expect(t, "valid", true, n.Valid)
+		expect(t, "value", 5, n.Int)
+		expect(t, "unset valid", false, optional.ToNull(optional.Value[int]{}, newTestNullable).Valid)
+	})
+}
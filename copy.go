@@ -0,0 +1,155 @@
+package optional
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// CopyOption configures the behavior of Copy
+type CopyOption func(*copyConfig)
+
+type copyConfig struct {
+	zeroAsUnset bool
+	tag         string
+}
+
+// CopyZeroAsUnset makes Copy treat plain source fields holding their zero
+// value as unset when copying them into optional destination fields
+func CopyZeroAsUnset() CopyOption {
+	return func(c *copyConfig) {
+		c.zeroAsUnset = true
+	}
+}
+
+// CopyTag makes Copy match fields by the name in the given struct tag
+// (e.g. "json" or "db"), falling back to the field name when it is absent
+func CopyTag(tag string) CopyOption {
+	return func(c *copyConfig) {
+		c.tag = tag
+	}
+}
+
+// Copy copies the fields of the struct src into the struct dst points to,
+// matching fields by name. The structs do not need to be the same type.
+// Plain fields are copied into optionals as set values and set optionals are
+// copied into plain fields; unset optionals leave plain fields at their zero
+// value. Nested structs with matching names are copied recursively, and
+// fields without a match are left untouched.
+func Copy(dst, src any, opts ...CopyOption) error {
+	var cfg copyConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() {
+		return errors.New("optional: copy destination must be a non-nil pointer")
+	}
+	dv = dv.Elem()
+
+	sv := reflect.ValueOf(src)
+	if !sv.IsValid() {
+		return errors.New("optional: cannot copy from nil")
+	}
+	for sv.Kind() == reflect.Pointer {
+		if sv.IsNil() {
+			return errors.New("optional: cannot copy from a nil pointer")
+		}
+		sv = sv.Elem()
+	}
+
+	if dv.Kind() != reflect.Struct || sv.Kind() != reflect.Struct {
+		return fmt.Errorf("optional: cannot copy %v into %v", sv.Type(), dv.Type())
+	}
+	return copyStruct(&cfg, "", dv, sv)
+}
+
+func copyStruct(cfg *copyConfig, prefix string, dv, sv reflect.Value) error {
+	srcFields := make(map[string]reflect.Value)
+	st := sv.Type()
+	for i := 0; i < st.NumField(); i++ {
+		if field := st.Field(i); field.IsExported() {
+			srcFields[cfg.fieldName(field)] = sv.Field(i)
+		}
+	}
+
+	dt := dv.Type()
+	for i := 0; i < dt.NumField(); i++ {
+		field := dt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := cfg.fieldName(field)
+		sf, ok := srcFields[name]
+		if !ok {
+			continue
+		}
+		if err := copyField(cfg, prefix+field.Name, dv.Field(i), sf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *copyConfig) fieldName(field reflect.StructField) string {
+	if c.tag != "" {
+		if name, _, _ := strings.Cut(field.Tag.Get(c.tag), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func copyField(cfg *copyConfig, path string, dv, sv reflect.Value) error {
+	var (
+		value any
+		set   bool
+	)
+	if IsOptionalType(sv.Type()) {
+		value, set = sv.Interface().(anyOptional).AsAny()
+	} else {
+		value, set = sv.Interface(), true
+		if cfg.zeroAsUnset && sv.IsZero() {
+			set = false
+		}
+	}
+
+	if IsOptionalType(dv.Type()) {
+		if !set {
+			value = nil
+		}
+		result, err := FromAny(value, dv.Type())
+		if err != nil {
+			return fmt.Errorf("optional: copying %s: %w", path, err)
+		}
+		dv.Set(reflect.ValueOf(result))
+		return nil
+	}
+
+	if !IsOptionalType(sv.Type()) && dv.Kind() == reflect.Struct && sv.Kind() == reflect.Struct && sv.Type() != dv.Type() {
+		return copyStruct(cfg, path+".", dv, sv)
+	}
+
+	if !set {
+		dv.Set(reflect.Zero(dv.Type()))
+		return nil
+	}
+	rv := reflect.ValueOf(value)
+	switch {
+	case !rv.IsValid():
+		dv.Set(reflect.Zero(dv.Type()))
+	case rv.Type().AssignableTo(dv.Type()):
+		dv.Set(rv)
+	case isNumberKind(rv.Kind()) && isNumberKind(dv.Kind()):
+		converted, err := convertNumber(rv, dv.Type())
+		if err != nil {
+			return fmt.Errorf("optional: copying %s: %w", path, err)
+		}
+		dv.Set(converted)
+	default:
+		return fmt.Errorf("optional: copying %s: cannot use %v as %v", path, rv.Type(), dv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package optional_test
+
+import (
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+type testCopyAddress struct {
+	City string
+}
+
+type testCopyModel struct {
+	ID      int
+	Name    string
+	Age     int
+	Email   optional.Value[string]
+	Address testCopyAddress
+}
+
+type testCopyAddressDTO struct {
+	City optional.Value[string]
+}
+
+type testCopyDTO struct {
+	ID      int64
+	Name    optional.Value[string]
+	Age     optional.Value[int]
+	Email   string
+	Address testCopyAddressDTO
+	Extra   string
+}
+
+func TestCopy(t *testing.T) {
+	t.Run("ModelToDTO", func(t *testing.T) {
+		src := testCopyModel{
+			ID:      7,
+			Name:    "Foo",
+			Address: testCopyAddress{City: "Bar"},
+		}
+		dst := testCopyDTO{Extra: "kept"}
+		if err := optional.Copy(&dst, src); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "ID", 7, dst.ID)
+		expect(t, "Name", "Foo", dst.Name.MustGet())
+		expect(t, "Age set", true, dst.Age.IsSet())
+		expect(t, "Email", "", dst.Email)
+		expect(t, "Address.City", "Bar", dst.Address.City.MustGet())
+		expect(t, "Extra", "kept", dst.Extra)
+	})
+	t.Run("ZeroAsUnset", func(t *testing.T) {
+		var dst testCopyDTO
+		if err := optional.Copy(&dst, &testCopyModel{Name: "Foo"}, optional.CopyZeroAsUnset()); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "Name set", true, dst.Name.IsSet())
+		expect(t, "Age set", false, dst.Age.IsSet())
+		expect(t, "Address.City set", false, dst.Address.City.IsSet())
+	})
+	t.Run("DTOToModel", func(t *testing.T) {
+		src := testCopyDTO{
+			ID:    3,
+			Age:   optional.NewValue(42),
+			Email: "foo@example.com",
+		}
+		dst := testCopyModel{Name: "stale"}
+		if err := optional.Copy(&dst, src); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "ID", 3, dst.ID)
+		expect(t, "Name", "", dst.Name)
+		expect(t, "Age", 42, dst.Age)
+		expect(t, "Email", "foo@example.com", dst.Email.MustGet())
+	})
+	t.Run("Tag", func(t *testing.T) {
+		type testSrc struct {
+			FullName string `db:"name"`
+		}
+		type testDst struct {
+			DisplayName optional.Value[string] `db:"name"`
+		}
+		var dst testDst
+		if err := optional.Copy(&dst, testSrc{FullName: "Foo"}, optional.CopyTag("db")); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "DisplayName", "Foo", dst.DisplayName.MustGet())
+	})
+	t.Run("Mismatch", func(t *testing.T) {
+		type testSrc struct{ Name int }
+		var dst struct{ Name string }
+		if err := optional.Copy(&dst, testSrc{Name: 1}); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+	t.Run("NonPointer", func(t *testing.T) {
+		if err := optional.Copy(testCopyDTO{}, testCopyModel{}); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+	t.Run("NotRepresentable", func(t *testing.T) {
+		type testSrc struct {
+			Port  optional.Value[int]
+			Ratio float64
+		}
+		var dst struct {
+			Port  uint16
+			Ratio int
+		}
+		if err := optional.Copy(&dst, testSrc{Port: optional.NewValue(70000)}); err == nil {
+			t.Fatal("expected failure for overflow, but got success")
+		}
+		if err := optional.Copy(&dst, testSrc{Ratio: 2.5}); err == nil {
+			t.Fatal("expected failure for truncation, but got success")
+		}
+		if err := optional.Copy(&dst, testSrc{Port: optional.NewValue(8080), Ratio: 2}); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "Port", uint16(8080), dst.Port)
+		expect(t, "Ratio", 2, dst.Ratio)
+	})
+	t.Run("Nil", func(t *testing.T) {
+		var dst testCopyDTO
+		if err := optional.Copy(&dst, nil); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+}
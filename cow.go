@@ -0,0 +1,71 @@
+package optional
+
+import "sync/atomic"
+
+// COW is a copy-on-write optional value that is safe for concurrent use.
+// Readers get a snapshot without locking and writers atomically publish a
+// new version, which makes it well-suited to read-heavy state such as an
+// optional global override consulted on every request.
+//
+// The zero COW is unset and ready to use. A COW must not be copied after
+// first use.
+type COW[T any] struct {
+	current atomic.Value // *Value[T]
+}
+
+// NewCOW constructs a COW with an initial value already published into it
+func NewCOW[T any](value Value[T]) *COW[T] {
+	c := &COW[T]{}
+	c.Store(value)
+	return c
+}
+
+// Load returns a snapshot of the current value
+func (c *COW[T]) Load() Value[T] {
+	if v, ok := c.current.Load().(*Value[T]); ok {
+		return *v
+	}
+	return Value[T]{}
+}
+
+// Store publishes a new value
+func (c *COW[T]) Store(value Value[T]) {
+	c.current.Store(&value)
+}
+
+// Set publishes a new set value
+func (c *COW[T]) Set(value T) {
+	c.Store(NewValue(value))
+}
+
+// Reset publishes an unset value
+func (c *COW[T]) Reset() {
+	c.Store(Value[T]{})
+}
+
+// Get returns the current value and its set flag
+func (c *COW[T]) Get() (T, bool) {
+	return c.Load().Get()
+}
+
+// IsSet returns true if the current value is set
+func (c *COW[T]) IsSet() bool {
+	return c.Load().IsSet()
+}
+
+// Update atomically replaces the current value with the result of fn.
+// fn may be called more than once if other writers race with it, so it
+// should be free of side effects and must not mutate the value it is given.
+func (c *COW[T]) Update(fn func(Value[T]) Value[T]) Value[T] {
+	for {
+		old := c.current.Load()
+		var prev Value[T]
+		if v, ok := old.(*Value[T]); ok {
+			prev = *v
+		}
+		next := fn(prev)
+		if c.current.CompareAndSwap(old, &next) {
+			return next
+		}
+	}
+}
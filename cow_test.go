@@ -0,0 +1,47 @@
+package optional_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestCOW(t *testing.T) {
+	t.Run("ZeroUnset", func(t *testing.T) {
+		var target optional.COW[int]
+		encounteredValue, encounteredSet := target.Get()
+		expect(t, "set", false, encounteredSet)
+		expect(t, "value", 0, encounteredValue)
+	})
+	t.Run("SetReset", func(t *testing.T) {
+		target := optional.NewCOW(optional.NewValue("Foo"))
+		expect(t, "set", true, target.IsSet())
+		snapshot := target.Load()
+		target.Set("Bar")
+		encounteredValue, _ := target.Get()
+		expect(t, "value", "Bar", encounteredValue)
+		snapshotValue, _ := snapshot.Get()
+		expect(t, "snapshot", "Foo", snapshotValue)
+		target.Reset()
+		expect(t, "set", false, target.IsSet())
+	})
+	t.Run("Update", func(t *testing.T) {
+		var target optional.COW[int]
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				target.Update(func(v optional.Value[int]) optional.Value[int] {
+					n, _ := v.Get()
+					return optional.NewValue(n + 1)
+				})
+			}()
+		}
+		wg.Wait()
+		encounteredValue, encounteredSet := target.Get()
+		expect(t, "set", true, encounteredSet)
+		expect(t, "value", 100, encounteredValue)
+	})
+}
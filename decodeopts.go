@@ -0,0 +1,264 @@
+package optional
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DecoderOptions controls how optional values are decoded by the
+// context-aware decode entry points, such as UnmarshalJSONContext
+type DecoderOptions struct {
+	// NullAsUnset decodes an explicit null as an unset value
+	// instead of a set zero value
+	NullAsUnset bool
+	// EmptyStringAsUnset decodes an empty string as an unset value
+	EmptyStringAsUnset bool
+	// DisallowNull rejects an explicit null decoded into an optional value
+	// with a *NullError, for consumers which require fields that are
+	// present to be non-null. it takes precedence over NullAsUnset
+	DisallowNull bool
+	// Strict rejects object keys that do not match any field.
+	// it has no effect when CaptureUnknown is set
+	Strict bool
+	// CaptureUnknown collects object keys that do not match any field into
+	// the struct's capture field, if it has one (see UnknownFields)
+	CaptureUnknown bool
+}
+
+// NullError is returned by UnmarshalJSONContext when DisallowNull is set
+// and an optional value was decoded from an explicit null
+type NullError struct {
+	// Path locates the value in the payload, such as items[0].name.
+	// it is empty for a top-level value
+	Path string
+}
+
+func (e *NullError) Error() string {
+	if e.Path == "" {
+		return "optional: null value not allowed"
+	}
+	return fmt.Sprintf("optional: null value not allowed for %s", e.Path)
+}
+
+type decoderOptionsKey struct{}
+
+// WithDecoderOptions returns a copy of ctx carrying the decoder options
+func WithDecoderOptions(ctx context.Context, opts DecoderOptions) context.Context {
+	return context.WithValue(ctx, decoderOptionsKey{}, opts)
+}
+
+// DecoderOptionsFromContext returns the decoder options carried by ctx.
+// if there are none, it returns the default options
+func DecoderOptionsFromContext(ctx context.Context) DecoderOptions {
+	opts, _ := ctx.Value(decoderOptionsKey{}).(DecoderOptions)
+	return opts
+}
+
+// UnmarshalJSONContext decodes data into v, applying the decoder options
+// carried by ctx to every optional value found along the way
+func UnmarshalJSONContext(ctx context.Context, data []byte, v any) error {
+	opts := DecoderOptionsFromContext(ctx)
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if opts.Strict && !opts.CaptureUnknown {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if !opts.NullAsUnset && !opts.EmptyStringAsUnset && !opts.DisallowNull && !opts.CaptureUnknown {
+		return nil
+	}
+
+	// the payload is walked as written, so captured fields keep their bytes
+	var raw json.RawMessage
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(&raw); err != nil {
+		return err
+	}
+	return applyDecoderOptions(&opts, "", reflect.ValueOf(v), raw)
+}
+
+type resetter interface {
+	Reset()
+}
+
+// applyDecoderOptions walks rv alongside the payload it was decoded from,
+// resetting the optionals whose encoded value the options say should be
+// treated as unset, and rejecting those they disallow
+func applyDecoderOptions(opts *DecoderOptions, path string, rv reflect.Value, raw json.RawMessage) error {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	if IsOptionalType(rv.Type()) {
+		kind := jsonKind(raw)
+		if kind == 'n' && opts.DisallowNull {
+			return &NullError{Path: path}
+		}
+		if !rv.CanAddr() {
+			return nil
+		}
+		r, ok := rv.Addr().Interface().(resetter)
+		if !ok {
+			return nil
+		}
+		switch kind {
+		case 'n':
+			if opts.NullAsUnset {
+				r.Reset()
+			}
+		case '"':
+			if opts.EmptyStringAsUnset && string(bytes.TrimSpace(raw)) == `""` {
+				r.Reset()
+			}
+		case '{', '[':
+			return applyDecoderOptionsElem(opts, path, rv, raw)
+		}
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		if obj, ok := jsonObject(raw); ok {
+			return applyDecoderOptionsStruct(opts, path, rv, obj)
+		}
+	case reflect.Slice, reflect.Array:
+		var arr []json.RawMessage
+		if jsonKind(raw) != '[' || json.Unmarshal(raw, &arr) != nil {
+			return nil
+		}
+		for i := 0; i < rv.Len() && i < len(arr); i++ {
+			if err := applyDecoderOptions(opts, fmt.Sprintf("%s[%d]", path, i), rv.Index(i), arr[i]); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		obj, ok := jsonObject(raw)
+		if !ok || rv.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		for _, key := range rv.MapKeys() {
+			elemRaw, ok := obj[key.String()]
+			if !ok {
+				continue
+			}
+			// map elements are not addressable, so decode options are
+			// applied to a copy which is then stored back
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			elem.Set(rv.MapIndex(key))
+			if err := applyDecoderOptions(opts, fmt.Sprintf("%s[%s]", path, key), elem, elemRaw); err != nil {
+				return err
+			}
+			rv.SetMapIndex(key, elem)
+		}
+	}
+	return nil
+}
+
+// jsonKind returns the first byte of the json value raw, which tells its
+// kind: 'n' for null, '"' for a string, '{' for an object, '[' for an array
+func jsonKind(raw json.RawMessage) byte {
+	raw = bytes.TrimLeft(raw, " \t\r\n")
+	if len(raw) == 0 {
+		return 0
+	}
+	return raw[0]
+}
+
+// jsonObject splits the json object raw into its members
+func jsonObject(raw json.RawMessage) (map[string]json.RawMessage, bool) {
+	if jsonKind(raw) != '{' {
+		return nil, false
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, false
+	}
+	return obj, true
+}
+
+// applyDecoderOptionsElem applies the decoder options to the value of the
+// set optional rv. the value is not addressable, so they are applied to a
+// copy which is then stored back
+func applyDecoderOptionsElem(opts *DecoderOptions, path string, rv reflect.Value, raw json.RawMessage) error {
+	opt := rv.Interface().(anyOptional)
+	value, set := opt.AsAny()
+	if !set || value == nil {
+		return nil
+	}
+	elem := reflect.New(opt.elemType()).Elem()
+	elem.Set(reflect.ValueOf(value))
+	if err := applyDecoderOptions(opts, path, elem, raw); err != nil {
+		return err
+	}
+	return rv.Addr().Interface().(anySetter).setAny(elem.Interface())
+}
+
+func applyDecoderOptionsStruct(opts *DecoderOptions, path string, rv reflect.Value, obj map[string]json.RawMessage) error {
+	if opts.CaptureUnknown {
+		captureUnknownFields(rv, obj)
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		name := field.Name
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if tagName, _, _ := strings.Cut(tag, ","); tagName != "" {
+			name = tagName
+		} else if field.Anonymous && tag == "" {
+			// promoted fields share the parent object
+			fv := rv.Field(i)
+			for fv.Kind() == reflect.Pointer && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct && !IsOptionalType(fv.Type()) {
+				if err := applyDecoderOptionsStruct(opts, path, fv, obj); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		raw, ok := lookupJSONKey(obj, name)
+		if !ok {
+			continue
+		}
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+		if err := applyDecoderOptions(opts, fieldPath, rv.Field(i), raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookupJSONKey finds name in obj, preferring an exact match but falling
+// back to the case-insensitive match encoding/json performs
+func lookupJSONKey(obj map[string]json.RawMessage, name string) (json.RawMessage, bool) {
+	if raw, ok := obj[name]; ok {
+		return raw, true
+	}
+	for key, raw := range obj {
+		if strings.EqualFold(key, name) {
+			return raw, true
+		}
+	}
+	return nil, false
+}
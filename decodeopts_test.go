@@ -0,0 +1,118 @@
+package optional_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestUnmarshalJSONContext(t *testing.T) {
+	type testNested struct {
+		Note optional.Value[string] `json:"note"`
+	}
+	type testRequest struct {
+		Name   optional.Value[string] `json:"name"`
+		Count  optional.Value[int]    `json:"count"`
+		Nested testNested             `json:"nested"`
+		Items  []testNested           `json:"items"`
+		Tags   map[string]optional.Value[string]
+	}
+	payload := []byte(`{"name":"","count":null,"nested":{"note":null},"items":[{"note":""}],"Tags":{"a":null}}`)
+
+	t.Run("Default", func(t *testing.T) {
+		var target testRequest
+		if err := optional.UnmarshalJSONContext(context.Background(), payload, &target); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "name set", true, target.Name.IsSet())
+		expect(t, "count set", true, target.Count.IsSet())
+		expect(t, "nested.note set", true, target.Nested.Note.IsSet())
+	})
+	t.Run("NullAsUnset", func(t *testing.T) {
+		ctx := optional.WithDecoderOptions(context.Background(), optional.DecoderOptions{
+			NullAsUnset: true,
+		})
+		var target testRequest
+		if err := optional.UnmarshalJSONContext(ctx, payload, &target); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "name set", true, target.Name.IsSet())
+		expect(t, "count set", false, target.Count.IsSet())
+		expect(t, "nested.note set", false, target.Nested.Note.IsSet())
+		expect(t, "tags.a set", false, target.Tags["a"].IsSet())
+	})
+	t.Run("NullAsUnsetInsideOptional", func(t *testing.T) {
+		ctx := optional.WithDecoderOptions(context.Background(), optional.DecoderOptions{
+			NullAsUnset: true,
+		})
+		var target struct {
+			Nested optional.Value[testNested]   `json:"nested"`
+			Items  optional.Value[[]testNested] `json:"items"`
+		}
+		if err := optional.UnmarshalJSONContext(ctx, []byte(`{"nested":{"note":null},"items":[{"note":null}]}`), &target); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "nested set", true, target.Nested.IsSet())
+		expect(t, "nested.note set", false, target.Nested.MustGet().Note.IsSet())
+		expect(t, "items[0].note set", false, target.Items.MustGet()[0].Note.IsSet())
+	})
+	t.Run("EmptyStringAsUnset", func(t *testing.T) {
+		ctx := optional.WithDecoderOptions(context.Background(), optional.DecoderOptions{
+			EmptyStringAsUnset: true,
+		})
+		var target testRequest
+		if err := optional.UnmarshalJSONContext(ctx, payload, &target); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "name set", false, target.Name.IsSet())
+		expect(t, "count set", true, target.Count.IsSet())
+		expect(t, "items[0].note set", false, target.Items[0].Note.IsSet())
+	})
+	t.Run("DisallowNull", func(t *testing.T) {
+		ctx := optional.WithDecoderOptions(context.Background(), optional.DecoderOptions{
+			DisallowNull: true,
+			NullAsUnset:  true,
+		})
+		tests := []struct {
+			name    string
+			payload string
+			path    string
+		}{
+			{"Field", `{"count":null}`, "count"},
+			{"Nested", `{"nested":{"note":null}}`, "nested.note"},
+			{"Slice", `{"items":[{"note":"a"},{"note":null}]}`, "items[1].note"},
+			{"Map", `{"Tags":{"a":null}}`, "Tags[a]"},
+		}
+		for _, tc := range tests {
+			var target testRequest
+			err := optional.UnmarshalJSONContext(ctx, []byte(tc.payload), &target)
+			var nullErr *optional.NullError
+			if !errors.As(err, &nullErr) {
+				t.Fatalf("%s: expected a NullError, got %v", tc.name, err)
+			}
+			expect(t, tc.name+" path", tc.path, nullErr.Path)
+		}
+
+		var target testRequest
+		if err := optional.UnmarshalJSONContext(ctx, []byte(`{"name":"","nested":{}}`), &target); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "name set", true, target.Name.IsSet())
+		expect(t, "count set", false, target.Count.IsSet())
+
+		var top optional.Value[int]
+		err := optional.UnmarshalJSONContext(ctx, []byte(`null`), &top)
+		expect(t, "top-level error", "optional: null value not allowed", err.Error())
+	})
+	t.Run("Strict", func(t *testing.T) {
+		ctx := optional.WithDecoderOptions(context.Background(), optional.DecoderOptions{
+			Strict: true,
+		})
+		var target testRequest
+		if err := optional.UnmarshalJSONContext(ctx, []byte(`{"unknown":1}`), &target); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+}
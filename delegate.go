@@ -0,0 +1,12 @@
+package optional
+
+// asInterface returns the value (or, failing that, a pointer to it) as an I.
+// it lets the encoders delegate to T's own marshalers even when they are
+// declared on *T, as the encoding packages do for addressable values
+func asInterface[I any, T any](value *T) (I, bool) {
+	if i, ok := any(*value).(I); ok {
+		return i, true
+	}
+	i, ok := any(value).(I)
+	return i, ok
+}
@@ -0,0 +1,191 @@
+package optional_test
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/heucuva/optional"
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// testPtrCodec implements every supported marshaler on its pointer
+type testPtrCodec struct {
+	value string
+}
+
+func (c *testPtrCodec) MarshalJSON() ([]byte, error) {
+	return json.Marshal("json:" + c.value)
+}
+
+func (c *testPtrCodec) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	c.value = strings.TrimPrefix(s, "json:")
+	return nil
+}
+
+func (c *testPtrCodec) MarshalYAML() (any, error) {
+	return "yaml:" + c.value, nil
+}
+
+func (c *testPtrCodec) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	c.value = strings.TrimPrefix(s, "yaml:")
+	return nil
+}
+
+func (c *testPtrCodec) MarshalText() ([]byte, error) {
+	return []byte("text:" + c.value), nil
+}
+
+func (c *testPtrCodec) UnmarshalText(text []byte) error {
+	c.value = strings.TrimPrefix(string(text), "text:")
+	return nil
+}
+
+func (c *testPtrCodec) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement("xml:"+c.value, start)
+}
+
+func (c *testPtrCodec) Value() (driver.Value, error) {
+	return "sql:" + c.value, nil
+}
+
+func (c *testPtrCodec) GobEncode() ([]byte, error) {
+	return []byte("gob:" + c.value), nil
+}
+
+func (c *testPtrCodec) GobDecode(data []byte) error {
+	c.value = strings.TrimPrefix(string(data), "gob:")
+	return nil
+}
+
+// testValueCodec implements the marshalers on its value
+type testValueCodec string
+
+func (c testValueCodec) MarshalJSON() ([]byte, error) {
+	return json.Marshal("json:" + string(c))
+}
+
+func (c testValueCodec) MarshalYAML() (any, error) {
+	return "yaml:" + string(c), nil
+}
+
+func (c testValueCodec) MarshalText() ([]byte, error) {
+	return []byte("text:" + c), nil
+}
+
+func (c testValueCodec) Value() (driver.Value, error) {
+	return "sql:" + string(c), nil
+}
+
+func TestDelegation(t *testing.T) {
+	ptrCodec := optional.NewValue(testPtrCodec{value: "Foo"})
+	valueCodec := optional.NewValue(testValueCodec("Foo"))
+
+	t.Run("JSON", func(t *testing.T) {
+		for name, v := range map[string]any{"Pointer": ptrCodec, "Value": valueCodec} {
+			blob, err := json.Marshal(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expect(t, name, `"json:Foo"`, string(blob))
+		}
+
+		var observed optional.Value[testPtrCodec]
+		if err := json.Unmarshal([]byte(`"json:Bar"`), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "unmarshal", "Bar", observed.MustGet().value)
+	})
+	t.Run("YAMLv2", func(t *testing.T) {
+		for name, v := range map[string]any{"Pointer": ptrCodec, "Value": valueCodec} {
+			blob, err := yaml.Marshal(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expect(t, name, "yaml:Foo\n", string(blob))
+		}
+
+		var observed optional.Value[testPtrCodec]
+		if err := yaml.Unmarshal([]byte(`yaml:Bar`), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "unmarshal", "Bar", observed.MustGet().value)
+	})
+	t.Run("YAMLv3", func(t *testing.T) {
+		for name, v := range map[string]any{"Pointer": ptrCodec, "Value": valueCodec} {
+			blob, err := yamlv3.Marshal(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expect(t, name, "yaml:Foo\n", string(blob))
+		}
+	})
+	t.Run("Text", func(t *testing.T) {
+		for name, v := range map[string]interface{ MarshalText() ([]byte, error) }{"Pointer": ptrCodec, "Value": valueCodec} {
+			text, err := v.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			expect(t, name, "text:Foo", string(text))
+		}
+
+		var observed optional.Value[testPtrCodec]
+		if err := observed.UnmarshalText([]byte("text:Bar")); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "unmarshal", "Bar", observed.MustGet().value)
+	})
+	t.Run("XML", func(t *testing.T) {
+		blob, err := xml.Marshal(struct {
+			XMLName xml.Name                     `xml:"doc"`
+			Codec   optional.Value[testPtrCodec] `xml:"codec"`
+		}{Codec: ptrCodec})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "xml", `<doc><codec>xml:Foo</codec></doc>`, string(blob))
+	})
+	t.Run("XMLAttr", func(t *testing.T) {
+		blob, err := xml.Marshal(struct {
+			XMLName xml.Name                       `xml:"doc"`
+			Codec   optional.Value[testValueCodec] `xml:"codec,attr"`
+		}{Codec: valueCodec})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "xml", `<doc codec="text:Foo"></doc>`, string(blob))
+	})
+	t.Run("SQL", func(t *testing.T) {
+		for name, v := range map[string]driver.Valuer{"Pointer": ptrCodec, "Value": valueCodec} {
+			observed, err := v.Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+			expect(t, name, "sql:Foo", observed.(string))
+		}
+	})
+	t.Run("Gob", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(ptrCodec); err != nil {
+			t.Fatal(err)
+		}
+		var observed optional.Value[testPtrCodec]
+		if err := gob.NewDecoder(&buf).Decode(&observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "gob", "Foo", observed.MustGet().value)
+	})
+}
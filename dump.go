@@ -0,0 +1,52 @@
+package optional
+
+import (
+	"fmt"
+	"io"
+)
+
+// DumpOption configures the behavior of Dump
+type DumpOption func(*dumpConfig)
+
+type dumpConfig struct {
+	redact func(path string, value any) any
+}
+
+// DumpRedactor installs a redaction hook into Dump.
+// The hook is called for every set optional field with the field's path
+// and value, and the value it returns is printed in place of the original.
+func DumpRedactor(redact func(path string, value any) any) DumpOption {
+	return func(c *dumpConfig) {
+		c.redact = redact
+	}
+}
+
+// Dump writes one line per optional field found in the struct v (or the struct
+// v points to), listing the field's path, whether it is set, and its value.
+// Nested structs are walked recursively, with their field names joined by dots.
+// The values of Sensitive fields are always masked.
+func Dump(w io.Writer, v any, opts ...DumpOption) error {
+	var cfg dumpConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	rv, err := structValue(v)
+	if err != nil {
+		return err
+	}
+	return walkOptionals("", rv, func(path string, opt anyOptional) error {
+		value, set := opt.AsAny()
+		if !set {
+			_, err := fmt.Fprintf(w, "%s: unset\n", path)
+			return err
+		}
+		if _, ok := opt.(redactor); ok {
+			value = redactedText
+		} else if cfg.redact != nil {
+			value = cfg.redact(path, value)
+		}
+		_, err := fmt.Fprintf(w, "%s: set %+v\n", path, value)
+		return err
+	})
+}
@@ -0,0 +1,75 @@
+package optional_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestDump(t *testing.T) {
+	type testAddress struct {
+		City optional.Value[string]
+		Zip  optional.Value[int]
+	}
+	type testRequest struct {
+		Name     optional.Value[string]
+		Token    optional.Value[string]
+		Age      optional.Value[int]
+		Address  testAddress
+		Billing  *testAddress
+		Ignored  int
+		internal optional.Value[int]
+	}
+
+	request := testRequest{
+		Name:     optional.NewValue("Foo"),
+		Token:    optional.NewValue("secret"),
+		Address:  testAddress{City: optional.NewValue("Bar")},
+		internal: optional.NewValue(1),
+	}
+	_ = request.internal
+
+	t.Run("Plain", func(t *testing.T) {
+		var sb strings.Builder
+		if err := optional.Dump(&sb, &request); err != nil {
+			t.Fatal(err)
+		}
+		expected := "Name: set Foo\n" +
+			"Token: set secret\n" +
+			"Age: unset\n" +
+			"Address.City: set Bar\n" +
+			"Address.Zip: unset\n"
+		expect(t, "dump", expected, sb.String())
+	})
+	t.Run("Redacted", func(t *testing.T) {
+		var sb strings.Builder
+		redact := optional.DumpRedactor(func(path string, value any) any {
+			if path == "Token" {
+				return "[REDACTED]"
+			}
+			return value
+		})
+		if err := optional.Dump(&sb, request, redact); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(sb.String(), "Token: set [REDACTED]\n") {
+			t.Fatalf("expected redacted token, got %q", sb.String())
+		}
+	})
+	t.Run("NotStruct", func(t *testing.T) {
+		var sb strings.Builder
+		if err := optional.Dump(&sb, 5); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+	t.Run("Nil", func(t *testing.T) {
+		var sb strings.Builder
+		if err := optional.Dump(&sb, nil); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+		if err := optional.Dump(&sb, (*testRequest)(nil)); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+}
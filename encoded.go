@@ -0,0 +1,153 @@
+package optional
+
+import (
+	"bytes"
+	"reflect"
+)
+
+// UnsetPolicy names, at the type level, how an Encoded value is marshaled
+// when it is unset. Policies are usually empty struct types:
+//
+//	type unsetAsZero struct{}
+//
+//	func (unsetAsZero) UnsetJSON() []byte { return []byte("0") }
+//	func (unsetAsZero) OmitUnset() bool   { return false }
+type UnsetPolicy interface {
+	// UnsetJSON returns the json literal written in place of an unset value
+	UnsetJSON() []byte
+	// OmitUnset returns true if unset values should be reported as zero by
+	// IsZero, so they are left out of fields tagged omitzero
+	OmitUnset() bool
+}
+
+// UnsetNull writes unset values as null, even in fields tagged omitzero
+type UnsetNull struct{}
+
+// UnsetJSON returns null
+func (UnsetNull) UnsetJSON() []byte { return []byte("null") }
+
+// OmitUnset returns false
+func (UnsetNull) OmitUnset() bool { return false }
+
+// UnsetOmit leaves unset values out of fields tagged omitzero, and writes
+// them as null elsewhere. this is how Value itself is marshaled
+type UnsetOmit struct{}
+
+// UnsetJSON returns null
+func (UnsetOmit) UnsetJSON() []byte { return []byte("null") }
+
+// OmitUnset returns true
+func (UnsetOmit) OmitUnset() bool { return true }
+
+// UnsetEmptyString writes unset values as an empty string
+type UnsetEmptyString struct{}
+
+// UnsetJSON returns an empty json string
+func (UnsetEmptyString) UnsetJSON() []byte { return []byte(`""`) }
+
+// OmitUnset returns false
+func (UnsetEmptyString) OmitUnset() bool { return false }
+
+// Encoded is an optional value which is marshaled to json according to the
+// UnsetPolicy P when it is unset, for APIs with their own conventions for
+// missing values:
+//
+//	type Request struct {
+//		Name  optional.Encoded[string, optional.UnsetEmptyString] `json:"name"`
+//		Limit optional.Encoded[int, optional.UnsetNull]           `json:"limit,omitzero"`
+//	}
+//
+// Unmarshaling P's literal results in an unset value, so unset values
+// round-trip (which also makes null decode as unset under UnsetNull and
+// UnsetOmit, unlike Value).
+type Encoded[T any, P UnsetPolicy] struct {
+	v Value[T]
+}
+
+// NewEncoded constructs an Encoded structure with a value already set into it
+func NewEncoded[T any, P UnsetPolicy](value T) Encoded[T, P] {
+	return Encoded[T, P]{v: NewValue(value)}
+}
+
+// Value converts the Encoded into a Value
+func (e Encoded[T, P]) Value() Value[T] {
+	return e.v
+}
+
+// Reset clears the memory on the value
+func (e *Encoded[T, P]) Reset() {
+	e.v.Reset()
+}
+
+// Set updates the value and sets the set flag
+func (e *Encoded[T, P]) Set(value T) {
+	e.v.Set(value)
+}
+
+// IsSet returns true if the value is set
+func (e Encoded[T, P]) IsSet() bool {
+	return e.v.set
+}
+
+// IsZero returns true if the value is unset and P omits unset values,
+// for omitzero
+func (e Encoded[T, P]) IsZero() bool {
+	var p P
+	return !e.v.set && p.OmitUnset()
+}
+
+// Get returns the value and its set flag
+func (e Encoded[T, P]) Get() (T, bool) {
+	return e.v.Get()
+}
+
+// String returns the value formatted as Some(value), if it is set.
+// otherwise, it returns None
+func (e Encoded[T, P]) String() string {
+	return e.v.String()
+}
+
+// MarshalJSON outputs the value of the Encoded, if it is set.
+// otherwise, it outputs P's literal for unset values
+func (e Encoded[T, P]) MarshalJSON() ([]byte, error) {
+	if !e.v.set {
+		var p P
+		return p.UnsetJSON(), nil
+	}
+	return marshalJSONValue(e.v.value)
+}
+
+// UnmarshalJSON unmarshals a value out of json and safely into our struct.
+// P's literal for unset values resets the value
+func (e *Encoded[T, P]) UnmarshalJSON(data []byte) error {
+	var p P
+	if len(data) == 0 || bytes.Equal(bytes.TrimSpace(data), p.UnsetJSON()) {
+		e.v.Reset()
+		return nil
+	}
+	return e.v.UnmarshalJSON(data)
+}
+
+// AsAny returns the value as an `any` along with its set flag, for code that
+// needs to inspect an optional without knowing its type at compile time
+func (e Encoded[T, P]) AsAny() (any, bool) {
+	return e.v.AsAny()
+}
+
+func (e Encoded[T, P]) elemType() reflect.Type {
+	return e.v.elemType()
+}
+
+func (e Encoded[T, P]) policyType() reflect.Type {
+	return reflect.TypeOf((*P)(nil)).Elem()
+}
+
+// SetAny sets the value to val, converting it to T as FromAny does.
+// a nil val resets the value
+func (e *Encoded[T, P]) SetAny(val any) error {
+	return e.v.SetAny(val)
+}
+
+func (e *Encoded[T, P]) setAny(val any) error {
+	return e.v.setAny(val)
+}
@@ -0,0 +1,79 @@
+package optional_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+type unsetAsNA struct{}
+
+func (unsetAsNA) UnsetJSON() []byte { return []byte(`"N/A"`) }
+func (unsetAsNA) OmitUnset() bool   { return false }
+
+type encodedRecord struct {
+	Null   optional.Encoded[int, optional.UnsetNull]           `json:"null"`
+	Omit   optional.Encoded[int, optional.UnsetOmit]           `json:"omit"`
+	Empty  optional.Encoded[string, optional.UnsetEmptyString] `json:"empty"`
+	Custom optional.Encoded[float64, unsetAsNA]                `json:"custom"`
+}
+
+func TestEncoded(t *testing.T) {
+	t.Run("MarshalUnset", func(t *testing.T) {
+		blob, err := json.Marshal(encodedRecord{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", `{"null":null,"omit":null,"empty":"","custom":"N/A"}`, string(blob))
+	})
+	t.Run("MarshalSet", func(t *testing.T) {
+		record := encodedRecord{
+			Null:   optional.NewEncoded[int, optional.UnsetNull](0),
+			Omit:   optional.NewEncoded[int, optional.UnsetOmit](1),
+			Empty:  optional.NewEncoded[string, optional.UnsetEmptyString]("Foo"),
+			Custom: optional.NewEncoded[float64, unsetAsNA](1.5),
+		}
+		blob, err := json.Marshal(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", `{"null":0,"omit":1,"empty":"Foo","custom":1.5}`, string(blob))
+	})
+	t.Run("RoundTrip", func(t *testing.T) {
+		var record encodedRecord
+		if err := json.Unmarshal([]byte(`{"null":null,"empty":"","custom":"N/A"}`), &record); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "null set", false, record.Null.IsSet())
+		expect(t, "omit set", false, record.Omit.IsSet())
+		expect(t, "empty set", false, record.Empty.IsSet())
+		expect(t, "custom set", false, record.Custom.IsSet())
+
+		if err := json.Unmarshal([]byte(`{"null":5,"empty":"Foo","custom":2.5}`), &record); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "null", 5, record.Null.Value().MustGet())
+		expect(t, "empty", "Foo", record.Empty.Value().MustGet())
+		expect(t, "custom", 2.5, record.Custom.Value().MustGet())
+	})
+	t.Run("Accessors", func(t *testing.T) {
+		var target optional.Encoded[int, optional.UnsetNull]
+		expect(t, "zero", false, target.IsZero())
+		expect(t, "omit zero", true, optional.Encoded[int, optional.UnsetOmit]{}.IsZero())
+		expect(t, "set omit zero", false, optional.NewEncoded[int, optional.UnsetOmit](0).IsZero())
+		target.Set(5)
+		value, set := target.Get()
+		expect(t, "value", 5, value)
+		expect(t, "set", true, set)
+		expect(t, "string", "Some(5)", target.String())
+		target.Reset()
+		expect(t, "reset", false, target.IsSet())
+	})
+	t.Run("Reflection", func(t *testing.T) {
+		typ := reflect.TypeOf(optional.Encoded[int, optional.UnsetNull]{})
+		expect(t, "optional type", true, optional.IsOptionalType(typ))
+		expect(t, "elem type", true, optional.ElemType(typ) == reflect.TypeOf(0))
+	})
+}
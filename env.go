@@ -0,0 +1,70 @@
+package optional
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// EnvError is returned by FromEnvErr when an environment variable cannot
+// be parsed
+type EnvError struct {
+	Key string
+	// Err is the error returned by the parse function, such as a
+	// *strconv.NumError
+	Err error
+}
+
+func (e *EnvError) Error() string {
+	return fmt.Sprintf("optional: parsing environment variable %s: %v", e.Key, e.Err)
+}
+
+func (e *EnvError) Unwrap() error {
+	return e.Err
+}
+
+// FromEnv returns the environment variable named by key, parsed by parse.
+// the value is unset if the variable is absent or cannot be parsed; use
+// FromEnvErr to tell a malformed variable from an absent one
+func FromEnv[T any](key string, parse func(string) (T, error)) Value[T] {
+	value, _ := FromEnvErr(key, parse)
+	return value
+}
+
+// FromEnvErr returns the environment variable named by key, parsed by parse.
+// the value is unset if the variable is absent, and an *EnvError is returned
+// if it cannot be parsed
+func FromEnvErr[T any](key string, parse func(string) (T, error)) (Value[T], error) {
+	s, ok := os.LookupEnv(key)
+	if !ok {
+		return Value[T]{}, nil
+	}
+	value, err := parse(s)
+	if err != nil {
+		return Value[T]{}, &EnvError{Key: key, Err: err}
+	}
+	return NewValue(value), nil
+}
+
+// FromEnvString returns the environment variable named by key.
+// the value is unset if the variable is absent, but set if it is empty
+func FromEnvString(key string) Value[string] {
+	return FromEnv(key, parseString)
+}
+
+// FromEnvInt returns the environment variable named by key, parsed as an int
+func FromEnvInt(key string) Value[int] {
+	return FromEnv(key, parseInt)
+}
+
+// FromEnvBool returns the environment variable named by key, parsed as a bool
+func FromEnvBool(key string) Value[bool] {
+	return FromEnv(key, strconv.ParseBool)
+}
+
+// FromEnvDuration returns the environment variable named by key, parsed as a
+// time.Duration
+func FromEnvDuration(key string) Value[time.Duration] {
+	return FromEnv(key, time.ParseDuration)
+}
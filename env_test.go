@@ -0,0 +1,60 @@
+package optional_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/heucuva/optional"
+)
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("OPTIONAL_TEST_NAME", "")
+	t.Setenv("OPTIONAL_TEST_PORT", "8080")
+	t.Setenv("OPTIONAL_TEST_DEBUG", "true")
+	t.Setenv("OPTIONAL_TEST_TIMEOUT", "5s")
+	t.Setenv("OPTIONAL_TEST_INVALID", "foo")
+
+	t.Run("Absent", func(t *testing.T) {
+		expect(t, "set", false, optional.FromEnvString("OPTIONAL_TEST_ABSENT").IsSet())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		expect(t, "value", "", optional.FromEnvString("OPTIONAL_TEST_NAME").MustGet())
+	})
+	t.Run("Typed", func(t *testing.T) {
+		expect(t, "port", 8080, optional.FromEnvInt("OPTIONAL_TEST_PORT").MustGet())
+		expect(t, "debug", true, optional.FromEnvBool("OPTIONAL_TEST_DEBUG").MustGet())
+		expect(t, "timeout", 5*time.Second, optional.FromEnvDuration("OPTIONAL_TEST_TIMEOUT").MustGet())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		expect(t, "set", false, optional.FromEnvInt("OPTIONAL_TEST_INVALID").IsSet())
+
+		value, err := optional.FromEnvErr("OPTIONAL_TEST_INVALID", strconv.Atoi)
+		expect(t, "set", false, value.IsSet())
+		var envErr *optional.EnvError
+		if !errors.As(err, &envErr) {
+			t.Fatalf("expected an EnvError, got %v", err)
+		}
+		expect(t, "key", "OPTIONAL_TEST_INVALID", envErr.Key)
+		var numErr *strconv.NumError
+		expect(t, "NumError", true, errors.As(err, &numErr))
+	})
+	t.Run("Err", func(t *testing.T) {
+		value, err := optional.FromEnvErr("OPTIONAL_TEST_PORT", strconv.Atoi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "port", 8080, value.MustGet())
+
+		value, err = optional.FromEnvErr("OPTIONAL_TEST_ABSENT", strconv.Atoi)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "set", false, value.IsSet())
+	})
+	t.Run("Layering", func(t *testing.T) {
+		port := optional.FromEnvInt("OPTIONAL_TEST_ABSENT").OrValue(80)
+		expect(t, "port", 80, port.MustGet())
+	})
+}
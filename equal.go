@@ -0,0 +1,20 @@
+package optional
+
+// Equal returns true if a and b are both unset, or are both set to equal values
+func Equal[T comparable](a, b Value[T]) bool {
+	return EqualFunc(a, b, func(x, y T) bool {
+		return x == y
+	})
+}
+
+// EqualFunc returns true if a and b are both unset, or are both set to values
+// that eq reports as equal. eq is only called when both values are set
+func EqualFunc[T any](a, b Value[T], eq func(x, y T) bool) bool {
+	if a.set != b.set {
+		return false
+	}
+	if !a.set {
+		return true
+	}
+	return eq(a.value, b.value)
+}
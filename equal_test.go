@@ -0,0 +1,42 @@
+package optional_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     optional.Value[int]
+		expected bool
+	}{
+		{"BothUnset", optional.Value[int]{}, optional.Value[int]{}, true},
+		{"OneUnset", optional.NewValue(0), optional.Value[int]{}, false},
+		{"SameValue", optional.NewValue(5), optional.NewValue(5), true},
+		{"DifferentValue", optional.NewValue(5), optional.NewValue(6), false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expect(t, "Equal", tc.expected, optional.Equal(tc.a, tc.b))
+			expect(t, "Equal (reversed)", tc.expected, optional.Equal(tc.b, tc.a))
+		})
+	}
+}
+
+func TestEqualFunc(t *testing.T) {
+	t.Run("Set", func(t *testing.T) {
+		a := optional.NewValue([]byte("foo"))
+		b := optional.NewValue([]byte("foo"))
+		expect(t, "EqualFunc", true, optional.EqualFunc(a, b, bytes.Equal))
+	})
+	t.Run("Unset", func(t *testing.T) {
+		var a, b optional.Value[[]byte]
+		expect(t, "EqualFunc", true, optional.EqualFunc(a, b, func(x, y []byte) bool {
+			t.Fatal("expected comparator not to be called")
+			return false
+		}))
+	})
+}
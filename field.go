@@ -0,0 +1,157 @@
+package optional
+
+import "encoding/json"
+
+type fieldState uint8
+
+const (
+	fieldAbsent = fieldState(iota)
+	fieldNull
+	fieldSet
+)
+
+// Field is a tri-state optional value, which tells apart a field that was
+// absent from a payload, one that was explicitly null, and one that holds
+// a value. This is what JSON PATCH-style APIs need, where "not provided"
+// means "leave as-is" and null means "clear it".
+//
+// When marshaling, absent and null fields both encode as null, but IsZero
+// only reports absent fields as zero - so tagging the field with `omitzero`
+// (encoding/json in Go 1.24 or newer) or `omitempty` (yaml) omits absent
+// fields while keeping explicit nulls.
+//
+// YAML decoders never hand null nodes to an unmarshaler, so a YAML null
+// decodes as absent.
+//
+// json.Unmarshal leaves fields whose keys are absent untouched, so structs
+// which are reused between payloads should be decoded with
+// UnmarshalJSONPresence instead.
+type Field[T any] struct {
+	state fieldState
+	value T
+}
+
+// NewField constructs a Field structure with a value already set into it
+func NewField[T any](value T) Field[T] {
+	var f Field[T]
+	f.Set(value)
+	return f
+}
+
+// NullField constructs a Field structure which is explicitly null
+func NullField[T any]() Field[T] {
+	var f Field[T]
+	f.SetNull()
+	return f
+}
+
+// IsZero is used by the json and yaml marshallers to determine "zero"-ness
+// for omitzero/omitempty. only absent fields are zero
+func (f Field[T]) IsZero() bool {
+	return f.state == fieldAbsent
+}
+
+// Reset clears the memory on the field, making it absent
+func (f *Field[T]) Reset() {
+	var empty T
+	f.value = empty
+	f.state = fieldAbsent
+}
+
+// SetNull clears the memory on the field, making it explicitly null
+func (f *Field[T]) SetNull() {
+	var empty T
+	f.value = empty
+	f.state = fieldNull
+}
+
+// Set updates the value of the field
+func (f *Field[T]) Set(value T) {
+	f.value = value
+	f.state = fieldSet
+}
+
+// IsAbsent returns true if the field was not provided at all
+func (f Field[T]) IsAbsent() bool {
+	return f.state == fieldAbsent
+}
+
+// IsNull returns true if the field was provided as an explicit null
+func (f Field[T]) IsNull() bool {
+	return f.state == fieldNull
+}
+
+// IsPresent returns true if the field was provided, either as null or
+// with a value
+func (f Field[T]) IsPresent() bool {
+	return f.state != fieldAbsent
+}
+
+// IsSet returns true if the field holds a value
+func (f Field[T]) IsSet() bool {
+	return f.state == fieldSet
+}
+
+// Get returns the value and whether the field holds one
+func (f Field[T]) Get() (T, bool) {
+	return f.value, f.state == fieldSet
+}
+
+// Value converts the field into a Value, which is set only if the field
+// holds a value
+func (f Field[T]) Value() Value[T] {
+	if f.state != fieldSet {
+		return Value[T]{}
+	}
+	return NewValue(f.value)
+}
+
+// MarshalJSON outputs the value of the Field, if it holds one.
+// otherwise, it returns nil
+func (f Field[T]) MarshalJSON() ([]byte, error) {
+	if f.state == fieldSet {
+		return marshalJSONValue(f.value)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON unmarshals a value out of json and safely into our struct.
+// a json null makes the field explicitly null
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 {
+		f.Reset()
+		return nil
+	}
+	if string(data) == "null" {
+		f.SetNull()
+		notifyDecode[T](true)
+		return nil
+	}
+	var val T
+	if err := json.Unmarshal(data, &val); err != nil {
+		return err
+	}
+	f.Set(val)
+	notifyDecode[T](false)
+	return nil
+}
+
+// MarshalYAML outputs the value of the Field, if it holds one.
+// otherwise, it returns nil
+func (f Field[T]) MarshalYAML() (any, error) {
+	if f.state == fieldSet {
+		return marshalYAMLValue(f.value)
+	}
+	return nil, nil
+}
+
+// UnmarshalYAML unmarshals a value out of yaml and safely into our struct
+func (f *Field[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	var val T
+	if err := unmarshal(&val); err != nil {
+		return err
+	}
+	f.Set(val)
+	notifyDecode[T](false)
+	return nil
+}
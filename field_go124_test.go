@@ -0,0 +1,26 @@
+//go:build go1.24
+
+package optional_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestFieldOmitZero(t *testing.T) {
+	type testOmitPatch struct {
+		Name  optional.Field[string] `json:"name,omitzero"`
+		Email optional.Field[string] `json:"email,omitzero"`
+		Age   optional.Field[int]    `json:"age,omitzero"`
+	}
+	blob, err := json.Marshal(testOmitPatch{
+		Name:  optional.NewField("Foo"),
+		Email: optional.NullField[string](),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, "json", `{"name":"Foo","email":null}`, string(blob))
+}
@@ -0,0 +1,77 @@
+package optional_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/heucuva/optional"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+type testPatch struct {
+	Name  optional.Field[string] `json:"name" yaml:"name,omitempty"`
+	Email optional.Field[string] `json:"email" yaml:"email,omitempty"`
+	Age   optional.Field[int]    `json:"age" yaml:"age,omitempty"`
+}
+
+func TestField(t *testing.T) {
+	t.Run("States", func(t *testing.T) {
+		var absent optional.Field[int]
+		expect(t, "absent.IsAbsent", true, absent.IsAbsent())
+		expect(t, "absent.IsPresent", false, absent.IsPresent())
+
+		null := optional.NullField[int]()
+		expect(t, "null.IsNull", true, null.IsNull())
+		expect(t, "null.IsPresent", true, null.IsPresent())
+		expect(t, "null.IsSet", false, null.IsSet())
+
+		set := optional.NewField(0)
+		expect(t, "set.IsSet", true, set.IsSet())
+		expect(t, "set.IsNull", false, set.IsNull())
+		value, ok := set.Get()
+		expect(t, "set.Get ok", true, ok)
+		expect(t, "set.Get", 0, value)
+		expect(t, "set.Value", 0, set.Value().MustGet())
+		expect(t, "null.Value set", false, null.Value().IsSet())
+
+		set.Reset()
+		expect(t, "reset.IsAbsent", true, set.IsAbsent())
+	})
+	t.Run("UnmarshalJSON", func(t *testing.T) {
+		var observed testPatch
+		if err := json.Unmarshal([]byte(`{"name":"Foo","email":null}`), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "name", "Foo", observed.Name.Value().MustGet())
+		expect(t, "email.IsNull", true, observed.Email.IsNull())
+		expect(t, "age.IsAbsent", true, observed.Age.IsAbsent())
+	})
+	t.Run("MarshalJSON", func(t *testing.T) {
+		blob, err := json.Marshal(testPatch{
+			Name:  optional.NewField("Foo"),
+			Email: optional.NullField[string](),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", `{"name":"Foo","email":null,"age":null}`, string(blob))
+	})
+	t.Run("MarshalYAML", func(t *testing.T) {
+		blob, err := yamlv3.Marshal(testPatch{
+			Name:  optional.NewField("Foo"),
+			Email: optional.NullField[string](),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "yaml", "name: Foo\nemail: null\n", string(blob))
+	})
+	t.Run("UnmarshalYAML", func(t *testing.T) {
+		var observed testPatch
+		if err := yamlv3.Unmarshal([]byte("name: Foo\n"), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "name", "Foo", observed.Name.Value().MustGet())
+		expect(t, "email.IsAbsent", true, observed.Email.IsAbsent())
+	})
+}
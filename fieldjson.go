@@ -0,0 +1,165 @@
+package optional
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// presenceField is implemented by pointers to Field
+type presenceField interface {
+	Reset()
+	UnmarshalJSON(data []byte) error
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// UnmarshalJSONPresence decodes the json object in data into the struct dest
+// points to, recording for each of its Field values whether the key was
+// absent, null, or present with a value. Fields whose keys are absent are
+// reset, so that a struct can be reused between payloads, which is not the
+// case with json.Unmarshal. Nested structs (and pointers to them) are
+// decoded the same way, while everything else is decoded by encoding/json.
+//
+// The object is scanned with a json.Decoder, matching keys to fields by
+// their json tags as json.Unmarshal does. Unknown keys are ignored.
+func UnmarshalJSONPresence(data []byte, dest any) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("optional: presence destination must be a non-nil pointer to a struct, got %T", dest)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := decodePresence(dec, rv.Elem()); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("optional: invalid json after top-level value")
+	}
+	return nil
+}
+
+// decodePresence decodes the next json value in dec, which must be an
+// object or null, into the struct rv
+func decodePresence(dec *json.Decoder, rv reflect.Value) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	resetPresence(rv)
+	if tok == nil {
+		return nil
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("optional: cannot decode json %v into %v", tok, rv.Type())
+	}
+
+	fields := jsonFields(rv.Type())
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+
+		field, ok := lookupJSONField(fields, key)
+		if !ok {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := decodePresenceField(dec, scanField(rv, field.index)); err != nil {
+			return fmt.Errorf("optional: decoding %s: %w", key, err)
+		}
+	}
+
+	_, err = dec.Token()
+	return err
+}
+
+// decodePresenceField decodes the next json value in dec into the field fv
+func decodePresenceField(dec *json.Decoder, fv reflect.Value) error {
+	if f, ok := fv.Addr().Interface().(presenceField); ok {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		return f.UnmarshalJSON(raw)
+	}
+	if !isPresenceStruct(fv.Type()) {
+		return dec.Decode(fv.Addr().Interface())
+	}
+	if fv.Kind() == reflect.Struct {
+		return decodePresence(dec, fv)
+	}
+
+	// a pointer to a struct is cleared by null, as encoding/json does
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+	if string(raw) == "null" {
+		fv.Set(reflect.Zero(fv.Type()))
+		return nil
+	}
+	if fv.IsNil() {
+		fv.Set(reflect.New(fv.Type().Elem()))
+	}
+	return decodePresence(json.NewDecoder(bytes.NewReader(raw)), fv.Elem())
+}
+
+// isPresenceStruct returns true if t is a struct, or a pointer to one,
+// whose fields are decoded by UnmarshalJSONPresence rather than by
+// encoding/json
+func isPresenceStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && !IsOptionalType(t) &&
+		!reflect.PointerTo(t).Implements(jsonUnmarshalerType) &&
+		!reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// resetPresence resets the Field values of the struct rv, and of the nested
+// structs held directly by it
+func resetPresence(rv reflect.Value) {
+	for _, field := range jsonFields(rv.Type()) {
+		fv, ok := jsonFieldValue(rv, field.index)
+		if !ok {
+			continue
+		}
+		if f, ok := fv.Addr().Interface().(presenceField); ok {
+			f.Reset()
+		} else if fv.Kind() == reflect.Struct && isPresenceStruct(fv.Type()) {
+			resetPresence(fv)
+		}
+	}
+}
+
+// lookupJSONField finds the field for key, preferring an exact match but
+// falling back to the case-insensitive match encoding/json performs
+func lookupJSONField(fields []jsonField, key string) (jsonField, bool) {
+	for _, field := range fields {
+		if field.name == key {
+			return field, true
+		}
+	}
+	for _, field := range fields {
+		if strings.EqualFold(field.name, key) {
+			return field, true
+		}
+	}
+	return jsonField{}, false
+}
@@ -0,0 +1,91 @@
+package optional_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/heucuva/optional"
+)
+
+type testPresenceAddress struct {
+	City optional.Field[string] `json:"city"`
+	Zip  optional.Field[string] `json:"zip"`
+}
+
+type testPresenceUser struct {
+	testPatch
+	Address  testPresenceAddress      `json:"address"`
+	Billing  *testPresenceAddress     `json:"billing"`
+	Tags     []string                 `json:"tags"`
+	Created  time.Time                `json:"created"`
+	Settings optional.Field[struct{}] `json:"settings"`
+}
+
+func TestUnmarshalJSONPresence(t *testing.T) {
+	var user testPresenceUser
+	err := optional.UnmarshalJSONPresence([]byte(`{
+		"name": "Foo",
+		"email": null,
+		"address": {"city": null},
+		"billing": {"zip": "75001"},
+		"tags": ["a", "b"],
+		"created": "2024-03-01T00:00:00Z",
+		"unknown": {"nested": [1, 2, 3]}
+	}`), &user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("States", func(t *testing.T) {
+		expect(t, "name", "Foo", user.Name.Value().MustGet())
+		expect(t, "email.IsNull", true, user.Email.IsNull())
+		expect(t, "age.IsAbsent", true, user.Age.IsAbsent())
+		expect(t, "address.city.IsNull", true, user.Address.City.IsNull())
+		expect(t, "address.zip.IsAbsent", true, user.Address.Zip.IsAbsent())
+		expect(t, "billing.city.IsAbsent", true, user.Billing.City.IsAbsent())
+		expect(t, "billing.zip", "75001", user.Billing.Zip.Value().MustGet())
+		expect(t, "settings.IsAbsent", true, user.Settings.IsAbsent())
+		expect(t, "tags", 2, len(user.Tags))
+		expect(t, "created", 2024, user.Created.Year())
+	})
+	t.Run("Reuse", func(t *testing.T) {
+		// fields left over from the previous payload become absent
+		reused := user
+		if err := optional.UnmarshalJSONPresence([]byte(`{"AGE": 5, "billing": null}`), &reused); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "name.IsAbsent", true, reused.Name.IsAbsent())
+		expect(t, "email.IsAbsent", true, reused.Email.IsAbsent())
+		expect(t, "age", 5, reused.Age.Value().MustGet())
+		expect(t, "address.city.IsAbsent", true, reused.Address.City.IsAbsent())
+		expect(t, "billing", true, reused.Billing == nil)
+		expect(t, "tags", 2, len(reused.Tags))
+	})
+	t.Run("Null", func(t *testing.T) {
+		reused := user
+		if err := optional.UnmarshalJSONPresence([]byte(`null`), &reused); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "name.IsAbsent", true, reused.Name.IsAbsent())
+	})
+	t.Run("Errors", func(t *testing.T) {
+		tests := map[string]string{
+			"NotObject":  `[1, 2]`,
+			"BadValue":   `{"age": "five"}`,
+			"BadNested":  `{"address": {"city": 5}}`,
+			"Truncated":  `{"name": "Foo"`,
+			"Trailing":   `{"name": "Foo"} {}`,
+			"BadPointer": `{"billing": 5}`,
+		}
+		for name, data := range tests {
+			var target testPresenceUser
+			if err := optional.UnmarshalJSONPresence([]byte(data), &target); err == nil {
+				t.Errorf("%s: expected failure, but got success", name)
+			}
+		}
+		var target testPresenceUser
+		if err := optional.UnmarshalJSONPresence([]byte(`{}`), target); err == nil {
+			t.Error("NotPointer: expected failure, but got success")
+		}
+	})
+}
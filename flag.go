@@ -0,0 +1,89 @@
+package optional
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Flag returns a flag.Value which parses its argument with parse and sets it
+// into v, so a command can tell a flag that was not passed apart from one
+// that was passed with a zero value
+func Flag[T any](v *Value[T], parse func(string) (T, error)) flag.Value {
+	return &valueFlag[T]{v: v, parse: parse}
+}
+
+// StringFlag returns a flag.Value which sets its argument into v
+func StringFlag(v *Value[string]) flag.Value {
+	return Flag(v, parseString)
+}
+
+// IntFlag returns a flag.Value which parses its argument as an int into v
+func IntFlag(v *Value[int]) flag.Value {
+	return Flag(v, parseInt)
+}
+
+// FloatFlag returns a flag.Value which parses its argument as a float64 into v
+func FloatFlag(v *Value[float64]) flag.Value {
+	return Flag(v, parseFloat)
+}
+
+// DurationFlag returns a flag.Value which parses its argument as a
+// time.Duration into v
+func DurationFlag(v *Value[time.Duration]) flag.Value {
+	return Flag(v, time.ParseDuration)
+}
+
+// BoolFlag returns a flag.Value which parses its argument as a bool into v.
+// like flag.Bool, it may be passed without an argument (e.g. -debug)
+func BoolFlag(v *Value[bool]) flag.Value {
+	return &boolFlag{valueFlag[bool]{v: v, parse: strconv.ParseBool}}
+}
+
+func parseString(s string) (string, error) {
+	return s, nil
+}
+
+func parseInt(s string) (int, error) {
+	i, err := strconv.ParseInt(s, 0, strconv.IntSize)
+	return int(i), err
+}
+
+func parseFloat(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+type valueFlag[T any] struct {
+	v     *Value[T]
+	parse func(string) (T, error)
+}
+
+func (f *valueFlag[T]) String() string {
+	// the flag package calls String on a zero flag to detect default values
+	if f.v == nil {
+		return ""
+	}
+	value, set := f.v.Get()
+	if !set {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+func (f *valueFlag[T]) Set(s string) error {
+	value, err := f.parse(s)
+	if err != nil {
+		return err
+	}
+	f.v.Set(value)
+	return nil
+}
+
+type boolFlag struct {
+	valueFlag[bool]
+}
+
+func (f *boolFlag) IsBoolFlag() bool {
+	return true
+}
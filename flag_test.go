@@ -0,0 +1,56 @@
+package optional_test
+
+import (
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/heucuva/optional"
+)
+
+func TestFlag(t *testing.T) {
+	var (
+		name    optional.Value[string]
+		count   optional.Value[int]
+		ratio   optional.Value[float64]
+		timeout optional.Value[time.Duration]
+		debug   optional.Value[bool]
+		quiet   optional.Value[bool]
+	)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(optional.StringFlag(&name), "name", "")
+	fs.Var(optional.IntFlag(&count), "count", "")
+	fs.Var(optional.FloatFlag(&ratio), "ratio", "")
+	fs.Var(optional.DurationFlag(&timeout), "timeout", "")
+	fs.Var(optional.BoolFlag(&debug), "debug", "")
+	fs.Var(optional.BoolFlag(&quiet), "quiet", "")
+
+	if err := fs.Parse([]string{"-count", "0", "-timeout=5s", "-debug"}); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, "name set", false, name.IsSet())
+	expect(t, "count", 0, count.MustGet())
+	expect(t, "ratio set", false, ratio.IsSet())
+	expect(t, "timeout", 5*time.Second, timeout.MustGet())
+	expect(t, "debug", true, debug.MustGet())
+	expect(t, "quiet set", false, quiet.IsSet())
+
+	t.Run("Invalid", func(t *testing.T) {
+		if err := fs.Parse([]string{"-count", "foo"}); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+	t.Run("Custom", func(t *testing.T) {
+		var level optional.Value[uint8]
+		f := optional.Flag(&level, func(s string) (uint8, error) {
+			return uint8(len(s)), nil
+		})
+		if err := f.Set("abc"); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "level", 3, level.MustGet())
+		expect(t, "String", "3", f.String())
+	})
+}
@@ -0,0 +1,20 @@
+package optional
+
+// Fold returns the result of onSet applied to the value of v, if v is set.
+// otherwise, it returns the result of onUnset
+func Fold[T, R any](v Value[T], onSet func(T) R, onUnset func() R) R {
+	if v.set {
+		return onSet(v.value)
+	}
+	return onUnset()
+}
+
+// Match calls onSet with the value, if it is set.
+// otherwise, it calls onUnset
+func (o Value[T]) Match(onSet func(T), onUnset func()) {
+	if o.set {
+		onSet(o.value)
+		return
+	}
+	onUnset()
+}
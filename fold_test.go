@@ -0,0 +1,29 @@
+package optional_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestFold(t *testing.T) {
+	describe := func(v optional.Value[int]) string {
+		return optional.Fold(v, strconv.Itoa, func() string { return "none" })
+	}
+	expect(t, "set", "42", describe(optional.NewValue(42)))
+	expect(t, "set zero", "0", describe(optional.NewValue(0)))
+	expect(t, "unset", "none", describe(optional.Value[int]{}))
+}
+
+func TestValueMatch(t *testing.T) {
+	var observed string
+	onSet := func(v string) { observed = "set " + v }
+	onUnset := func() { observed = "unset" }
+
+	optional.NewValue("Foo").Match(onSet, onUnset)
+	expect(t, "set", "set Foo", observed)
+
+	optional.Value[string]{}.Match(onSet, onUnset)
+	expect(t, "unset", "unset", observed)
+}
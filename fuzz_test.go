@@ -0,0 +1,159 @@
+package optional_test
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/heucuva/optional"
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+type fuzzNested struct {
+	Note  optional.Value[string] `json:"note" yaml:"note"`
+	Level optional.Value[int64]  `json:"level" yaml:"level"`
+}
+
+type fuzzDocument struct {
+	Name   optional.Value[string]     `json:"name" yaml:"name"`
+	Count  optional.Value[int64]      `json:"count" yaml:"count"`
+	Ratio  optional.Value[float64]    `json:"ratio" yaml:"ratio"`
+	Flag   optional.Value[bool]       `json:"flag" yaml:"flag"`
+	Nested optional.Value[fuzzNested] `json:"nested" yaml:"nested"`
+	Tags   optional.Value[[]string]   `json:"tags" yaml:"tags"`
+	Items  []optional.Value[string]   `json:"items" yaml:"items,omitempty"`
+}
+
+// newFuzzDocument builds a document out of fuzzed values, setting the
+// fields selected by the bits of mask
+func newFuzzDocument(s string, i int64, f float64, b bool, mask uint8) fuzzDocument {
+	var doc fuzzDocument
+	doc.Name.SetIf(mask&0x01 != 0, s)
+	doc.Count.SetIf(mask&0x02 != 0, i)
+	doc.Ratio.SetIf(mask&0x04 != 0, f)
+	doc.Flag.SetIf(mask&0x08 != 0, b)
+	if mask&0x10 != 0 {
+		var nested fuzzNested
+		nested.Note.SetIf(mask&0x20 != 0, s)
+		nested.Level.SetIf(mask&0x40 != 0, -i)
+		doc.Nested.Set(nested)
+	}
+	if mask&0x80 != 0 {
+		// a set empty slice, since a set nil slice encodes as null
+		doc.Tags.Set(append([]string{}, s, s+s))
+		doc.Items = []optional.Value[string]{optional.NewValue(s), {}}
+	}
+	return doc
+}
+
+func addFuzzSeeds(f *testing.F) {
+	f.Add("Foo", int64(1), 1.5, true, uint8(0xff))
+	f.Add("", int64(0), 0.0, false, uint8(0x00))
+	f.Add("", int64(0), 0.0, false, uint8(0xff))
+	f.Add(`say "hi"`, int64(math.MaxInt64), -1e300, true, uint8(0xb5))
+	f.Add(`'single' and "double"`, int64(math.MinInt64), 1e-7, false, uint8(0xff))
+	f.Add("null", int64(-1), math.SmallestNonzeroFloat64, true, uint8(0x91))
+	f.Add("~", int64(42), 0.1, true, uint8(0xa1))
+	f.Add("123", int64(7), 3.0, false, uint8(0xff))
+	f.Add("key: value\n- item", int64(7), 3.0, false, uint8(0xff))
+	f.Add("  padded\t", int64(7), 3.0, false, uint8(0xff))
+	f.Add("ünïcode ✓", int64(7), 3.0, false, uint8(0xff))
+}
+
+func FuzzJSONRoundTrip(f *testing.F) {
+	addFuzzSeeds(f)
+	ctx := optional.WithDecoderOptions(context.Background(), optional.DecoderOptions{NullAsUnset: true})
+	f.Fuzz(func(t *testing.T, s string, i int64, fl float64, b bool, mask uint8) {
+		if !utf8.ValidString(s) || math.IsNaN(fl) || math.IsInf(fl, 0) {
+			// json cannot represent these
+			t.Skip()
+		}
+		expected := newFuzzDocument(s, i, fl, b, mask)
+		blob, err := json.Marshal(expected)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// unset values encode as null, which decodes as a set zero value
+		// unless decoded with NullAsUnset
+		var observed fuzzDocument
+		if err := optional.UnmarshalJSONContext(ctx, blob, &observed); err != nil {
+			t.Fatalf("unmarshaling %s: %v", blob, err)
+		}
+		if !reflect.DeepEqual(expected, observed) {
+			t.Fatalf("%s: expected %+v, got %+v", blob, expected, observed)
+		}
+	})
+}
+
+// yamlCodec is the marshal and unmarshal functions of a yaml library
+type yamlCodec struct {
+	name      string
+	marshal   func(any) ([]byte, error)
+	unmarshal func([]byte, any) error
+}
+
+var yamlCodecs = []yamlCodec{
+	{"yaml.v2", yaml.Marshal, yaml.Unmarshal},
+	{"yaml.v3", yamlv3.Marshal, yamlv3.Unmarshal},
+}
+
+// roundTrips returns true if the codec round-trips s on its own, in a
+// mapping and in a sequence, as the yaml libraries mangle some strings
+// (such as yaml.v3 with "\n" in a sequence) whether or not they are optional
+func (c yamlCodec) roundTrips(s string) bool {
+	type plain struct {
+		S string   `yaml:"s"`
+		L []string `yaml:"l"`
+	}
+	expected := plain{S: s, L: []string{s, s + s}}
+	blob, err := c.marshal(expected)
+	if err != nil {
+		return false
+	}
+	var observed plain
+	return c.unmarshal(blob, &observed) == nil && reflect.DeepEqual(expected, observed)
+}
+
+func FuzzYAMLRoundTrip(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, s string, i int64, fl float64, b bool, mask uint8) {
+		if math.IsNaN(fl) {
+			// NaN never equals itself
+			t.Skip()
+		}
+
+		for _, codec := range yamlCodecs {
+			if !codec.roundTrips(s) {
+				continue
+			}
+			expected := newFuzzDocument(s, i, fl, b, mask)
+			blob, err := codec.marshal(expected)
+			if err != nil {
+				t.Fatalf("%s: %v", codec.name, err)
+			}
+			var observed fuzzDocument
+			if err := codec.unmarshal(blob, &observed); err != nil {
+				t.Fatalf("%s: unmarshaling %q: %v", codec.name, blob, err)
+			}
+
+			// yaml.v3 handles null nodes before calling the unmarshaler, and
+			// drops them from slices of structs, so unset items are lost
+			if codec.name == "yaml.v3" && expected.Items != nil {
+				var items []optional.Value[string]
+				for _, item := range expected.Items {
+					if item.IsSet() {
+						items = append(items, item)
+					}
+				}
+				expected.Items = items
+			}
+			if !reflect.DeepEqual(expected, observed) {
+				t.Fatalf("%s: %q: expected %+v, got %+v", codec.name, blob, expected, observed)
+			}
+		}
+	})
+}
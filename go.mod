@@ -5,4 +5,5 @@ go 1.18
 require (
 	golang.org/x/exp v0.0.0-20220713135740-79cabaa25d75
 	gopkg.in/yaml.v2 v2.4.0
+	gopkg.in/yaml.v3 v3.0.1
 )
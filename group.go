@@ -0,0 +1,83 @@
+package optional
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Presence is implemented by anything that reports whether it is set,
+// including every optional container in this package (and pointers to them)
+type Presence interface {
+	IsSet() bool
+}
+
+// Group ties several optional values together, so rules such as
+// "these fields must be provided together" can be checked in one place.
+//
+// A Group holds whatever was passed to Add, so add pointers to values
+// (e.g. &req.Start) if the group should observe later changes to them.
+type Group struct {
+	names   []string
+	members []Presence
+}
+
+// NewGroup constructs an empty Group
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// Add adds a named member to the group and returns the group, for chaining
+func (g *Group) Add(name string, member Presence) *Group {
+	g.names = append(g.names, name)
+	g.members = append(g.members, member)
+	return g
+}
+
+// AllSet returns true if every member of the group is set
+func (g *Group) AllSet() bool {
+	for _, m := range g.members {
+		if !m.IsSet() {
+			return false
+		}
+	}
+	return true
+}
+
+// AnySet returns true if at least one member of the group is set
+func (g *Group) AnySet() bool {
+	for _, m := range g.members {
+		if m.IsSet() {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireAllOrNone returns a *PartialGroupError if some, but not all, of
+// the members of the group are set
+func (g *Group) RequireAllOrNone() error {
+	if !g.AnySet() || g.AllSet() {
+		return nil
+	}
+
+	err := &PartialGroupError{}
+	for i, m := range g.members {
+		if m.IsSet() {
+			err.Set = append(err.Set, g.names[i])
+		} else {
+			err.Unset = append(err.Unset, g.names[i])
+		}
+	}
+	return err
+}
+
+// PartialGroupError is returned when only some members of a Group are set
+type PartialGroupError struct {
+	Set   []string
+	Unset []string
+}
+
+func (e *PartialGroupError) Error() string {
+	return fmt.Sprintf("optional: %s must be provided together with %s",
+		strings.Join(e.Unset, ", "), strings.Join(e.Set, ", "))
+}
@@ -0,0 +1,53 @@
+package optional_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestGroup(t *testing.T) {
+	var (
+		start optional.Value[int]
+		end   optional.Value[int]
+		unit  optional.Value[string]
+	)
+	group := optional.NewGroup().
+		Add("start", &start).
+		Add("end", &end).
+		Add("unit", &unit)
+
+	t.Run("None", func(t *testing.T) {
+		expect(t, "AllSet", false, group.AllSet())
+		expect(t, "AnySet", false, group.AnySet())
+		if err := group.RequireAllOrNone(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("Some", func(t *testing.T) {
+		start.Set(1)
+		unit.Set("s")
+		defer start.Reset()
+		defer unit.Reset()
+		expect(t, "AllSet", false, group.AllSet())
+		expect(t, "AnySet", true, group.AnySet())
+		err := group.RequireAllOrNone()
+		var partial *optional.PartialGroupError
+		if !errors.As(err, &partial) {
+			t.Fatalf("expected PartialGroupError, got %v", err)
+		}
+		expect(t, "len(Set)", 2, len(partial.Set))
+		expect(t, "Unset[0]", "end", partial.Unset[0])
+		expect(t, "message", "optional: end must be provided together with start, unit", err.Error())
+	})
+	t.Run("All", func(t *testing.T) {
+		start.Set(1)
+		end.Set(2)
+		unit.Set("s")
+		expect(t, "AllSet", true, group.AllSet())
+		if err := group.RequireAllOrNone(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
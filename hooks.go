@@ -0,0 +1,37 @@
+package optional
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// DecodeHook is called whenever an optional value is unmarshaled.
+// typeName is the name of the optional's element type and wasNull reports
+// whether the encoded value was an explicit null.
+type DecodeHook func(typeName string, wasNull bool)
+
+var decodeHook atomic.Value // DecodeHook
+
+// OnDecode installs a hook that is invoked every time an optional value is
+// unmarshaled, which is useful for collecting metrics on how often fields
+// arrive set or null. Fields that are absent from the payload are never
+// unmarshaled, so they do not trigger the hook.
+// Passing nil removes the currently installed hook.
+func OnDecode(hook DecodeHook) {
+	decodeHook.Store(hook)
+}
+
+// NotifyDecode reports the decoding of an optional value holding a T to the
+// hook installed by OnDecode. Codecs implemented outside this package, such
+// as optmsgpack, call it so that their decodes are reported as well.
+func NotifyDecode[T any](wasNull bool) {
+	notifyDecode[T](wasNull)
+}
+
+func notifyDecode[T any](wasNull bool) {
+	hook, _ := decodeHook.Load().(DecodeHook)
+	if hook == nil {
+		return
+	}
+	hook(reflect.TypeOf((*T)(nil)).Elem().String(), wasNull)
+}
@@ -0,0 +1,128 @@
+package optional_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/heucuva/optional"
+	"gopkg.in/yaml.v2"
+)
+
+func TestOnDecode(t *testing.T) {
+	type decodeEvent struct {
+		typeName string
+		wasNull  bool
+	}
+	var events []decodeEvent
+	optional.OnDecode(func(typeName string, wasNull bool) {
+		events = append(events, decodeEvent{typeName: typeName, wasNull: wasNull})
+	})
+	defer optional.OnDecode(nil)
+
+	type testStruct struct {
+		A optional.Value[int]    `json:"a" yaml:"a"`
+		B optional.Value[string] `json:"b" yaml:"b"`
+		C optional.Value[bool]   `json:"c" yaml:"c"`
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		events = nil
+		var observed testStruct
+		if err := json.Unmarshal([]byte(`{"a":5,"b":null}`), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "len(events)", 2, len(events))
+		expect(t, "events[0].typeName", "int", events[0].typeName)
+		expect(t, "events[0].wasNull", false, events[0].wasNull)
+		expect(t, "events[1].typeName", "string", events[1].typeName)
+		expect(t, "events[1].wasNull", true, events[1].wasNull)
+	})
+	t.Run("YAML", func(t *testing.T) {
+		events = nil
+		var observed testStruct
+		if err := yaml.Unmarshal([]byte("a: 5\nc: true\n"), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "len(events)", 2, len(events))
+		expect(t, "events[1].typeName", "bool", events[1].typeName)
+	})
+	t.Run("Text", func(t *testing.T) {
+		events = nil
+		var a optional.Value[int]
+		var b optional.Value[string]
+		if err := a.UnmarshalText([]byte("5")); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.UnmarshalText([]byte{}); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "len(events)", 2, len(events))
+		expect(t, "events[0].typeName", "int", events[0].typeName)
+		expect(t, "events[0].wasNull", false, events[0].wasNull)
+		expect(t, "events[1].wasNull", true, events[1].wasNull)
+	})
+	t.Run("Gob", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := gob.NewEncoder(&buf)
+		if err := enc.Encode(optional.NewValue(5)); err != nil {
+			t.Fatal(err)
+		}
+		if err := enc.Encode(optional.Value[string]{}); err != nil {
+			t.Fatal(err)
+		}
+		events = nil
+		dec := gob.NewDecoder(&buf)
+		var a optional.Value[int]
+		var b optional.Value[string]
+		if err := dec.Decode(&a); err != nil {
+			t.Fatal(err)
+		}
+		if err := dec.Decode(&b); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "len(events)", 2, len(events))
+		expect(t, "events[0].typeName", "int", events[0].typeName)
+		expect(t, "events[0].wasNull", false, events[0].wasNull)
+		expect(t, "events[1].wasNull", true, events[1].wasNull)
+	})
+	t.Run("XML", func(t *testing.T) {
+		events = nil
+		var observed struct {
+			A optional.Value[int]    `xml:"a,attr"`
+			B optional.Value[string] `xml:"b"`
+		}
+		if err := xml.Unmarshal([]byte(`<x a="5"><b>Foo</b></x>`), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "len(events)", 2, len(events))
+		expect(t, "events[0].typeName", "int", events[0].typeName)
+		expect(t, "events[1].typeName", "string", events[1].typeName)
+	})
+	t.Run("SQL", func(t *testing.T) {
+		events = nil
+		var a optional.Value[int]
+		var b optional.Value[string]
+		if err := a.Scan(int64(5)); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.Scan(nil); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "len(events)", 2, len(events))
+		expect(t, "events[0].typeName", "int", events[0].typeName)
+		expect(t, "events[0].wasNull", false, events[0].wasNull)
+		expect(t, "events[1].wasNull", true, events[1].wasNull)
+	})
+	t.Run("Removed", func(t *testing.T) {
+		optional.OnDecode(nil)
+		events = nil
+		var observed optional.Value[int]
+		if err := json.Unmarshal([]byte(`5`), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "len(events)", 0, len(events))
+	})
+}
@@ -0,0 +1,15 @@
+//go:build go1.23
+
+package optional
+
+import "iter"
+
+// Iter returns an iterator yielding the value, if it is set.
+// otherwise, the iterator yields nothing
+func (o Value[T]) Iter() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if o.set {
+			yield(o.value)
+		}
+	}
+}
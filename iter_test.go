@@ -0,0 +1,29 @@
+//go:build go1.23
+
+package optional_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestValueIter(t *testing.T) {
+	t.Run("Set", func(t *testing.T) {
+		observed := slices.Collect(optional.NewValue(5).Iter())
+		expect(t, "len", 1, len(observed))
+		expect(t, "value", 5, observed[0])
+	})
+	t.Run("Unset", func(t *testing.T) {
+		for range (optional.Value[int]{}).Iter() {
+			t.Fatal("expected no values")
+		}
+	})
+	t.Run("Break", func(t *testing.T) {
+		for v := range optional.NewValue("Foo").Iter() {
+			expect(t, "value", "Foo", v)
+			break
+		}
+	})
+}
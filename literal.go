@@ -0,0 +1,386 @@
+package optional
+
+import (
+	"fmt"
+	"go/format"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ToGoLiteral renders v as a Go source expression that evaluates to an equal
+// value, using NewValue for set optionals and zero values for unset ones.
+// It is intended for snapshotting optional-laden structures as golden
+// fixtures or generated code.
+//
+// Named types are qualified by their package name, so the result compiles
+// wherever those packages are imported under their default names. Values
+// which have no literal form (functions, channels, pointers to non-structs,
+// non-zero unexported fields, NaN and infinite floats) cause an error.
+// Negative zero floats are rendered with math.Copysign.
+func ToGoLiteral(v any) (string, error) {
+	var sb literalWriter
+	if err := writeLiteral(&sb, reflect.ValueOf(v), true); err != nil {
+		return "", err
+	}
+	out, err := format.Source([]byte(sb.String()))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// goLiteral renders v like ToGoLiteral, but on a single line and without
+// running it through go/format, for use by GoString
+func goLiteral(v any) (string, error) {
+	sb := literalWriter{singleLine: true}
+	if err := writeLiteral(&sb, reflect.ValueOf(v), true); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// literalWriter collects a literal, laying out the elements of composite
+// literals one per line (for go/format to indent) or on a single line
+type literalWriter struct {
+	strings.Builder
+	singleLine bool
+}
+
+// openElements starts the elements of a composite literal, if it has any
+func (sb *literalWriter) openElements(n int) {
+	sb.WriteString("{")
+	if n > 0 && !sb.singleLine {
+		sb.WriteString("\n")
+	}
+}
+
+// writeElement writes element i of a composite literal
+func (sb *literalWriter) writeElement(i int, element string) {
+	if sb.singleLine {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(element)
+		return
+	}
+	sb.WriteString(element + ",\n")
+}
+
+func writeLiteral(sb *literalWriter, rv reflect.Value, typed bool) error {
+	if !rv.IsValid() {
+		sb.WriteString("nil")
+		return nil
+	}
+
+	t := rv.Type()
+	if IsOptionalType(t) {
+		return writeOptionalLiteral(sb, rv)
+	}
+
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		lit, err := scalarLiteral(rv)
+		if err != nil {
+			return err
+		}
+		if !typed {
+			name, err := literalTypeName(t)
+			if err != nil {
+				return err
+			}
+			lit = name + "(" + lit + ")"
+		}
+		sb.WriteString(lit)
+
+	case reflect.Interface:
+		if rv.IsNil() {
+			sb.WriteString("nil")
+			return nil
+		}
+		return writeLiteral(sb, rv.Elem(), false)
+
+	case reflect.Pointer:
+		if rv.IsNil() {
+			sb.WriteString("nil")
+			return nil
+		}
+		if t.Elem().Kind() != reflect.Struct {
+			return fmt.Errorf("optional: cannot render pointer to %v as a literal", t.Elem())
+		}
+		sb.WriteString("&")
+		return writeLiteral(sb, rv.Elem(), false)
+
+	case reflect.Slice:
+		if rv.IsNil() {
+			sb.WriteString("nil")
+			return nil
+		}
+		return writeElements(sb, rv, t)
+
+	case reflect.Array:
+		return writeElements(sb, rv, t)
+
+	case reflect.Map:
+		if rv.IsNil() {
+			sb.WriteString("nil")
+			return nil
+		}
+		return writeMapLiteral(sb, rv, t)
+
+	case reflect.Struct:
+		return writeStructLiteral(sb, rv, t)
+
+	default:
+		return fmt.Errorf("optional: cannot render %v as a literal", t)
+	}
+	return nil
+}
+
+func writeOptionalLiteral(sb *literalWriter, rv reflect.Value) error {
+	opt := rv.Interface().(anyOptional)
+	typeArgs, err := literalTypeArgs(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	value, set := opt.AsAny()
+	if !set {
+		name, err := literalTypeName(rv.Type())
+		if err != nil {
+			return err
+		}
+		sb.WriteString(name + "{}")
+		return nil
+	}
+
+	suffix := ""
+	container := rv.Type().Name()
+	container = container[:strings.IndexByte(container, '[')]
+	switch container {
+	case "Boxed":
+		sb.WriteString("optional.NewBoxed[" + typeArgs + "](")
+	case "ReadOnly":
+		sb.WriteString("optional.NewValue[" + typeArgs + "](")
+		suffix = ".Freeze()"
+	case "Strict":
+		sb.WriteString("optional.NewStrict[" + typeArgs + "](")
+	case "Sensitive":
+		sb.WriteString("optional.NewSensitive[" + typeArgs + "](")
+	case "Encoded":
+		sb.WriteString("optional.NewEncoded[" + typeArgs + "](")
+	default:
+		sb.WriteString("optional.NewValue[" + typeArgs + "](")
+	}
+
+	ev := reflect.New(opt.elemType()).Elem()
+	if value != nil {
+		ev.Set(reflect.ValueOf(value))
+	}
+	if err := writeLiteral(sb, ev, true); err != nil {
+		return err
+	}
+	sb.WriteString(")" + suffix)
+	return nil
+}
+
+func writeElements(sb *literalWriter, rv reflect.Value, t reflect.Type) error {
+	name, err := literalTypeName(t)
+	if err != nil {
+		return err
+	}
+	sb.WriteString(name)
+	sb.openElements(rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		elem := literalWriter{singleLine: sb.singleLine}
+		if err := writeLiteral(&elem, rv.Index(i), true); err != nil {
+			return err
+		}
+		sb.writeElement(i, elem.String())
+	}
+	sb.WriteString("}")
+	return nil
+}
+
+func writeMapLiteral(sb *literalWriter, rv reflect.Value, t reflect.Type) error {
+	name, err := literalTypeName(t)
+	if err != nil {
+		return err
+	}
+
+	type entry struct {
+		key, value string
+	}
+	entries := make([]entry, 0, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		key := literalWriter{singleLine: sb.singleLine}
+		value := literalWriter{singleLine: sb.singleLine}
+		if err := writeLiteral(&key, iter.Key(), true); err != nil {
+			return err
+		}
+		if err := writeLiteral(&value, iter.Value(), true); err != nil {
+			return err
+		}
+		entries = append(entries, entry{key: key.String(), value: value.String()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	sb.WriteString(name)
+	sb.openElements(len(entries))
+	for i, e := range entries {
+		sb.writeElement(i, e.key+": "+e.value)
+	}
+	sb.WriteString("}")
+	return nil
+}
+
+func writeStructLiteral(sb *literalWriter, rv reflect.Value, t reflect.Type) error {
+	name, err := literalTypeName(t)
+	if err != nil {
+		return err
+	}
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := rv.Field(i)
+		if fv.IsZero() {
+			continue
+		}
+		if !field.IsExported() {
+			return fmt.Errorf("optional: cannot render unexported field %s of %v as a literal", field.Name, t)
+		}
+		value := literalWriter{singleLine: sb.singleLine}
+		if err := writeLiteral(&value, fv, true); err != nil {
+			return err
+		}
+		fields = append(fields, field.Name+": "+value.String())
+	}
+
+	sb.WriteString(name)
+	sb.openElements(len(fields))
+	for i, field := range fields {
+		sb.writeElement(i, field)
+	}
+	sb.WriteString("}")
+	return nil
+}
+
+func scalarLiteral(rv reflect.Value) (string, error) {
+	switch rv.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.String:
+		return strconv.Quote(rv.String()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return floatLiteral(rv.Float(), rv.Type().Bits())
+	case reflect.Complex64, reflect.Complex128:
+		c := rv.Complex()
+		bits := rv.Type().Bits() / 2
+		r, err := floatLiteral(real(c), bits)
+		if err != nil {
+			return "", err
+		}
+		i, err := floatLiteral(imag(c), bits)
+		if err != nil {
+			return "", err
+		}
+		return "complex(" + r + ", " + i + ")", nil
+	}
+	return "", fmt.Errorf("optional: cannot render %v as a literal", rv.Type())
+}
+
+func floatLiteral(f float64, bits int) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("optional: cannot render %v as a literal", f)
+	}
+	if f == 0 && math.Signbit(f) {
+		return "math.Copysign(0, -1)", nil
+	}
+	lit := strconv.FormatFloat(f, 'g', -1, bits)
+	if !strings.ContainsAny(lit, ".eE") {
+		lit += ".0"
+	}
+	return lit, nil
+}
+
+// policied is implemented by the optional containers with a policy type
+// parameter, such as Encoded
+type policied interface {
+	policyType() reflect.Type
+}
+
+// literalTypeArgs returns the type arguments of the optional type t, as they
+// would be written in Go source
+func literalTypeArgs(t reflect.Type) (string, error) {
+	args, err := literalTypeName(ElemType(t))
+	if err != nil {
+		return "", err
+	}
+	if p, ok := reflect.Zero(t).Interface().(policied); ok {
+		policy, err := literalTypeName(p.policyType())
+		if err != nil {
+			return "", err
+		}
+		args += ", " + policy
+	}
+	return args, nil
+}
+
+// literalTypeName returns the name of t as it would be written in Go source
+func literalTypeName(t reflect.Type) (string, error) {
+	if IsOptionalType(t) {
+		typeArgs, err := literalTypeArgs(t)
+		if err != nil {
+			return "", err
+		}
+		container := t.Name()
+		return "optional." + container[:strings.IndexByte(container, '[')] + "[" + typeArgs + "]", nil
+	}
+
+	if t.Name() != "" {
+		if strings.ContainsRune(t.Name(), '[') {
+			return "", fmt.Errorf("optional: cannot render generic type %v as a literal", t)
+		}
+		if t.PkgPath() == "" {
+			return t.Name(), nil
+		}
+		return t.String(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		elemName, err := literalTypeName(t.Elem())
+		return "*" + elemName, err
+	case reflect.Slice:
+		elemName, err := literalTypeName(t.Elem())
+		return "[]" + elemName, err
+	case reflect.Array:
+		elemName, err := literalTypeName(t.Elem())
+		return fmt.Sprintf("[%d]%s", t.Len(), elemName), err
+	case reflect.Map:
+		keyName, err := literalTypeName(t.Key())
+		if err != nil {
+			return "", err
+		}
+		elemName, err := literalTypeName(t.Elem())
+		return "map[" + keyName + "]" + elemName, err
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return "any", nil
+		}
+	}
+	return "", fmt.Errorf("optional: cannot render type %v as a literal", t)
+}
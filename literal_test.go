@@ -0,0 +1,111 @@
+package optional_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestToGoLiteral(t *testing.T) {
+	type testInner struct {
+		Port optional.Value[uint16]
+	}
+	type testConfig struct {
+		Name    optional.Value[string]
+		Debug   optional.Value[bool]
+		Ratio   optional.Value[float64]
+		Tags    optional.Value[[]string]
+		Inner   testInner
+		Nested  *testInner
+		Limits  map[string]optional.Value[int]
+		Unused  optional.Value[int]
+		Boxed   optional.Boxed[int]
+		Frozen  optional.ReadOnly[int]
+		Skipped int
+	}
+
+	t.Run("Struct", func(t *testing.T) {
+		observed, err := optional.ToGoLiteral(testConfig{
+			Name:   optional.NewValue("Foo"),
+			Debug:  optional.NewValue(false),
+			Ratio:  optional.NewValue(2.0),
+			Tags:   optional.NewValue([]string{"a", "b"}),
+			Inner:  testInner{Port: optional.NewValue[uint16](8080)},
+			Nested: &testInner{},
+			Limits: map[string]optional.Value[int]{
+				"b": {},
+				"a": optional.NewValue(1),
+			},
+			Boxed:  optional.NewBoxed(5),
+			Frozen: optional.NewValue(6).Freeze(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `optional_test.testConfig{
+	Name:  optional.NewValue[string]("Foo"),
+	Debug: optional.NewValue[bool](false),
+	Ratio: optional.NewValue[float64](2.0),
+	Tags: optional.NewValue[[]string]([]string{
+		"a",
+		"b",
+	}),
+	Inner: optional_test.testInner{
+		Port: optional.NewValue[uint16](8080),
+	},
+	Nested: &optional_test.testInner{},
+	Limits: map[string]optional.Value[int]{
+		"a": optional.NewValue[int](1),
+		"b": optional.Value[int]{},
+	},
+	Boxed:  optional.NewBoxed[int](5),
+	Frozen: optional.NewValue[int](6).Freeze(),
+}`
+		expect(t, "literal", expected, observed)
+	})
+	t.Run("Unset", func(t *testing.T) {
+		observed, err := optional.ToGoLiteral(optional.Value[int32]{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "literal", "optional.Value[int32]{}", observed)
+	})
+	t.Run("Encoded", func(t *testing.T) {
+		type testRequest struct {
+			Name  optional.Encoded[string, optional.UnsetEmptyString]
+			Email optional.Encoded[string, optional.UnsetOmit]
+		}
+		observed, err := optional.ToGoLiteral(testRequest{
+			Name: optional.NewEncoded[string, optional.UnsetEmptyString]("Foo"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `optional_test.testRequest{
+	Name: optional.NewEncoded[string, optional.UnsetEmptyString]("Foo"),
+}`
+		expect(t, "literal", expected, observed)
+
+		observed, err = optional.ToGoLiteral(optional.Encoded[string, optional.UnsetOmit]{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "unset literal", "optional.Encoded[string, optional.UnsetOmit]{}", observed)
+	})
+	t.Run("Interface", func(t *testing.T) {
+		observed, err := optional.ToGoLiteral(optional.NewValue[any](int8(5)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "literal", "optional.NewValue[any](int8(5))", observed)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		if _, err := optional.ToGoLiteral(optional.NewValue(math.NaN())); err == nil {
+			t.Fatal("expected failure for NaN, but got success")
+		}
+		if _, err := optional.ToGoLiteral(optional.NewValue(func() {})); err == nil {
+			t.Fatal("expected failure for func, but got success")
+		}
+	})
+}
@@ -0,0 +1,19 @@
+package optional
+
+// Map returns a Value holding the result of fn applied to the value of v,
+// if v is set. otherwise, it returns an unset Value
+func Map[T, U any](v Value[T], fn func(T) U) Value[U] {
+	if !v.set {
+		return Value[U]{}
+	}
+	return NewValue(fn(v.value))
+}
+
+// Apply returns a Value holding the result of fn applied to the values of a
+// and b, if both are set. otherwise, it returns an unset Value
+func Apply[A, B, C any](a Value[A], b Value[B], fn func(A, B) C) Value[C] {
+	if !a.set || !b.set {
+		return Value[C]{}
+	}
+	return NewValue(fn(a.value, b.value))
+}
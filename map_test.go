@@ -0,0 +1,47 @@
+package optional_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestMap(t *testing.T) {
+	t.Run("Set", func(t *testing.T) {
+		encounteredValue, encounteredSet := optional.Map(optional.NewValue(42), strconv.Itoa).Get()
+		expect(t, "set", true, encounteredSet)
+		expect(t, "value", "42", encounteredValue)
+	})
+	t.Run("Unset", func(t *testing.T) {
+		called := false
+		target := optional.Map(optional.Value[int]{}, func(v int) string {
+			called = true
+			return strconv.Itoa(v)
+		})
+		expect(t, "set", false, target.IsSet())
+		expect(t, "called", false, called)
+	})
+}
+
+func TestApply(t *testing.T) {
+	add := func(a, b int) int { return a + b }
+	t.Run("BothSet", func(t *testing.T) {
+		expect(t, "value", 5, optional.Apply(optional.NewValue(2), optional.NewValue(3), add).MustGet())
+	})
+	t.Run("OneUnset", func(t *testing.T) {
+		called := false
+		target := optional.Apply(optional.NewValue(2), optional.Value[int]{}, func(a, b int) int {
+			called = true
+			return a + b
+		})
+		expect(t, "set", false, target.IsSet())
+		expect(t, "called", false, called)
+	})
+	t.Run("MixedTypes", func(t *testing.T) {
+		target := optional.Apply(optional.NewValue("port"), optional.NewValue(80), func(name string, v int) string {
+			return name + "=" + strconv.Itoa(v)
+		})
+		expect(t, "value", "port=80", target.MustGet())
+	})
+}
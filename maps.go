@@ -0,0 +1,93 @@
+package optional
+
+import (
+	"sort"
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Keys returns the keys of the set entries in m, in no particular order
+func Keys[K comparable, V any](m map[K]Value[V]) []K {
+	keys := make([]K, 0, len(m))
+	for k, v := range m {
+		if v.set {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// Compact converts a sparse map of optional values into a dense map
+// holding only the set entries
+func Compact[K comparable, V any](m map[K]Value[V]) map[K]V {
+	dense := make(map[K]V, len(m))
+	for k, v := range m {
+		if v.set {
+			dense[k] = v.value
+		}
+	}
+	return dense
+}
+
+// Sparse converts a dense map into a sparse map of optional values with an
+// entry for every key in keys. keys missing from m have unset entries
+func Sparse[K comparable, V any](m map[K]V, keys []K) map[K]Value[V] {
+	sparse := make(map[K]Value[V], len(keys))
+	for _, k := range keys {
+		var v Value[V]
+		if value, ok := m[k]; ok {
+			v.Set(value)
+		}
+		sparse[k] = v
+	}
+	return sparse
+}
+
+// Entry is a key and the value set for it, as returned by Snapshot
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// Snapshot returns the set entries in m, sorted by key, so dumps and tests
+// over optional maps are reproducible
+func Snapshot[K constraints.Ordered, V any](m map[K]Value[V]) []Entry[K, V] {
+	return SnapshotFunc(m, func(a, b K) bool { return a < b })
+}
+
+// SnapshotFunc returns the set entries in m, sorted by key using less
+func SnapshotFunc[K comparable, V any](m map[K]Value[V], less func(a, b K) bool) []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, len(m))
+	for k, v := range m {
+		if v.set {
+			entries = append(entries, Entry[K, V]{Key: k, Value: v.value})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return less(entries[i].Key, entries[j].Key)
+	})
+	return entries
+}
+
+// SnapshotSyncMap returns the set entries of a sync.Map holding Value[V]
+// values under K keys, sorted by key. Entries of any other type are skipped.
+// Like sync.Map.Range, it does not reflect a single consistent point in time
+// if the map is modified concurrently.
+func SnapshotSyncMap[K constraints.Ordered, V any](m *sync.Map) []Entry[K, V] {
+	var entries []Entry[K, V]
+	m.Range(func(key, value any) bool {
+		k, ok := key.(K)
+		if !ok {
+			return true
+		}
+		if v, ok := value.(Value[V]); ok && v.set {
+			entries = append(entries, Entry[K, V]{Key: k, Value: v.value})
+		}
+		return true
+	})
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+	return entries
+}
@@ -0,0 +1,81 @@
+package optional_test
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestMaps(t *testing.T) {
+	sparse := map[string]optional.Value[int]{
+		"a": optional.NewValue(1),
+		"b": {},
+		"c": optional.NewValue(0),
+	}
+
+	t.Run("Keys", func(t *testing.T) {
+		keys := optional.Keys(sparse)
+		sort.Strings(keys)
+		expect(t, "len", 2, len(keys))
+		expect(t, "keys[0]", "a", keys[0])
+		expect(t, "keys[1]", "c", keys[1])
+	})
+	t.Run("Compact", func(t *testing.T) {
+		dense := optional.Compact(sparse)
+		expect(t, "len", 2, len(dense))
+		expect(t, "a", 1, dense["a"])
+		_, ok := dense["c"]
+		expect(t, "c present", true, ok)
+		_, ok = dense["b"]
+		expect(t, "b present", false, ok)
+	})
+	t.Run("Sparse", func(t *testing.T) {
+		observed := optional.Sparse(map[string]int{"a": 1, "z": 26}, []string{"a", "b"})
+		expect(t, "len", 2, len(observed))
+		expect(t, "a", 1, observed["a"].MustGet())
+		expect(t, "b set", false, observed["b"].IsSet())
+		_, ok := observed["z"]
+		expect(t, "z present", false, ok)
+	})
+}
+
+func TestSnapshot(t *testing.T) {
+	sparse := map[string]optional.Value[int]{
+		"c": optional.NewValue(3),
+		"b": {},
+		"a": optional.NewValue(1),
+		"d": optional.NewValue(0),
+	}
+	expected := []optional.Entry[string, int]{{"a", 1}, {"c", 3}, {"d", 0}}
+
+	t.Run("Snapshot", func(t *testing.T) {
+		observed := optional.Snapshot(sparse)
+		expect(t, "len", len(expected), len(observed))
+		for i := range expected {
+			expect(t, "key", expected[i].Key, observed[i].Key)
+			expect(t, "value", expected[i].Value, observed[i].Value)
+		}
+	})
+	t.Run("SnapshotFunc", func(t *testing.T) {
+		observed := optional.SnapshotFunc(sparse, func(a, b string) bool { return a > b })
+		expect(t, "len", 3, len(observed))
+		expect(t, "first", "d", observed[0].Key)
+	})
+	t.Run("SnapshotSyncMap", func(t *testing.T) {
+		var m sync.Map
+		for k, v := range sparse {
+			m.Store(k, v)
+		}
+		m.Store("e", "not an optional")
+		m.Store(5, optional.NewValue(5))
+
+		observed := optional.SnapshotSyncMap[string, int](&m)
+		expect(t, "len", len(expected), len(observed))
+		for i := range expected {
+			expect(t, "key", expected[i].Key, observed[i].Key)
+			expect(t, "value", expected[i].Value, observed[i].Value)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package optional
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// MemoizeOption configures the behavior of Memoize
+type MemoizeOption func(*memoizeConfig)
+
+type memoizeConfig struct {
+	ttl     time.Duration
+	maxSize int
+}
+
+// MemoizeTTL makes Memoize forget results after ttl has passed
+func MemoizeTTL(ttl time.Duration) MemoizeOption {
+	return func(c *memoizeConfig) {
+		c.ttl = ttl
+	}
+}
+
+// MemoizeMaxSize makes Memoize remember at most size results, forgetting the
+// least recently used result first
+func MemoizeMaxSize(size int) MemoizeOption {
+	return func(c *memoizeConfig) {
+		c.maxSize = size
+	}
+}
+
+// Memoize wraps fn so that its result for each key is remembered, whether or
+// not it is set. This makes it a good fit for expensive lookups which may
+// legitimately find nothing, as the absent results are cached too.
+//
+// By default results are remembered forever; see MemoizeTTL and
+// MemoizeMaxSize. The returned function is safe for concurrent use, but
+// concurrent calls for a key which is not yet remembered may each call fn.
+// Use SingleFlight when the lookups must be deduplicated.
+func Memoize[K comparable, V any](fn func(K) Value[V], opts ...MemoizeOption) func(K) Value[V] {
+	var cfg memoizeConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	m := &memoizer[K, V]{
+		cfg:     cfg,
+		entries: make(map[K]*list.Element),
+		lru:     list.New(),
+	}
+	return func(key K) Value[V] {
+		if v, ok := m.get(key); ok {
+			return v
+		}
+		v := fn(key)
+		m.put(key, v)
+		return v
+	}
+}
+
+type memoizer[K comparable, V any] struct {
+	cfg     memoizeConfig
+	mu      sync.Mutex
+	entries map[K]*list.Element
+	lru     *list.List
+}
+
+type memoEntry[K comparable, V any] struct {
+	key    K
+	value  Value[V]
+	expiry time.Time
+}
+
+func (m *memoizer[K, V]) get(key K) (Value[V], bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	elem, ok := m.entries[key]
+	if !ok {
+		return Value[V]{}, false
+	}
+	e := elem.Value.(*memoEntry[K, V])
+	if m.cfg.ttl > 0 && !time.Now().Before(e.expiry) {
+		m.lru.Remove(elem)
+		delete(m.entries, key)
+		return Value[V]{}, false
+	}
+	m.lru.MoveToFront(elem)
+	return e.value, true
+}
+
+func (m *memoizer[K, V]) put(key K, value Value[V]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := &memoEntry[K, V]{key: key, value: value}
+	if m.cfg.ttl > 0 {
+		e.expiry = time.Now().Add(m.cfg.ttl)
+	}
+	if elem, ok := m.entries[key]; ok {
+		elem.Value = e
+		m.lru.MoveToFront(elem)
+		return
+	}
+	m.entries[key] = m.lru.PushFront(e)
+	if m.cfg.maxSize > 0 && m.lru.Len() > m.cfg.maxSize {
+		oldest := m.lru.Back()
+		m.lru.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoEntry[K, V]).key)
+	}
+}
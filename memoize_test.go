@@ -0,0 +1,56 @@
+package optional_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/heucuva/optional"
+)
+
+func TestMemoize(t *testing.T) {
+	newLookup := func(calls map[string]int) func(string) optional.Value[int] {
+		return func(key string) optional.Value[int] {
+			calls[key]++
+			if key == "missing" {
+				return optional.Value[int]{}
+			}
+			return optional.NewValue(len(key))
+		}
+	}
+
+	t.Run("Caches", func(t *testing.T) {
+		calls := map[string]int{}
+		lookup := optional.Memoize(newLookup(calls))
+		for i := 0; i < 3; i++ {
+			expect(t, "present", 3, lookup("foo").MustGet())
+			expect(t, "absent", false, lookup("missing").IsSet())
+		}
+		expect(t, "present calls", 1, calls["foo"])
+		expect(t, "absent calls", 1, calls["missing"])
+	})
+
+	t.Run("TTL", func(t *testing.T) {
+		calls := map[string]int{}
+		lookup := optional.Memoize(newLookup(calls), optional.MemoizeTTL(20*time.Millisecond))
+		lookup("missing")
+		lookup("missing")
+		expect(t, "calls before expiry", 1, calls["missing"])
+		time.Sleep(40 * time.Millisecond)
+		lookup("missing")
+		expect(t, "calls after expiry", 2, calls["missing"])
+	})
+
+	t.Run("MaxSize", func(t *testing.T) {
+		calls := map[string]int{}
+		lookup := optional.Memoize(newLookup(calls), optional.MemoizeMaxSize(2))
+		lookup("a")
+		lookup("b")
+		lookup("a") // a is now the most recently used
+		lookup("c") // evicts b
+		lookup("a")
+		lookup("b")
+		expect(t, "a calls", 1, calls["a"])
+		expect(t, "b calls", 2, calls["b"])
+		expect(t, "c calls", 1, calls["c"])
+	})
+}
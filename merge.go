@@ -0,0 +1,245 @@
+package optional
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ConflictPolicy controls what Merge does when a field is set in both the
+// destination and the source
+type ConflictPolicy int
+
+const (
+	// SourceWins overwrites the destination with the source
+	SourceWins = ConflictPolicy(iota)
+	// DestinationWins keeps the destination as it is
+	DestinationWins
+	// ConflictError fails the merge if the two differ
+	ConflictError
+)
+
+// MergeConflictError is returned by Merge under the ConflictError policy
+type MergeConflictError struct {
+	Path string
+}
+
+func (e *MergeConflictError) Error() string {
+	return fmt.Sprintf("optional: merge conflict at %s", e.Path)
+}
+
+// MergeOption configures the behavior of Merge
+type MergeOption func(*mergeConfig)
+
+type mergeConfig struct {
+	policy    ConflictPolicy
+	sliceKeys map[reflect.Type]string
+	// keyIndexes holds the field indexes of sliceKeys, once validated
+	keyIndexes map[reflect.Type][]int
+}
+
+// MergeConflicts sets the policy applied when a field is set on both sides
+func MergeConflicts(policy ConflictPolicy) MergeOption {
+	return func(c *mergeConfig) {
+		c.policy = policy
+	}
+}
+
+// MergeSliceKey makes Merge match the elements of slices of elem's struct
+// type by the named field, merging matching elements and appending the rest.
+// without a key, a non-empty source slice replaces the destination slice.
+// Merge fails if elem is not a struct, or has no comparable field by that name
+func MergeSliceKey(elem any, field string) MergeOption {
+	return func(c *mergeConfig) {
+		if c.sliceKeys == nil {
+			c.sliceKeys = make(map[reflect.Type]string)
+		}
+		c.sliceKeys[reflect.TypeOf(elem)] = field
+	}
+}
+
+// Merge layers the struct src over the struct dst points to. Both must be
+// the same type. Set optionals and non-zero plain fields in src are applied
+// to dst, while unset optionals and zero fields leave dst untouched. Nested
+// structs and maps are merged recursively, apart from structs without
+// exported fields (such as time.Time), which are plain values. Slices are
+// merged as configured by MergeSliceKey.
+func Merge(dst, src any, opts ...MergeOption) error {
+	var cfg mergeConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() {
+		return errors.New("optional: merge destination must be a non-nil pointer")
+	}
+	dv = dv.Elem()
+
+	if err := cfg.validateSliceKeys(); err != nil {
+		return err
+	}
+
+	sv := reflect.ValueOf(src)
+	if !sv.IsValid() {
+		return errors.New("optional: merge source must not be nil")
+	}
+	for sv.Kind() == reflect.Pointer {
+		if sv.IsNil() {
+			return nil
+		}
+		sv = sv.Elem()
+	}
+	if sv.Type() != dv.Type() {
+		return fmt.Errorf("optional: cannot merge %v into %v", sv.Type(), dv.Type())
+	}
+	return cfg.merge(dv.Type().Name(), dv, sv)
+}
+
+func (c *mergeConfig) merge(path string, dv, sv reflect.Value) error {
+	t := dv.Type()
+	if IsOptionalType(t) {
+		if !sv.Interface().(anyOptional).IsSet() {
+			return nil
+		}
+		if dv.Interface().(anyOptional).IsSet() {
+			return c.resolve(path, dv, sv)
+		}
+		dv.Set(sv)
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if !isNestedStruct(t) {
+			// structs such as time.Time are merged as a whole
+			break
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			if err := c.merge(path+"."+t.Field(i).Name, dv.Field(i), sv.Field(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Pointer:
+		if sv.IsNil() {
+			return nil
+		}
+		if dv.IsNil() {
+			dv.Set(reflect.New(t.Elem()))
+		}
+		return c.merge(path, dv.Elem(), sv.Elem())
+
+	case reflect.Map:
+		if sv.Len() == 0 {
+			return nil
+		}
+		if dv.IsNil() {
+			dv.Set(reflect.MakeMapWithSize(t, sv.Len()))
+		}
+		iter := sv.MapRange()
+		for iter.Next() {
+			key := iter.Key()
+			existing := dv.MapIndex(key)
+			if !existing.IsValid() {
+				dv.SetMapIndex(key, iter.Value())
+				continue
+			}
+			// map elements are not addressable, so merge into a copy
+			elem := reflect.New(t.Elem()).Elem()
+			elem.Set(existing)
+			if err := c.merge(fmt.Sprintf("%s[%v]", path, key), elem, iter.Value()); err != nil {
+				return err
+			}
+			dv.SetMapIndex(key, elem)
+		}
+		return nil
+
+	case reflect.Slice:
+		if sv.Len() == 0 {
+			return nil
+		}
+		if key, ok := c.keyIndexes[t.Elem()]; ok {
+			return c.mergeKeyedSlice(path, key, dv, sv)
+		}
+	}
+
+	if sv.IsZero() {
+		return nil
+	}
+	if !dv.IsZero() {
+		return c.resolve(path, dv, sv)
+	}
+	dv.Set(sv)
+	return nil
+}
+
+// validateSliceKeys checks that the fields named by MergeSliceKey exist
+// and are comparable, and records their indexes
+func (c *mergeConfig) validateSliceKeys() error {
+	c.keyIndexes = make(map[reflect.Type][]int, len(c.sliceKeys))
+	for t, key := range c.sliceKeys {
+		if t == nil || t.Kind() != reflect.Struct {
+			return fmt.Errorf("optional: merge slice key %q: %v is not a struct", key, t)
+		}
+		field, ok := t.FieldByName(key)
+		if !ok || !field.IsExported() {
+			return fmt.Errorf("optional: merge slice key %q: no exported field in %v", key, t)
+		}
+		if !field.Type.Comparable() {
+			return fmt.Errorf("optional: merge slice key %q: %v is not comparable", key, field.Type)
+		}
+		c.keyIndexes[t] = field.Index
+	}
+	return nil
+}
+
+func (c *mergeConfig) mergeKeyedSlice(path string, key []int, dv, sv reflect.Value) error {
+	index := make(map[any]int, dv.Len())
+	for i := 0; i < dv.Len(); i++ {
+		k, err := dv.Index(i).FieldByIndexErr(key)
+		if err != nil {
+			return fmt.Errorf("optional: merging %s[%d]: %w", path, i, err)
+		}
+		index[k.Interface()] = i
+	}
+
+	for i := 0; i < sv.Len(); i++ {
+		elem := sv.Index(i)
+		kv, err := elem.FieldByIndexErr(key)
+		if err != nil {
+			return fmt.Errorf("optional: merging %s[%d]: %w", path, i, err)
+		}
+		k := kv.Interface()
+		j, ok := index[k]
+		if !ok {
+			index[k] = dv.Len()
+			dv.Set(reflect.Append(dv, elem))
+			continue
+		}
+		if err := c.merge(fmt.Sprintf("%s[%v]", path, k), dv.Index(j), elem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolve applies the conflict policy to a field set on both sides
+func (c *mergeConfig) resolve(path string, dv, sv reflect.Value) error {
+	switch c.policy {
+	case DestinationWins:
+		return nil
+	case ConflictError:
+		if reflect.DeepEqual(dv.Interface(), sv.Interface()) {
+			return nil
+		}
+		return &MergeConflictError{Path: path}
+	default:
+		dv.Set(sv)
+		return nil
+	}
+}
@@ -0,0 +1,144 @@
+package optional_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/heucuva/optional"
+)
+
+type testMergeServer struct {
+	Name string
+	Port optional.Value[int]
+}
+
+type testMergeConfig struct {
+	Debug   optional.Value[bool]
+	Timeout optional.Value[int]
+	Log     struct {
+		Level optional.Value[string]
+		File  optional.Value[string]
+	}
+	Servers []testMergeServer
+	Limits  map[string]optional.Value[int]
+	Owner   string
+}
+
+func TestMerge(t *testing.T) {
+	newBase := func() testMergeConfig {
+		var base testMergeConfig
+		base.Debug.Set(false)
+		base.Timeout.Set(30)
+		base.Log.Level.Set("info")
+		base.Servers = []testMergeServer{
+			{Name: "a", Port: optional.NewValue(80)},
+			{Name: "b", Port: optional.NewValue(81)},
+		}
+		base.Limits = map[string]optional.Value[int]{"cpu": optional.NewValue(1)}
+		base.Owner = "ops"
+		return base
+	}
+
+	var overlay testMergeConfig
+	overlay.Debug.Set(true)
+	overlay.Log.File.Set("/var/log/app")
+	overlay.Servers = []testMergeServer{
+		{Name: "b", Port: optional.NewValue(8081)},
+		{Name: "c"},
+	}
+	overlay.Limits = map[string]optional.Value[int]{
+		"cpu": optional.NewValue(2),
+		"mem": optional.NewValue(512),
+	}
+
+	t.Run("SourceWins", func(t *testing.T) {
+		target := newBase()
+		if err := optional.Merge(&target, overlay, optional.MergeSliceKey(testMergeServer{}, "Name")); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "Debug", true, target.Debug.MustGet())
+		expect(t, "Timeout", 30, target.Timeout.MustGet())
+		expect(t, "Log.Level", "info", target.Log.Level.MustGet())
+		expect(t, "Log.File", "/var/log/app", target.Log.File.MustGet())
+		expect(t, "len(Servers)", 3, len(target.Servers))
+		expect(t, "Servers[a].Port", 80, target.Servers[0].Port.MustGet())
+		expect(t, "Servers[b].Port", 8081, target.Servers[1].Port.MustGet())
+		expect(t, "Servers[c].Name", "c", target.Servers[2].Name)
+		expect(t, "Limits[cpu]", 2, target.Limits["cpu"].MustGet())
+		expect(t, "Limits[mem]", 512, target.Limits["mem"].MustGet())
+		expect(t, "Owner", "ops", target.Owner)
+	})
+	t.Run("UnkeyedSlice", func(t *testing.T) {
+		target := newBase()
+		if err := optional.Merge(&target, overlay); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "len(Servers)", 2, len(target.Servers))
+		expect(t, "Servers[0].Name", "b", target.Servers[0].Name)
+	})
+	t.Run("DestinationWins", func(t *testing.T) {
+		target := newBase()
+		if err := optional.Merge(&target, overlay, optional.MergeConflicts(optional.DestinationWins)); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "Debug", false, target.Debug.MustGet())
+		expect(t, "Log.File", "/var/log/app", target.Log.File.MustGet())
+		expect(t, "Limits[cpu]", 1, target.Limits["cpu"].MustGet())
+	})
+	t.Run("ConflictError", func(t *testing.T) {
+		target := newBase()
+		err := optional.Merge(&target, overlay, optional.MergeConflicts(optional.ConflictError))
+		var conflict *optional.MergeConflictError
+		if !errors.As(err, &conflict) {
+			t.Fatalf("expected MergeConflictError, got %v", err)
+		}
+		expect(t, "path", "testMergeConfig.Debug", conflict.Path)
+	})
+	t.Run("TypeMismatch", func(t *testing.T) {
+		target := newBase()
+		if err := optional.Merge(&target, testMergeServer{}); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+	t.Run("Time", func(t *testing.T) {
+		type testSchedule struct {
+			Start time.Time
+			End   *time.Time
+		}
+		start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		var target testSchedule
+		if err := optional.Merge(&target, testSchedule{Start: start, End: &start}); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "start", true, target.Start.Equal(start))
+		expect(t, "end", true, target.End != nil && target.End.Equal(start))
+
+		later := start.Add(time.Hour)
+		err := optional.Merge(&target, testSchedule{Start: later}, optional.MergeConflicts(optional.ConflictError))
+		var conflict *optional.MergeConflictError
+		expect(t, "conflict", true, errors.As(err, &conflict))
+	})
+	t.Run("NilSource", func(t *testing.T) {
+		target := newBase()
+		if err := optional.Merge(&target, nil); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+	t.Run("InvalidSliceKey", func(t *testing.T) {
+		type testTagged struct {
+			Tags []string
+		}
+		tests := map[string]optional.MergeOption{
+			"Misspelled":    optional.MergeSliceKey(testMergeServer{}, "Nmae"),
+			"Pointer":       optional.MergeSliceKey(&testMergeServer{}, "Name"),
+			"NotComparable": optional.MergeSliceKey(testTagged{}, "Tags"),
+		}
+		for name, opt := range tests {
+			target := newBase()
+			if err := optional.Merge(&target, overlay, opt); err == nil {
+				t.Errorf("%s: expected failure, but got success", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package optional
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// FormFile returns the header of the first file uploaded under the form key
+// name, parsing the request's multipart form if necessary.
+// the value is unset if no file was provided, but set for an empty file
+func FormFile(r *http.Request, name string) (Value[*multipart.FileHeader], error) {
+	f, header, err := r.FormFile(name)
+	if errors.Is(err, http.ErrMissingFile) {
+		return Value[*multipart.FileHeader]{}, nil
+	}
+	if err != nil {
+		return Value[*multipart.FileHeader]{}, err
+	}
+	if err := f.Close(); err != nil {
+		return Value[*multipart.FileHeader]{}, err
+	}
+	return NewValue(header), nil
+}
+
+// MultipartFile returns the header of the first file in an already parsed
+// multipart form under the key name.
+// the value is unset if no file was provided, but set for an empty file
+func MultipartFile(form *multipart.Form, name string) Value[*multipart.FileHeader] {
+	if form == nil || len(form.File[name]) == 0 {
+		return Value[*multipart.FileHeader]{}
+	}
+	return NewValue(form.File[name][0])
+}
+
+// FormFileBytes returns the contents of the first file uploaded under the
+// form key name, parsing the request's multipart form if necessary.
+// the value is unset if no file was provided, but set for an empty file
+func FormFileBytes(r *http.Request, name string) (Value[[]byte], error) {
+	header, err := FormFile(r, name)
+	if err != nil {
+		return Value[[]byte]{}, err
+	}
+	return FileBytes(header)
+}
+
+// FileBytes reads the contents of an optional uploaded file.
+// an unset file results in an unset value
+func FileBytes(header Value[*multipart.FileHeader]) (Value[[]byte], error) {
+	h, set := header.Get()
+	if !set || h == nil {
+		return Value[[]byte]{}, nil
+	}
+
+	f, err := h.Open()
+	if err != nil {
+		return Value[[]byte]{}, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return Value[[]byte]{}, err
+	}
+	return NewValue(data), nil
+}
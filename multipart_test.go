@@ -0,0 +1,83 @@
+package optional_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func newMultipartRequest(t *testing.T, files map[string]string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for name, content := range files {
+		part, err := w.CreateFormFile(name, name+".txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := part.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.WriteField("comment", "Foo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	return r
+}
+
+func TestFormFile(t *testing.T) {
+	r := newMultipartRequest(t, map[string]string{
+		"avatar": "image data",
+		"empty":  "",
+	})
+
+	t.Run("Provided", func(t *testing.T) {
+		header, err := optional.FormFile(r, "avatar")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "filename", "avatar.txt", header.MustGet().Filename)
+
+		content, err := optional.FormFileBytes(r, "avatar")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "content", "image data", string(content.MustGet()))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		content, err := optional.FormFileBytes(r, "empty")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "set", true, content.IsSet())
+		expect(t, "len", 0, len(content.MustGet()))
+	})
+	t.Run("Missing", func(t *testing.T) {
+		header, err := optional.FormFile(r, "missing")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "set", false, header.IsSet())
+
+		content, err := optional.FormFileBytes(r, "missing")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "set", false, content.IsSet())
+	})
+	t.Run("MultipartFile", func(t *testing.T) {
+		expect(t, "set", true, optional.MultipartFile(r.MultipartForm, "avatar").IsSet())
+		expect(t, "set", false, optional.MultipartFile(r.MultipartForm, "missing").IsSet())
+		expect(t, "set", false, optional.MultipartFile(nil, "avatar").IsSet())
+	})
+}
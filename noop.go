@@ -0,0 +1,33 @@
+package optional
+
+import "reflect"
+
+// OrNoop returns the value, if it is set to something other than nil.
+// otherwise, it returns noop. It is meant for interface-typed optionals
+// (an optional logger or metrics sink, say), so call sites can always call
+// methods on the result without checking for presence first.
+func OrNoop[T any](v Value[T], noop T) T {
+	if !v.set || isNil(v.value) {
+		return noop
+	}
+	return v.value
+}
+
+// IfPresent calls fn with the value, if it is set to something other than nil
+func IfPresent[T any](v Value[T], fn func(T)) {
+	if v.set && !isNil(v.value) {
+		fn(v.value)
+	}
+}
+
+// isNil returns true if value is nil, including typed nils held by an interface
+func isNil(value any) bool {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return rv.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package optional_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestOrNoop(t *testing.T) {
+	var buf bytes.Buffer
+
+	t.Run("Set", func(t *testing.T) {
+		sink := optional.NewValue[io.Writer](&buf)
+		optional.OrNoop(sink, io.Discard).Write([]byte("Foo"))
+		expect(t, "written", "Foo", buf.String())
+	})
+	t.Run("Unset", func(t *testing.T) {
+		var sink optional.Value[io.Writer]
+		if optional.OrNoop(sink, io.Discard) != io.Discard {
+			t.Fatal("expected noop")
+		}
+	})
+	t.Run("SetNil", func(t *testing.T) {
+		sink := optional.NewValue[io.Writer](nil)
+		if optional.OrNoop(sink, io.Discard) != io.Discard {
+			t.Fatal("expected noop")
+		}
+		var nilBuffer *bytes.Buffer
+		sink = optional.NewValue[io.Writer](nilBuffer)
+		if optional.OrNoop(sink, io.Discard) != io.Discard {
+			t.Fatal("expected noop for typed nil")
+		}
+	})
+}
+
+func TestIfPresent(t *testing.T) {
+	calls := 0
+	count := func(io.Writer) { calls++ }
+
+	optional.IfPresent(optional.NewValue[io.Writer](io.Discard), count)
+	optional.IfPresent(optional.Value[io.Writer]{}, count)
+	optional.IfPresent(optional.NewValue[io.Writer](nil), count)
+	expect(t, "calls", 1, calls)
+}
@@ -0,0 +1,408 @@
+package optional
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MarshalJSONOmitUnset marshals v to json as encoding/json would, except that
+// struct fields holding unset optionals are left out entirely instead of
+// being encoded as null, with or without `omitempty` and `omitzero`. This is
+// useful before Go 1.24, where encoding/json has no `omitzero`, and for
+// structs whose tags cannot be changed.
+//
+// Set optionals are encoded as their values, and their unset fields are left
+// out in turn. Values whose types implement json.Marshaler or
+// encoding.TextMarshaler are marshaled by those methods, so unset optionals
+// inside them are left as they encode them.
+func MarshalJSONOmitUnset(v any) ([]byte, error) {
+	e := omitUnsetEncoder{seen: make(map[uintptr]struct{})}
+	if err := e.encode(reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return e.buf.Bytes(), nil
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+type omitUnsetEncoder struct {
+	buf bytes.Buffer
+	// seen holds the pointers being encoded, to detect cycles
+	seen map[uintptr]struct{}
+}
+
+func (e *omitUnsetEncoder) encode(rv reflect.Value) error {
+	if !rv.IsValid() {
+		e.buf.WriteString("null")
+		return nil
+	}
+	if IsOptionalType(rv.Type()) {
+		value, set := UnwrapAny(rv)
+		if !set {
+			e.buf.WriteString("null")
+			return nil
+		}
+		rv = value
+	}
+
+	t := rv.Type()
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+		return e.marshal(rv.Interface())
+	}
+	if rv.CanAddr() {
+		if pt := reflect.PointerTo(t); pt.Implements(jsonMarshalerType) || pt.Implements(textMarshalerType) {
+			return e.marshal(rv.Addr().Interface())
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		ptr := rv.Pointer()
+		if _, ok := e.seen[ptr]; ok {
+			return &json.UnsupportedValueError{Value: rv, Str: fmt.Sprintf("encountered a cycle via %v", t)}
+		}
+		e.seen[ptr] = struct{}{}
+		defer delete(e.seen, ptr)
+		return e.encode(rv.Elem())
+	case reflect.Interface:
+		if rv.IsNil() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		return e.encode(rv.Elem())
+	case reflect.Struct:
+		return e.encodeStruct(rv)
+	case reflect.Map:
+		return e.encodeMap(rv)
+	case reflect.Slice:
+		if rv.IsNil() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			// byte slices are base64 encoded
+			return e.marshal(rv.Interface())
+		}
+		return e.encodeArray(rv)
+	case reflect.Array:
+		return e.encodeArray(rv)
+	}
+	return e.marshal(rv.Interface())
+}
+
+func (e *omitUnsetEncoder) marshal(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	e.buf.Write(data)
+	return nil
+}
+
+func (e *omitUnsetEncoder) encodeStruct(rv reflect.Value) error {
+	e.buf.WriteByte('{')
+	first := true
+	for _, field := range jsonFields(rv.Type()) {
+		fv, ok := jsonFieldValue(rv, field.index)
+		if !ok {
+			continue
+		}
+		if IsOptionalType(fv.Type()) {
+			if !fv.Interface().(anyOptional).IsSet() {
+				continue
+			}
+		} else if field.omitEmpty && isEmptyJSONValue(fv) {
+			continue
+		}
+
+		if !first {
+			e.buf.WriteByte(',')
+		}
+		first = false
+		if err := e.marshal(field.name); err != nil {
+			return err
+		}
+		e.buf.WriteByte(':')
+
+		if !field.quoted {
+			if err := e.encode(fv); err != nil {
+				return err
+			}
+			continue
+		}
+		// the `string` option encodes a scalar as a json string
+		start := e.buf.Len()
+		if err := e.encode(fv); err != nil {
+			return err
+		}
+		value := string(e.buf.Bytes()[start:])
+		if value == "null" {
+			continue
+		}
+		e.buf.Truncate(start)
+		if err := e.marshal(value); err != nil {
+			return err
+		}
+	}
+	e.buf.WriteByte('}')
+	return nil
+}
+
+func (e *omitUnsetEncoder) encodeMap(rv reflect.Value) error {
+	if rv.IsNil() {
+		e.buf.WriteString("null")
+		return nil
+	}
+
+	type entry struct {
+		key   string
+		value reflect.Value
+	}
+	entries := make([]entry, 0, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		key, err := jsonMapKey(iter.Key())
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{key: key, value: iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	e.buf.WriteByte('{')
+	for i, entry := range entries {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		if err := e.marshal(entry.key); err != nil {
+			return err
+		}
+		e.buf.WriteByte(':')
+		if err := e.encode(entry.value); err != nil {
+			return err
+		}
+	}
+	e.buf.WriteByte('}')
+	return nil
+}
+
+func (e *omitUnsetEncoder) encodeArray(rv reflect.Value) error {
+	e.buf.WriteByte('[')
+	for i := 0; i < rv.Len(); i++ {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		if err := e.encode(rv.Index(i)); err != nil {
+			return err
+		}
+	}
+	e.buf.WriteByte(']')
+	return nil
+}
+
+// jsonMapKey converts a map key to a json object key, as encoding/json does
+func jsonMapKey(key reflect.Value) (string, error) {
+	if key.Kind() == reflect.String {
+		return key.String(), nil
+	}
+	if tm, ok := key.Interface().(encoding.TextMarshaler); ok {
+		if key.Kind() == reflect.Pointer && key.IsNil() {
+			return "", nil
+		}
+		text, err := tm.MarshalText()
+		return string(text), err
+	}
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), nil
+	}
+	return "", &json.UnsupportedTypeError{Type: key.Type()}
+}
+
+// isEmptyJSONValue reports whether rv is empty, as `omitempty` defines it
+func isEmptyJSONValue(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Bool:
+		return !rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return rv.IsNil()
+	}
+	return false
+}
+
+type jsonField struct {
+	name      string
+	index     []int
+	tagged    bool
+	omitEmpty bool
+	quoted    bool
+}
+
+// jsonFields lists the fields of the struct type t that encoding/json
+// encodes, in the order it encodes them. fields of embedded structs without
+// a name in their tag are promoted, with the shallowest field of a name
+// winning, and a tagged field winning over untagged ones at the same depth.
+// names which remain ambiguous are dropped
+func jsonFields(t reflect.Type) []jsonField {
+	type level struct {
+		t     reflect.Type
+		index []int
+	}
+
+	var fields []jsonField
+	names := make(map[string]bool)
+	visited := make(map[reflect.Type]bool)
+	for current := []level{{t: t}}; len(current) > 0; {
+		var next []level
+		found := make(map[string][]jsonField)
+		var order []string
+		for _, lv := range current {
+			if visited[lv.t] {
+				continue
+			}
+			visited[lv.t] = true
+
+			for i := 0; i < lv.t.NumField(); i++ {
+				field := lv.t.Field(i)
+				ft := field.Type
+				if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if field.Anonymous {
+					// unexported embedded pointers cannot be followed
+					if !field.IsExported() && (ft.Kind() != reflect.Struct || field.Type.Kind() == reflect.Pointer) {
+						continue
+					}
+				} else if !field.IsExported() {
+					continue
+				}
+
+				tag := field.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				index := append(append([]int(nil), lv.index...), i)
+				if name == "" && field.Anonymous && ft.Kind() == reflect.Struct && !IsOptionalType(ft) {
+					next = append(next, level{t: ft, index: index})
+					continue
+				}
+				if !field.IsExported() {
+					continue
+				}
+
+				f := jsonField{
+					name:      name,
+					index:     index,
+					tagged:    name != "",
+					omitEmpty: hasJSONOption(opts, "omitempty"),
+				}
+				if f.name == "" {
+					f.name = field.Name
+				}
+				if hasJSONOption(opts, "string") {
+					quotedType := ft
+					if IsOptionalType(ft) && passesJSONOptions(ft) {
+						// the option reaches the value of the optional
+						quotedType = ElemType(ft)
+					}
+					switch quotedType.Kind() {
+					case reflect.Bool, reflect.String,
+						reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+						reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+						reflect.Float32, reflect.Float64:
+						f.quoted = true
+					}
+				}
+				if names[f.name] {
+					// dominated by a shallower field
+					continue
+				}
+				if _, ok := found[f.name]; !ok {
+					order = append(order, f.name)
+				}
+				found[f.name] = append(found[f.name], f)
+			}
+		}
+
+		for _, name := range order {
+			names[name] = true
+			candidates := found[name]
+			var tagged []jsonField
+			for _, f := range candidates {
+				if f.tagged {
+					tagged = append(tagged, f)
+				}
+			}
+			switch {
+			case len(candidates) == 1:
+				fields = append(fields, candidates[0])
+			case len(tagged) == 1:
+				fields = append(fields, tagged[0])
+			}
+		}
+		current = next
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := fields[i].index, fields[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return fields
+}
+
+func hasJSONOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonFieldValue returns the field of rv at index. it returns false if the
+// field is reached through a nil pointer to an embedded struct
+func jsonFieldValue(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+			rv = rv.Elem()
+		}
+		rv = rv.Field(x)
+	}
+	return rv, true
+}
@@ -0,0 +1,176 @@
+package optional_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/heucuva/optional"
+)
+
+type testOmitAddress struct {
+	Street optional.Value[string] `json:"street"`
+	City   optional.Value[string] `json:"city"`
+}
+
+type testOmitBase struct {
+	ID      int                       `json:"id"`
+	Created optional.Value[time.Time] `json:"created"`
+}
+
+type testOmitUser struct {
+	testOmitBase
+	Name     optional.Value[string]          `json:"name"`
+	Nickname optional.Boxed[string]          `json:"nickname,omitempty"`
+	Age      optional.Value[int]             `json:"age"`
+	Address  optional.Value[testOmitAddress] `json:"address"`
+	Tags     []optional.Value[string]        `json:"tags"`
+	Labels   map[string]optional.Value[int]  `json:"labels,omitempty"`
+	Note     *string                         `json:"note"`
+	Count    int                             `json:"count,string"`
+	Secret   string                          `json:"-"`
+	Raw      optional.Value[json.RawMessage] `json:"raw"`
+	Password optional.Sensitive[string]      `json:"password"`
+	Empty    string                          `json:",omitempty"`
+}
+
+func TestMarshalJSONOmitUnset(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{
+			name:     "AllUnset",
+			value:    testOmitUser{},
+			expected: `{"id":0,"tags":null,"note":null,"count":"0"}`,
+		},
+		{
+			name: "Set",
+			value: testOmitUser{
+				testOmitBase: testOmitBase{ID: 5},
+				Name:         optional.NewValue("Foo"),
+				Nickname:     optional.NewBoxed(""),
+				Age:          optional.NewValue(0),
+				Address:      optional.NewValue(testOmitAddress{City: optional.NewValue("Paris")}),
+				Tags:         []optional.Value[string]{optional.NewValue("a"), {}},
+				Labels:       map[string]optional.Value[int]{"b": optional.NewValue(2), "a": {}},
+				Count:        3,
+				Raw:          optional.NewValue(json.RawMessage(`{"x":1}`)),
+				Password:     optional.NewSensitive("hunter2"),
+			},
+			expected: `{"id":5,"name":"Foo","nickname":"","age":0,"address":{"city":"Paris"},"tags":["a",null],"labels":{"a":null,"b":2},"note":null,"count":"3","raw":{"x":1},"password":"hunter2"}`,
+		},
+		{
+			name:     "Pointer",
+			value:    &testOmitAddress{Street: optional.NewValue("Main")},
+			expected: `{"street":"Main"}`,
+		},
+		{
+			name:     "Slice",
+			value:    []testOmitAddress{{}, {City: optional.NewValue("Rome")}},
+			expected: `[{},{"city":"Rome"}]`,
+		},
+		{
+			name:     "TopLevelUnset",
+			value:    optional.Value[int]{},
+			expected: `null`,
+		},
+		{
+			name:     "Marshaler",
+			value:    map[int]time.Time{1: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+			expected: `{"1":"2024-03-01T00:00:00Z"}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			observed, err := optional.MarshalJSONOmitUnset(tc.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expect(t, "json", tc.expected, string(observed))
+		})
+	}
+
+	t.Run("MatchesEncodingJSON", func(t *testing.T) {
+		// without optionals, the output is the same as encoding/json's
+		type inner struct {
+			A int `json:"a,omitempty"`
+			B string
+		}
+		type outer struct {
+			inner
+			B     string            `json:"B"`
+			C     []byte            `json:"c"`
+			D     map[string]inner  `json:"d"`
+			E     *inner            `json:"e"`
+			F     any               `json:"f"`
+			G     [2]bool           `json:"g"`
+			H     float64           `json:"h,string"`
+			I     string            `json:"i,omitempty"`
+			Empty map[string]string `json:"empty,omitempty"`
+		}
+		value := outer{
+			inner: inner{A: 1, B: "hidden"},
+			B:     "<b>",
+			C:     []byte("Foo"),
+			D:     map[string]inner{"x": {B: "y"}},
+			F:     []any{1, "two", nil},
+			H:     1.5,
+		}
+		expected, err := json.Marshal(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		observed, err := optional.MarshalJSONOmitUnset(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", string(expected), string(observed))
+	})
+	t.Run("StringOption", func(t *testing.T) {
+		// the `string` option applies to optionals as encoding/json applies it
+		type quoted struct {
+			A optional.Value[int]     `json:"a,string"`
+			B optional.Boxed[bool]    `json:"b,string"`
+			C optional.Value[string]  `json:"c,string"`
+			D optional.Sensitive[int] `json:"d,string"`
+			E optional.Value[[]int]   `json:"e,string"`
+		}
+		value := quoted{
+			A: optional.NewValue(5),
+			B: optional.NewBoxed(true),
+			C: optional.NewValue("Foo"),
+			D: optional.NewSensitive(3),
+			E: optional.NewValue([]int{1}),
+		}
+		expected, err := json.Marshal(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		observed, err := optional.MarshalJSONOmitUnset(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", string(expected), string(observed))
+	})
+	t.Run("Cycle", func(t *testing.T) {
+		type node struct {
+			Next *node                  `json:"next"`
+			Name optional.Value[string] `json:"name"`
+		}
+		n := &node{}
+		n.Next = n
+		if _, err := optional.MarshalJSONOmitUnset(n); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		if _, err := optional.MarshalJSONOmitUnset(map[bool]int{true: 1}); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+		if _, err := optional.MarshalJSONOmitUnset(struct{ C chan int }{}); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+}
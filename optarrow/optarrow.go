@@ -0,0 +1,308 @@
+// Package optarrow converts structs of optional values to and from Apache
+// Arrow record batches with github.com/apache/arrow-go, so they can be
+// streamed over Arrow IPC or Flight.
+//
+// Each exported struct field becomes a column named by its `arrow` tag (or
+// its name, if untagged). Optional fields become nullable columns, whose
+// validity bitmaps mark unset values as null, and null values read back as
+// unset. Plain fields become non-nullable columns.
+//
+// Fields may hold bools, integers, floats, strings, []byte, or time.Time,
+// which is stored as a UTC nanosecond timestamp.
+package optarrow
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/heucuva/optional"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// RecordWriter writes record batches to a stream. It is implemented by the
+// writers of arrow's ipc and flight packages.
+type RecordWriter interface {
+	Write(rec arrow.RecordBatch) error
+}
+
+type column struct {
+	name     string
+	field    []int
+	optional bool
+	// elem is the type of the value held by the field
+	elem reflect.Type
+}
+
+// columns lists the columns of the struct type t
+func columns(t reflect.Type) ([]column, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("optarrow: %v is not a struct type", t)
+	}
+
+	var cols []column
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("arrow"); ok {
+			if tag == "-" {
+				continue
+			}
+			name = tag
+		}
+		col := column{name: name, field: field.Index, elem: field.Type}
+		if elem := optional.ElemType(field.Type); elem != nil {
+			col.optional, col.elem = true, elem
+		}
+		if _, err := dataType(col.elem); err != nil {
+			return nil, fmt.Errorf("optarrow: field %s: %w", field.Name, err)
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
+
+// dataType returns the arrow data type holding values of type t
+func dataType(t reflect.Type) (arrow.DataType, error) {
+	if t == timeType {
+		return &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}, nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return arrow.FixedWidthTypes.Boolean, nil
+	case reflect.Int8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case reflect.Int16:
+		return arrow.PrimitiveTypes.Int16, nil
+	case reflect.Int32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case reflect.Int, reflect.Int64:
+		return arrow.PrimitiveTypes.Int64, nil
+	case reflect.Uint8:
+		return arrow.PrimitiveTypes.Uint8, nil
+	case reflect.Uint16:
+		return arrow.PrimitiveTypes.Uint16, nil
+	case reflect.Uint32:
+		return arrow.PrimitiveTypes.Uint32, nil
+	case reflect.Uint, reflect.Uint64:
+		return arrow.PrimitiveTypes.Uint64, nil
+	case reflect.Float32:
+		return arrow.PrimitiveTypes.Float32, nil
+	case reflect.Float64:
+		return arrow.PrimitiveTypes.Float64, nil
+	case reflect.String:
+		return arrow.BinaryTypes.String, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return arrow.BinaryTypes.Binary, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported type %v", t)
+}
+
+// Schema returns the arrow schema of record batches holding structs of type S
+func Schema[S any]() (*arrow.Schema, error) {
+	cols, err := columns(reflect.TypeOf((*S)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	return schema(cols), nil
+}
+
+func schema(cols []column) *arrow.Schema {
+	fields := make([]arrow.Field, len(cols))
+	for i, col := range cols {
+		dt, _ := dataType(col.elem)
+		fields[i] = arrow.Field{Name: col.name, Type: dt, Nullable: col.optional}
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+// NewRecordBatch builds a record batch out of rows, allocated by mem.
+// the caller must release it
+func NewRecordBatch[S any](mem memory.Allocator, rows []S) (arrow.RecordBatch, error) {
+	cols, err := columns(reflect.TypeOf((*S)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	b := array.NewRecordBuilder(mem, schema(cols))
+	defer b.Release()
+	for i := range rows {
+		rv := reflect.ValueOf(&rows[i]).Elem()
+		for j, col := range cols {
+			value, set := optional.UnwrapAny(rv.FieldByIndex(col.field))
+			if !set {
+				b.Field(j).AppendNull()
+				continue
+			}
+			appendValue(b.Field(j), value)
+		}
+	}
+	return b.NewRecordBatch(), nil
+}
+
+// appendValue appends v to b, which was built for v's type by dataType
+func appendValue(b array.Builder, v reflect.Value) {
+	switch b := b.(type) {
+	case *array.BooleanBuilder:
+		b.Append(v.Bool())
+	case *array.Int8Builder:
+		b.Append(int8(v.Int()))
+	case *array.Int16Builder:
+		b.Append(int16(v.Int()))
+	case *array.Int32Builder:
+		b.Append(int32(v.Int()))
+	case *array.Int64Builder:
+		b.Append(v.Int())
+	case *array.Uint8Builder:
+		b.Append(uint8(v.Uint()))
+	case *array.Uint16Builder:
+		b.Append(uint16(v.Uint()))
+	case *array.Uint32Builder:
+		b.Append(uint32(v.Uint()))
+	case *array.Uint64Builder:
+		b.Append(v.Uint())
+	case *array.Float32Builder:
+		b.Append(float32(v.Float()))
+	case *array.Float64Builder:
+		b.Append(v.Float())
+	case *array.StringBuilder:
+		b.Append(v.String())
+	case *array.BinaryBuilder:
+		b.Append(v.Bytes())
+	case *array.TimestampBuilder:
+		b.Append(arrow.Timestamp(v.Interface().(time.Time).UnixNano()))
+	}
+}
+
+// Rows reads the rows of rec into structs of type S, matching columns to
+// fields by name. every field of S must have a column of the type it is
+// stored as, and null values are only allowed in optional fields
+func Rows[S any](rec arrow.RecordBatch) ([]S, error) {
+	cols, err := columns(reflect.TypeOf((*S)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	arrays := make([]arrow.Array, len(cols))
+	for j, col := range cols {
+		indices := rec.Schema().FieldIndices(col.name)
+		if len(indices) != 1 {
+			return nil, fmt.Errorf("optarrow: column %q not found", col.name)
+		}
+		arr := rec.Column(indices[0])
+		if dt, _ := dataType(col.elem); !arrow.TypeEqual(arr.DataType(), dt) {
+			return nil, fmt.Errorf("optarrow: column %q holds %v, not %v", col.name, arr.DataType(), dt)
+		}
+		arrays[j] = arr
+	}
+
+	rows := make([]S, rec.NumRows())
+	for i := range rows {
+		rv := reflect.ValueOf(&rows[i]).Elem()
+		for j, col := range cols {
+			fv := rv.FieldByIndex(col.field)
+			if arrays[j].IsNull(i) {
+				if !col.optional {
+					return nil, fmt.Errorf("optarrow: row %d: column %q is null", i, col.name)
+				}
+				continue
+			}
+			value := reflect.New(col.elem).Elem()
+			readValue(arrays[j], i, value)
+			if !col.optional {
+				fv.Set(value)
+				continue
+			}
+			opt, err := optional.FromAny(value.Interface(), fv.Type())
+			if err != nil {
+				return nil, fmt.Errorf("optarrow: row %d: column %q: %w", i, col.name, err)
+			}
+			fv.Set(reflect.ValueOf(opt))
+		}
+	}
+	return rows, nil
+}
+
+// readValue reads value i of arr into dst, whose type arr was built for by
+// dataType
+func readValue(arr arrow.Array, i int, dst reflect.Value) {
+	switch arr := arr.(type) {
+	case *array.Boolean:
+		dst.SetBool(arr.Value(i))
+	case *array.Int8:
+		dst.SetInt(int64(arr.Value(i)))
+	case *array.Int16:
+		dst.SetInt(int64(arr.Value(i)))
+	case *array.Int32:
+		dst.SetInt(int64(arr.Value(i)))
+	case *array.Int64:
+		dst.SetInt(arr.Value(i))
+	case *array.Uint8:
+		dst.SetUint(uint64(arr.Value(i)))
+	case *array.Uint16:
+		dst.SetUint(uint64(arr.Value(i)))
+	case *array.Uint32:
+		dst.SetUint(uint64(arr.Value(i)))
+	case *array.Uint64:
+		dst.SetUint(arr.Value(i))
+	case *array.Float32:
+		dst.SetFloat(float64(arr.Value(i)))
+	case *array.Float64:
+		dst.SetFloat(arr.Value(i))
+	case *array.String:
+		dst.SetString(arr.Value(i))
+	case *array.Binary:
+		// the array owns the memory backing its values
+		dst.SetBytes(append([]byte{}, arr.Value(i)...))
+	case *array.Timestamp:
+		dst.Set(reflect.ValueOf(time.Unix(0, int64(arr.Value(i))).UTC()))
+	}
+}
+
+// Write writes rows to w as record batches of at most batchSize rows each,
+// allocated by mem. w must have been created with the schema of S, e.g.
+// by passing ipc.WithSchema with the result of Schema
+func Write[S any](w RecordWriter, mem memory.Allocator, rows []S, batchSize int) error {
+	if batchSize <= 0 {
+		return errors.New("optarrow: batch size must be positive")
+	}
+	for start := 0; start < len(rows); start += batchSize {
+		end := start + batchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		rec, err := NewRecordBatch(mem, rows[start:end])
+		if err != nil {
+			return err
+		}
+		err = w.Write(rec)
+		rec.Release()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadAll reads the rows of every remaining record batch of r into structs
+// of type S. r may be a reader of arrow's ipc or flight packages
+func ReadAll[S any](r array.RecordReader) ([]S, error) {
+	var rows []S
+	for r.Next() {
+		batch, err := Rows[S](r.RecordBatch())
+		if err != nil {
+			return rows, err
+		}
+		rows = append(rows, batch...)
+	}
+	return rows, r.Err()
+}
@@ -0,0 +1,214 @@
+package optarrow_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/flight"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/heucuva/optional"
+	"github.com/heucuva/optional/optarrow"
+)
+
+type testEvent struct {
+	ID      int64                     `arrow:"id"`
+	Name    optional.Value[string]    `arrow:"name"`
+	Score   optional.Value[float64]   `arrow:"score"`
+	Count   optional.Boxed[uint16]    `arrow:"count"`
+	Active  optional.Value[bool]      `arrow:"active"`
+	Payload optional.Value[[]byte]    `arrow:"payload"`
+	At      optional.Value[time.Time] `arrow:"at"`
+	Skipped string                    `arrow:"-"`
+}
+
+var testEvents = []testEvent{
+	{
+		ID:      1,
+		Name:    optional.NewValue("Foo"),
+		Score:   optional.NewValue(1.5),
+		Count:   optional.NewBoxed[uint16](3),
+		Active:  optional.NewValue(false),
+		Payload: optional.NewValue([]byte("data")),
+		At:      optional.NewValue(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)),
+	},
+	{ID: 2},
+	{ID: 3, Name: optional.NewValue(""), Score: optional.NewValue(0.0)},
+}
+
+func expectEvents(t *testing.T, observed []testEvent) {
+	t.Helper()
+	if len(observed) != len(testEvents) {
+		t.Fatalf("expected %d rows, but encountered %d", len(testEvents), len(observed))
+	}
+	for i, expected := range testEvents {
+		got := observed[i]
+		if got.ID != expected.ID ||
+			!optional.Equal(got.Name, expected.Name) ||
+			!optional.Equal(got.Score, expected.Score) ||
+			got.Count.IsSet() != expected.Count.IsSet() ||
+			!optional.Equal(got.Active, expected.Active) ||
+			got.Payload.IsSet() != expected.Payload.IsSet() ||
+			string(got.Payload.GetOrZero()) != string(expected.Payload.GetOrZero()) ||
+			got.At.IsSet() != expected.At.IsSet() ||
+			!got.At.GetOrZero().Equal(expected.At.GetOrZero()) {
+			t.Errorf("row %d: expected %v, but encountered %v", i, expected, got)
+		}
+	}
+}
+
+func TestSchema(t *testing.T) {
+	schema, err := optarrow.Schema[testEvent]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema.NumFields() != 7 {
+		t.Fatalf("expected 7 fields, but encountered %d", schema.NumFields())
+	}
+	id, name := schema.Field(0), schema.Field(1)
+	if id.Name != "id" || id.Nullable || id.Type.ID() != arrow.INT64 {
+		t.Errorf("unexpected id field %v", id)
+	}
+	if name.Name != "name" || !name.Nullable || name.Type.ID() != arrow.STRING {
+		t.Errorf("unexpected name field %v", name)
+	}
+
+	if _, err := optarrow.Schema[struct{ C optional.Value[chan int] }](); err == nil {
+		t.Error("expected failure, but got success")
+	}
+	if _, err := optarrow.Schema[int](); err == nil {
+		t.Error("expected failure, but got success")
+	}
+}
+
+func TestRecordBatch(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	rec, err := optarrow.NewRecordBatch(mem, testEvents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+
+	t.Run("Validity", func(t *testing.T) {
+		name := rec.Column(1)
+		if name.NullN() != 1 || !name.IsNull(1) || name.IsNull(2) {
+			t.Errorf("expected only row 1 to be null, got %v", name)
+		}
+		if rec.Column(0).NullN() != 0 {
+			t.Error("expected no nulls in a plain column")
+		}
+	})
+	t.Run("Rows", func(t *testing.T) {
+		observed, err := optarrow.Rows[testEvent](rec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectEvents(t, observed)
+	})
+	t.Run("Mismatch", func(t *testing.T) {
+		if _, err := optarrow.Rows[struct{ Missing int64 }](rec); err == nil {
+			t.Error("missing column: expected failure, but got success")
+		}
+		if _, err := optarrow.Rows[struct {
+			ID string `arrow:"id"`
+		}](rec); err == nil {
+			t.Error("type mismatch: expected failure, but got success")
+		}
+		if _, err := optarrow.Rows[struct {
+			Name string `arrow:"name"`
+		}](rec); err == nil {
+			t.Error("null in plain field: expected failure, but got success")
+		}
+	})
+}
+
+func TestIPC(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	schema, err := optarrow.Schema[testEvent]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w := ipc.NewWriter(&buf, ipc.WithSchema(schema), ipc.WithAllocator(mem))
+	if err := optarrow.Write(w, mem, testEvents, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ipc.NewReader(&buf, ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+	observed, err := optarrow.ReadAll[testEvent](r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectEvents(t, observed)
+
+	if err := optarrow.Write(w, mem, testEvents, 0); err == nil {
+		t.Error("expected failure, but got success")
+	}
+}
+
+// testFlightStream carries flight data from a writer to a reader in memory,
+// as a DoGet stream would
+type testFlightStream struct {
+	data []*flight.FlightData
+}
+
+func (s *testFlightStream) Send(data *flight.FlightData) error {
+	// the writer reuses data and its buffers, as gRPC serializes it at once
+	s.data = append(s.data, &flight.FlightData{
+		DataHeader: append([]byte{}, data.DataHeader...),
+		DataBody:   append([]byte{}, data.DataBody...),
+	})
+	return nil
+}
+
+func (s *testFlightStream) Recv() (*flight.FlightData, error) {
+	if len(s.data) == 0 {
+		return nil, io.EOF
+	}
+	data := s.data[0]
+	s.data = s.data[1:]
+	return data, nil
+}
+
+func TestFlight(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	schema, err := optarrow.Schema[testEvent]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stream testFlightStream
+	w := flight.NewRecordWriter(&stream, ipc.WithSchema(schema), ipc.WithAllocator(mem))
+	if err := optarrow.Write(w, mem, testEvents, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := flight.NewRecordReader(&stream, ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+	observed, err := optarrow.ReadAll[testEvent](r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectEvents(t, observed)
+}
@@ -0,0 +1,16 @@
+module github.com/heucuva/optional/optcbor
+
+go 1.20
+
+require (
+	github.com/fxamacker/cbor/v2 v2.9.4
+	github.com/heucuva/optional v0.0.0
+)
+
+require (
+	github.com/x448/float16 v0.8.4 // indirect
+	golang.org/x/exp v0.0.0-20220713135740-79cabaa25d75 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/heucuva/optional => ../
@@ -0,0 +1,25 @@
+// Package optcbor registers github.com/fxamacker/cbor/v2 as the CBOR codec
+// for optional values. Import it for its side effect:
+//
+//	import _ "github.com/heucuva/optional/optcbor"
+//
+// Unset values encode as CBOR null, and both null and undefined decode as unset.
+package optcbor
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	"github.com/heucuva/optional"
+)
+
+func init() {
+	Register(cbor.Marshal, cbor.Unmarshal)
+}
+
+// Register installs custom marshaling functions, such as those of a
+// cbor.EncMode and cbor.DecMode, in place of the package defaults
+func Register(marshal func(any) ([]byte, error), unmarshal func([]byte, any) error) {
+	optional.RegisterCBORCodec(optional.CBORCodec{
+		Marshal:   marshal,
+		Unmarshal: unmarshal,
+	})
+}
@@ -0,0 +1,257 @@
+package optcbor_test
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/heucuva/optional"
+	_ "github.com/heucuva/optional/optcbor"
+)
+
+type cborTest[T any] struct {
+	test  string
+	value T
+}
+
+func cborCase[T any](name string, value T) cborTest[T] {
+	return cborTest[T]{test: name, value: value}
+}
+
+// run checks that a set Value encodes exactly like its contents, and that
+// the encoding decodes back into an equal set Value
+func (ti cborTest[T]) run(t *testing.T) {
+	t.Helper()
+	expected, err := cbor.Marshal(ti.value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob, err := cbor.Marshal(optional.NewValue(ti.value))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(expected, blob) {
+		t.Fatalf("expected %x, got %x", expected, blob)
+	}
+
+	var observed optional.Value[T]
+	if err := cbor.Unmarshal(blob, &observed); err != nil {
+		t.Fatal(err)
+	}
+	value, set := observed.Get()
+	if !set || !reflect.DeepEqual(value, ti.value) {
+		t.Fatalf("expected %+v, got %+v (set %v)", ti.value, value, set)
+	}
+}
+
+func testCBOR[T any](t *testing.T, tests ...cborTest[T]) {
+	t.Helper()
+
+	t.Run("Unset", func(t *testing.T) {
+		blob, err := cbor.Marshal(optional.Value[T]{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(blob, []byte{0xf6}) {
+			t.Fatalf("expected null, got %x", blob)
+		}
+		for _, data := range [][]byte{{0xf6}, {0xf7}} {
+			var observed optional.Value[T]
+			if err := cbor.Unmarshal(data, &observed); err != nil {
+				t.Fatal(err)
+			}
+			if observed.IsSet() {
+				t.Fatalf("expected %x to decode as unset", data)
+			}
+		}
+	})
+
+	for _, ti := range tests {
+		t.Run(ti.test, ti.run)
+	}
+}
+
+func TestCBOR(t *testing.T) {
+	// Boolean
+	t.Run("Bool", func(t *testing.T) {
+		testCBOR(t,
+			cborCase("True", true),
+			cborCase("False", false),
+		)
+	})
+
+	// Signed Integer
+	t.Run("Int", func(t *testing.T) {
+		testCBOR(t,
+			cborCase("Zero", 0),
+			cborCase("Positive", math.MaxInt),
+			cborCase("Negative", math.MinInt),
+		)
+	})
+	t.Run("Int8", func(t *testing.T) {
+		testCBOR(t,
+			cborCase[int8]("Zero", 0),
+			cborCase[int8]("Positive", math.MaxInt8),
+			cborCase[int8]("Negative", math.MinInt8),
+		)
+	})
+	t.Run("Int16", func(t *testing.T) {
+		testCBOR(t,
+			cborCase[int16]("Zero", 0),
+			cborCase[int16]("Positive", math.MaxInt16),
+			cborCase[int16]("Negative", math.MinInt16),
+		)
+	})
+	t.Run("Int32", func(t *testing.T) {
+		testCBOR(t,
+			cborCase[int32]("Zero", 0),
+			cborCase[int32]("Positive", math.MaxInt32),
+			cborCase[int32]("Negative", math.MinInt32),
+		)
+	})
+
+	// Unsigned integer
+	t.Run("Uint", func(t *testing.T) {
+		testCBOR(t,
+			cborCase[uint]("Zero", 0),
+			cborCase[uint]("Max", math.MaxUint),
+		)
+	})
+	t.Run("Uint8", func(t *testing.T) {
+		testCBOR(t,
+			cborCase[uint8]("Zero", 0),
+			cborCase[uint8]("Max", math.MaxUint8),
+		)
+	})
+	t.Run("Uint16", func(t *testing.T) {
+		testCBOR(t,
+			cborCase[uint16]("Zero", 0),
+			cborCase[uint16]("Max", math.MaxUint16),
+		)
+	})
+	t.Run("Uint32", func(t *testing.T) {
+		testCBOR(t,
+			cborCase[uint32]("Zero", 0),
+			cborCase[uint32]("Max", math.MaxUint32),
+		)
+	})
+
+	// Floating point
+	t.Run("Float32", func(t *testing.T) {
+		testCBOR(t,
+			cborCase[float32]("ZeroPositive", 0.0),
+			cborCase("ZeroNegative", math.Float32frombits(0x80000000)),
+			cborCase[float32]("Positive", math.MaxFloat32),
+			cborCase[float32]("Negative", -math.MaxFloat32),
+			cborCase[float32]("Smallest", math.SmallestNonzeroFloat32),
+			cborCase("PositiveInf", math.Float32frombits(0x7F800000)),
+			cborCase("NegativeInf", math.Float32frombits(0xFF800000)),
+		)
+	})
+	t.Run("Float64", func(t *testing.T) {
+		testCBOR(t,
+			cborCase("ZeroPositive", 0.0),
+			cborCase("ZeroNegative", math.Float64frombits(0x8000000000000000)),
+			cborCase("Positive", math.MaxFloat64),
+			cborCase("Negative", -math.MaxFloat64),
+			cborCase("Smallest", math.SmallestNonzeroFloat64),
+			cborCase("PositiveInf", math.Inf(1)),
+			cborCase("NegativeInf", math.Inf(-1)),
+		)
+	})
+
+	// Rune
+	t.Run("Rune", func(t *testing.T) {
+		testCBOR(t,
+			cborCase("Alpha", 'A'),
+			cborCase("Unicode", '⺟'),
+		)
+	})
+
+	// String
+	t.Run("String", func(t *testing.T) {
+		testCBOR(t,
+			cborCase("Empty", ""),
+			cborCase("NonEmpty", "The quick brown fox"),
+		)
+	})
+
+	// Bytes
+	t.Run("Bytes", func(t *testing.T) {
+		testCBOR(t,
+			cborCase("Empty", []byte{}),
+			cborCase("NonEmpty", []byte("The quick brown fox")),
+		)
+	})
+
+	// Slice
+	t.Run("Slice", func(t *testing.T) {
+		testCBOR(t,
+			cborCase("Empty", []string{}),
+			cborCase("NonEmpty", []string{"The quick brown fox"}),
+		)
+	})
+
+	// Map
+	t.Run("Map", func(t *testing.T) {
+		testCBOR(t,
+			cborCase("Empty", map[string]string{}),
+			cborCase("NonEmpty", map[string]string{"entry": "The quick brown fox"}),
+		)
+	})
+
+	// Struct
+	t.Run("Struct", func(t *testing.T) {
+		t.Run("OneField", func(t *testing.T) {
+			type testStructOneField struct {
+				Value int `cbor:"value"`
+			}
+			testCBOR(t,
+				cborCase("Zero", testStructOneField{}),
+				cborCase("Set", testStructOneField{Value: 5}),
+			)
+		})
+		t.Run("TwoFields", func(t *testing.T) {
+			type testStructTwoFields struct {
+				A int  `cbor:"a"`
+				B bool `cbor:"b"`
+			}
+			testCBOR(t,
+				cborCase("Set", testStructTwoFields{A: 1, B: true}),
+			)
+		})
+		t.Run("EmbeddedOptional", func(t *testing.T) {
+			type testStructEmbeddedOptional struct {
+				Value optional.Value[int] `cbor:"value"`
+			}
+			testCBOR(t,
+				cborCase("SetValueUnset", testStructEmbeddedOptional{}),
+				cborCase("SetValueSet", testStructEmbeddedOptional{Value: optional.NewValue(5)}),
+			)
+		})
+	})
+}
+
+func TestCBORBoxed(t *testing.T) {
+	blob, err := cbor.Marshal(optional.NewBoxed("Foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := cbor.Marshal(optional.NewValue("Foo"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(expected, blob) {
+		t.Fatalf("expected %x, got %x", expected, blob)
+	}
+
+	var observed optional.Boxed[string]
+	if err := cbor.Unmarshal(blob, &observed); err != nil {
+		t.Fatal(err)
+	}
+	if value, set := observed.Get(); !set || value != "Foo" {
+		t.Fatalf("expected Foo, got %q (set %v)", value, set)
+	}
+}
@@ -0,0 +1,293 @@
+// Package optcsv reads CSV and TSV streams into structs of optional values.
+//
+// Each struct field is bound to the column whose header matches its `csv`
+// tag (or its name, if untagged). Cells holding a null token leave their
+// optional field unset, so "no value" and "empty value" can be told apart.
+package optcsv
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/heucuva/optional"
+)
+
+// ErrorPolicy controls what the Reader does when a row cannot be decoded
+type ErrorPolicy int
+
+const (
+	// FailFast returns the first decoding error from Read
+	FailFast = ErrorPolicy(iota)
+	// SkipRow skips rows that cannot be decoded, reporting them to the
+	// error handler (if one is installed)
+	SkipRow
+)
+
+// ParseError describes a cell that could not be decoded
+type ParseError struct {
+	Line   int
+	Column string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("optcsv: line %d, column %q: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Option configures a Reader
+type Option func(*config)
+
+type config struct {
+	comma        rune
+	nullTokens   []string
+	columnTokens map[string][]string
+	policy       ErrorPolicy
+	onError      func(err error)
+}
+
+// Comma sets the field delimiter (',' by default; use '\t' for TSV)
+func Comma(r rune) Option {
+	return func(c *config) {
+		c.comma = r
+	}
+}
+
+// NullTokens sets the cell contents treated as null for every column
+// (by default, only the empty string)
+func NullTokens(tokens ...string) Option {
+	return func(c *config) {
+		c.nullTokens = tokens
+	}
+}
+
+// ColumnNullTokens sets the cell contents treated as null for one column,
+// overriding NullTokens
+func ColumnNullTokens(column string, tokens ...string) Option {
+	return func(c *config) {
+		c.columnTokens[column] = tokens
+	}
+}
+
+// Policy sets the ErrorPolicy (FailFast by default)
+func Policy(policy ErrorPolicy) Option {
+	return func(c *config) {
+		c.policy = policy
+	}
+}
+
+// OnError installs a handler that is called with every row error skipped
+// under the SkipRow policy
+func OnError(fn func(err error)) Option {
+	return func(c *config) {
+		c.onError = fn
+	}
+}
+
+type binding struct {
+	column string
+	index  int
+	field  []int
+	tokens []string
+}
+
+// Reader decodes records from a CSV stream into values of struct type T
+type Reader[T any] struct {
+	r        *csv.Reader
+	cfg      config
+	bindings []binding
+}
+
+// NewReader constructs a Reader, consuming the header row of the stream.
+// Every column bound to a field of T must be present in the header.
+func NewReader[T any](r io.Reader, opts ...Option) (*Reader[T], error) {
+	cfg := config{
+		comma:        ',',
+		nullTokens:   []string{""},
+		columnTokens: make(map[string][]string),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	rt := reflect.TypeOf((*T)(nil)).Elem()
+	if rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("optcsv: %v is not a struct type", rt)
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comma = cfg.comma
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+
+	reader := &Reader[T]{
+		r:   cr,
+		cfg: cfg,
+	}
+	for _, field := range reflect.VisibleFields(rt) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		column := field.Name
+		if tag, ok := field.Tag.Lookup("csv"); ok {
+			if tag == "-" {
+				continue
+			}
+			column = tag
+		}
+		index, ok := columns[column]
+		if !ok {
+			return nil, fmt.Errorf("optcsv: column %q not found in header", column)
+		}
+		tokens, ok := cfg.columnTokens[column]
+		if !ok {
+			tokens = cfg.nullTokens
+		}
+		reader.bindings = append(reader.bindings, binding{
+			column: column,
+			index:  index,
+			field:  field.Index,
+			tokens: tokens,
+		})
+	}
+	return reader, nil
+}
+
+// Read decodes the next record.
+// it returns io.EOF when there are no more records
+func (r *Reader[T]) Read() (T, error) {
+	for {
+		value, err := r.read()
+		if err == nil || r.cfg.policy != SkipRow {
+			return value, err
+		}
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			return value, err
+		}
+		if r.cfg.onError != nil {
+			r.cfg.onError(err)
+		}
+	}
+}
+
+// ReadAll decodes all of the remaining records
+func (r *Reader[T]) ReadAll() ([]T, error) {
+	var values []T
+	for {
+		value, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return values, nil
+		}
+		if err != nil {
+			return values, err
+		}
+		values = append(values, value)
+	}
+}
+
+func (r *Reader[T]) read() (T, error) {
+	var value T
+	record, err := r.r.Read()
+	if err != nil {
+		return value, err
+	}
+	line, _ := r.r.FieldPos(0)
+
+	rv := reflect.ValueOf(&value).Elem()
+	for _, b := range r.bindings {
+		if b.index >= len(record) {
+			continue
+		}
+		cell := record[b.index]
+		if isNullToken(cell, b.tokens) {
+			continue
+		}
+		if err := setField(rv.FieldByIndex(b.field), cell); err != nil {
+			return value, &ParseError{Line: line, Column: b.column, Err: err}
+		}
+	}
+	return value, nil
+}
+
+func isNullToken(cell string, tokens []string) bool {
+	for _, token := range tokens {
+		if cell == token {
+			return true
+		}
+	}
+	return false
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+func setField(fv reflect.Value, cell string) error {
+	if !optional.IsOptionalType(fv.Type()) {
+		return parseCell(fv, cell)
+	}
+
+	elem := reflect.New(optional.ElemType(fv.Type())).Elem()
+	if err := parseCell(elem, cell); err != nil {
+		return err
+	}
+	opt, err := optional.FromAny(elem.Interface(), fv.Type())
+	if err != nil {
+		return err
+	}
+	fv.Set(reflect.ValueOf(opt))
+	return nil
+}
+
+func parseCell(v reflect.Value, cell string) error {
+	if reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(cell))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(cell)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(cell, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(cell, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(cell, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %v", v.Type())
+	}
+	return nil
+}
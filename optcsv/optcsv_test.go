@@ -0,0 +1,120 @@
+package optcsv_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heucuva/optional"
+	"github.com/heucuva/optional/optcsv"
+)
+
+type testRecord struct {
+	Name    string                    `csv:"name"`
+	Age     optional.Value[int]       `csv:"age"`
+	Score   optional.Value[float64]   `csv:"score"`
+	Comment optional.Value[string]    `csv:"comment"`
+	Seen    optional.Value[time.Time] `csv:"seen"`
+	Ignored int                       `csv:"-"`
+}
+
+func TestReader(t *testing.T) {
+	const data = "name,age,score,comment,seen\n" +
+		"alice,30,1.5,,2022-01-02T03:04:05Z\n" +
+		"bob,,NA,hello,\n"
+
+	t.Run("CSV", func(t *testing.T) {
+		reader, err := optcsv.NewReader[testRecord](strings.NewReader(data),
+			optcsv.ColumnNullTokens("score", "NA"),
+			optcsv.ColumnNullTokens("comment"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		records, err := reader.ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != 2 {
+			t.Fatalf("expected 2 records, got %d", len(records))
+		}
+
+		alice := records[0]
+		if age, set := alice.Age.Get(); !set || age != 30 {
+			t.Fatalf("expected age 30, got %v (set %v)", age, set)
+		}
+		if comment, set := alice.Comment.Get(); !set || comment != "" {
+			t.Fatalf("expected empty comment to be set, got %q (set %v)", comment, set)
+		}
+		if seen, set := alice.Seen.Get(); !set || seen.Year() != 2022 {
+			t.Fatalf("expected seen in 2022, got %v (set %v)", seen, set)
+		}
+
+		bob := records[1]
+		if bob.Name != "bob" {
+			t.Fatalf("expected name bob, got %q", bob.Name)
+		}
+		if bob.Age.IsSet() || bob.Score.IsSet() || bob.Seen.IsSet() {
+			t.Fatalf("expected null cells to be unset, got %+v", bob)
+		}
+	})
+	t.Run("TSV", func(t *testing.T) {
+		tsv := strings.NewReplacer(",", "\t").Replace(data)
+		reader, err := optcsv.NewReader[testRecord](strings.NewReader(tsv),
+			optcsv.Comma('\t'),
+			optcsv.NullTokens("", "NA"),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		records, err := reader.ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != 2 || records[1].Score.IsSet() || records[0].Comment.IsSet() {
+			t.Fatalf("unexpected records %+v", records)
+		}
+	})
+	t.Run("FailFast", func(t *testing.T) {
+		reader, err := optcsv.NewReader[testRecord](strings.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := reader.Read(); err != nil {
+			t.Fatal(err)
+		}
+		_, err = reader.Read()
+		var perr *optcsv.ParseError
+		if !errors.As(err, &perr) {
+			t.Fatalf("expected ParseError, got %v", err)
+		}
+		if perr.Line != 3 || perr.Column != "score" {
+			t.Fatalf("unexpected error location %d %q", perr.Line, perr.Column)
+		}
+	})
+	t.Run("SkipRow", func(t *testing.T) {
+		var skipped []error
+		reader, err := optcsv.NewReader[testRecord](strings.NewReader(data),
+			optcsv.Policy(optcsv.SkipRow),
+			optcsv.OnError(func(err error) {
+				skipped = append(skipped, err)
+			}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		records, err := reader.ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != 1 || len(skipped) != 1 {
+			t.Fatalf("expected 1 record and 1 skipped row, got %d and %d", len(records), len(skipped))
+		}
+	})
+	t.Run("MissingColumn", func(t *testing.T) {
+		if _, err := optcsv.NewReader[testRecord](strings.NewReader("name,age\n")); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+}
@@ -0,0 +1,179 @@
+// Package optenum maps enumerated values to and from their names, producing
+// optional values so unrecognized names can be treated as "not provided".
+package optenum
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/heucuva/optional"
+)
+
+// Policy controls how unrecognized names are decoded
+type Policy int
+
+const (
+	// UnknownAsUnset decodes unrecognized names as an unset value
+	UnknownAsUnset = Policy(iota)
+	// UnknownAsError fails to decode unrecognized names
+	UnknownAsError
+)
+
+// UnknownNameError is returned when an unrecognized name is decoded under
+// the UnknownAsError policy
+type UnknownNameError struct {
+	Name string
+}
+
+func (e *UnknownNameError) Error() string {
+	return fmt.Sprintf("optenum: unknown name %q", e.Name)
+}
+
+// Enum is an enum descriptor: a table mapping values of E to their names
+type Enum[E comparable] struct {
+	names  map[E]string
+	values map[string]E
+	policy Policy
+}
+
+// New constructs an Enum descriptor out of a value to name table
+func New[E comparable](table map[E]string, policy Policy) *Enum[E] {
+	e := &Enum[E]{
+		names:  make(map[E]string, len(table)),
+		values: make(map[string]E, len(table)),
+		policy: policy,
+	}
+	for value, name := range table {
+		e.names[value] = name
+		e.values[name] = value
+	}
+	return e
+}
+
+// Parse returns the value with the given name.
+// an unrecognized name results in an unset value, regardless of policy
+func (e *Enum[E]) Parse(name string) optional.Value[E] {
+	if value, ok := e.values[name]; ok {
+		return optional.NewValue(value)
+	}
+	return optional.Value[E]{}
+}
+
+// Decode returns the value with the given name.
+// an unrecognized name is handled according to the descriptor's policy
+func (e *Enum[E]) Decode(name string) (optional.Value[E], error) {
+	v := e.Parse(name)
+	if !v.IsSet() && e.policy == UnknownAsError {
+		return v, &UnknownNameError{Name: name}
+	}
+	return v, nil
+}
+
+// String returns the name of value, or its default formatting if it is
+// not in the table
+func (e *Enum[E]) String(value E) string {
+	if name, ok := e.names[value]; ok {
+		return name
+	}
+	return fmt.Sprint(value)
+}
+
+// Described is implemented by enum types that can provide their own
+// descriptor, which lets Value encode and decode them by name
+type Described[E comparable] interface {
+	comparable
+	Descriptor() *Enum[E]
+}
+
+// Value is an optional enum value which is encoded by name in json and text
+type Value[E Described[E]] struct {
+	v optional.Value[E]
+}
+
+// NewValue constructs a Value structure with a value already set into it
+func NewValue[E Described[E]](value E) Value[E] {
+	return Value[E]{v: optional.NewValue(value)}
+}
+
+// Reset clears the memory on the value
+func (o *Value[E]) Reset() {
+	o.v.Reset()
+}
+
+// Set updates the value and sets the set flag
+func (o *Value[E]) Set(value E) {
+	o.v.Set(value)
+}
+
+// IsSet returns true if the value is set
+func (o Value[E]) IsSet() bool {
+	return o.v.IsSet()
+}
+
+// Get returns the value and its set flag
+func (o Value[E]) Get() (E, bool) {
+	return o.v.Get()
+}
+
+// Optional returns the value as an optional.Value
+func (o Value[E]) Optional() optional.Value[E] {
+	return o.v
+}
+
+func descriptor[E Described[E]]() *Enum[E] {
+	var zero E
+	return zero.Descriptor()
+}
+
+// MarshalText outputs the name of the value, if it is set.
+// otherwise, it returns empty text
+func (o Value[E]) MarshalText() ([]byte, error) {
+	value, set := o.v.Get()
+	if !set {
+		return []byte{}, nil
+	}
+	return []byte(descriptor[E]().String(value)), nil
+}
+
+// UnmarshalText decodes a value out of its name.
+// empty text resets the value
+func (o *Value[E]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		o.Reset()
+		return nil
+	}
+	v, err := descriptor[E]().Decode(string(text))
+	if err != nil {
+		return err
+	}
+	o.v = v
+	return nil
+}
+
+// MarshalJSON outputs the name of the value, if it is set.
+// otherwise, it returns nil
+func (o Value[E]) MarshalJSON() ([]byte, error) {
+	value, set := o.v.Get()
+	if !set {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(descriptor[E]().String(value))
+}
+
+// UnmarshalJSON decodes a value out of its name
+func (o *Value[E]) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 || string(data) == "null" {
+		o.Reset()
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	v, err := descriptor[E]().Decode(name)
+	if err != nil {
+		return err
+	}
+	o.v = v
+	return nil
+}
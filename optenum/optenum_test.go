@@ -0,0 +1,119 @@
+package optenum_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/heucuva/optional/optenum"
+)
+
+type testColor int
+
+const (
+	testRed = testColor(iota + 1)
+	testGreen
+)
+
+var testColors = optenum.New(map[testColor]string{
+	testRed:   "red",
+	testGreen: "green",
+}, optenum.UnknownAsUnset)
+
+func (testColor) Descriptor() *optenum.Enum[testColor] {
+	return testColors
+}
+
+type testSize string
+
+var testSizes = optenum.New(map[testSize]string{
+	"S": "small",
+	"L": "large",
+}, optenum.UnknownAsError)
+
+func (testSize) Descriptor() *optenum.Enum[testSize] {
+	return testSizes
+}
+
+func TestEnum(t *testing.T) {
+	t.Run("Parse", func(t *testing.T) {
+		if value, set := testColors.Parse("green").Get(); !set || value != testGreen {
+			t.Fatalf("expected green, got %v (set %v)", value, set)
+		}
+		if testColors.Parse("blue").IsSet() {
+			t.Fatal("expected unknown name to be unset")
+		}
+	})
+	t.Run("Decode", func(t *testing.T) {
+		if _, err := testColors.Decode("blue"); err != nil {
+			t.Fatal(err)
+		}
+		_, err := testSizes.Decode("medium")
+		var unknown *optenum.UnknownNameError
+		if !errors.As(err, &unknown) || unknown.Name != "medium" {
+			t.Fatalf("expected UnknownNameError, got %v", err)
+		}
+	})
+	t.Run("String", func(t *testing.T) {
+		if observed := testColors.String(testRed); observed != "red" {
+			t.Fatalf("expected red, got %q", observed)
+		}
+		if observed := testColors.String(testColor(42)); observed != "42" {
+			t.Fatalf("expected 42, got %q", observed)
+		}
+	})
+}
+
+func TestValue(t *testing.T) {
+	type testRequest struct {
+		Color optenum.Value[testColor] `json:"color"`
+		Size  optenum.Value[testSize]  `json:"size"`
+	}
+
+	t.Run("MarshalJSON", func(t *testing.T) {
+		blob, err := json.Marshal(testRequest{Color: optenum.NewValue(testGreen)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if observed := string(blob); observed != `{"color":"green","size":null}` {
+			t.Fatalf("unexpected json %s", observed)
+		}
+	})
+	t.Run("UnmarshalJSON", func(t *testing.T) {
+		var observed testRequest
+		if err := json.Unmarshal([]byte(`{"color":"red","size":"large"}`), &observed); err != nil {
+			t.Fatal(err)
+		}
+		if value, _ := observed.Color.Get(); value != testRed {
+			t.Fatalf("expected red, got %v", value)
+		}
+		if value, _ := observed.Size.Get(); value != "L" {
+			t.Fatalf("expected L, got %v", value)
+		}
+	})
+	t.Run("UnmarshalJSONUnknown", func(t *testing.T) {
+		var observed testRequest
+		if err := json.Unmarshal([]byte(`{"color":"blue"}`), &observed); err != nil {
+			t.Fatal(err)
+		}
+		if observed.Color.IsSet() {
+			t.Fatal("expected unknown color to be unset")
+		}
+		if err := json.Unmarshal([]byte(`{"size":"medium"}`), &observed); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+	t.Run("Text", func(t *testing.T) {
+		text, err := optenum.NewValue(testRed).MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var observed optenum.Value[testColor]
+		if err := observed.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if value := observed.Optional().MustGet(); value != testRed {
+			t.Fatalf("expected red, got %v", value)
+		}
+	})
+}
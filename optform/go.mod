@@ -0,0 +1,16 @@
+module github.com/heucuva/optional/optform
+
+go 1.20
+
+require (
+	github.com/go-playground/form/v4 v4.2.1
+	github.com/gorilla/schema v1.4.1
+	github.com/heucuva/optional v0.0.0
+)
+
+require (
+	golang.org/x/exp v0.0.0-20220713135740-79cabaa25d75 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/heucuva/optional => ../
@@ -0,0 +1,114 @@
+// Package optform lets optional values be decoded from and encoded to URL
+// query strings and form posts with github.com/gorilla/schema and
+// github.com/go-playground/form.
+//
+// A parameter that is absent leaves its optional unset, and an empty
+// parameter resets it. Values are parsed and formatted with the optional's
+// text encoding, so T may be a string, bool, number, or a type implementing
+// encoding.TextMarshaler and encoding.TextUnmarshaler.
+//
+// gorilla/schema decodes optional.Value fields through their UnmarshalText
+// method without any registration; only its encoder needs registering.
+package optform
+
+import (
+	"encoding"
+	"reflect"
+
+	"github.com/go-playground/form/v4"
+	"github.com/gorilla/schema"
+	"github.com/heucuva/optional"
+)
+
+// RegisterSchemaEncoder registers optional types with a gorilla/schema
+// encoder. set values are encoded as text, and unset values are omitted
+// from fields tagged omitempty.
+//
+// Each of types is either an optional value (e.g. optional.Value[int]{}) or
+// a struct, or pointer to a struct, whose optional fields are registered,
+// including those of nested structs.
+func RegisterSchemaEncoder(e *schema.Encoder, types ...any) {
+	for _, t := range textTypes(types) {
+		e.RegisterEncoder(reflect.Zero(t).Interface(), func(v reflect.Value) string {
+			text, _ := v.Interface().(encoding.TextMarshaler).MarshalText()
+			return string(text)
+		})
+	}
+}
+
+// RegisterFormDecoder registers optional types with a go-playground/form
+// decoder. types are found as they are by RegisterSchemaEncoder
+func RegisterFormDecoder(d *form.Decoder, types ...any) {
+	for _, t := range textTypes(types) {
+		t := t
+		d.RegisterCustomTypeFunc(func(values []string) (any, error) {
+			v := reflect.New(t)
+			var text string
+			if len(values) > 0 {
+				text = values[0]
+			}
+			if err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+				return nil, err
+			}
+			return v.Elem().Interface(), nil
+		}, reflect.Zero(t).Interface())
+	}
+}
+
+// RegisterFormEncoder registers optional types with a go-playground/form
+// encoder. set values are encoded as text, and unset values are omitted.
+// types are found as they are by RegisterSchemaEncoder
+func RegisterFormEncoder(e *form.Encoder, types ...any) {
+	for _, t := range textTypes(types) {
+		e.RegisterCustomTypeFunc(func(x any) ([]string, error) {
+			if opt, ok := x.(interface{ IsSet() bool }); ok && !opt.IsSet() {
+				return nil, nil
+			}
+			text, err := x.(encoding.TextMarshaler).MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			return []string{string(text)}, nil
+		}, reflect.Zero(t).Interface())
+	}
+}
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// textTypes returns the optional types with a text encoding found in types
+func textTypes(types []any) []reflect.Type {
+	var found []reflect.Type
+	seen := make(map[reflect.Type]bool)
+	var find func(t reflect.Type)
+	find = func(t reflect.Type) {
+		for t != nil && t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t == nil || seen[t] {
+			return
+		}
+		seen[t] = true
+
+		if optional.IsOptionalType(t) {
+			if t.Implements(textMarshalerType) && reflect.PointerTo(t).Implements(textUnmarshalerType) {
+				found = append(found, t)
+			}
+			return
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			for i := 0; i < t.NumField(); i++ {
+				find(t.Field(i).Type)
+			}
+		case reflect.Slice, reflect.Array, reflect.Map:
+			find(t.Elem())
+		}
+	}
+	for _, t := range types {
+		find(reflect.TypeOf(t))
+	}
+	return found
+}
@@ -0,0 +1,117 @@
+package optform_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/go-playground/form/v4"
+	"github.com/gorilla/schema"
+	"github.com/heucuva/optional"
+	"github.com/heucuva/optional/optform"
+)
+
+type query struct {
+	Name   optional.Value[string]  `schema:"name,omitempty" form:"name"`
+	Limit  optional.Value[int]     `schema:"limit,omitempty" form:"limit"`
+	Active optional.Value[bool]    `schema:"active,omitempty" form:"active"`
+	Ratio  optional.Value[float64] `schema:"ratio,omitempty" form:"ratio"`
+}
+
+func checkDecoded(t *testing.T, q query) {
+	t.Helper()
+	if v, set := q.Name.Get(); !set || v != "foo" {
+		t.Errorf("expected name foo, got %v", q.Name)
+	}
+	if v, set := q.Limit.Get(); !set || v != 10 {
+		t.Errorf("expected limit 10, got %v", q.Limit)
+	}
+	if v, set := q.Active.Get(); !set || v {
+		t.Errorf("expected active to be set to false, got %v", q.Active)
+	}
+	if q.Ratio.IsSet() {
+		t.Errorf("expected absent ratio to be unset, got %v", q.Ratio)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	values := url.Values{"name": {"foo"}, "limit": {"10"}, "active": {"false"}}
+
+	t.Run("Decode", func(t *testing.T) {
+		var q query
+		if err := schema.NewDecoder().Decode(&q, values); err != nil {
+			t.Fatal(err)
+		}
+		checkDecoded(t, q)
+	})
+	t.Run("DecodeInvalid", func(t *testing.T) {
+		var q query
+		if err := schema.NewDecoder().Decode(&q, url.Values{"limit": {"x"}}); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+	t.Run("Encode", func(t *testing.T) {
+		e := schema.NewEncoder()
+		optform.RegisterSchemaEncoder(e, query{})
+		q := query{
+			Name:   optional.NewValue("foo"),
+			Limit:  optional.NewValue(10),
+			Active: optional.NewValue(false),
+		}
+		encoded := url.Values{}
+		if err := e.Encode(q, encoded); err != nil {
+			t.Fatal(err)
+		}
+		if encoded.Encode() != values.Encode() {
+			t.Fatalf("expected %q, got %q", values.Encode(), encoded.Encode())
+		}
+	})
+}
+
+func TestForm(t *testing.T) {
+	values := url.Values{"name": {"foo"}, "limit": {"10"}, "active": {"false"}}
+
+	t.Run("Decode", func(t *testing.T) {
+		d := form.NewDecoder()
+		optform.RegisterFormDecoder(d, &query{})
+		var q query
+		if err := d.Decode(&q, values); err != nil {
+			t.Fatal(err)
+		}
+		checkDecoded(t, q)
+	})
+	t.Run("DecodeEmpty", func(t *testing.T) {
+		d := form.NewDecoder()
+		optform.RegisterFormDecoder(d, optional.Value[string]{})
+		q := query{Name: optional.NewValue("bar")}
+		if err := d.Decode(&q, url.Values{"name": {""}}); err != nil {
+			t.Fatal(err)
+		}
+		if q.Name.IsSet() {
+			t.Fatalf("expected an empty parameter to reset the value, got %v", q.Name)
+		}
+	})
+	t.Run("DecodeInvalid", func(t *testing.T) {
+		d := form.NewDecoder()
+		optform.RegisterFormDecoder(d, query{})
+		var q query
+		if err := d.Decode(&q, url.Values{"limit": {"x"}}); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+	t.Run("Encode", func(t *testing.T) {
+		e := form.NewEncoder()
+		optform.RegisterFormEncoder(e, query{})
+		q := query{
+			Name:   optional.NewValue("foo"),
+			Limit:  optional.NewValue(10),
+			Active: optional.NewValue(false),
+		}
+		encoded, err := e.Encode(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if encoded.Encode() != values.Encode() {
+			t.Fatalf("expected %q, got %q", values.Encode(), encoded.Encode())
+		}
+	})
+}
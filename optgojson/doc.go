@@ -0,0 +1,10 @@
+// Package optgojson tests that optional values work with
+// github.com/goccy/go-json, which has no extension mechanism to register
+// them with.
+//
+// go-json encodes and decodes optionals through their MarshalJSON and
+// UnmarshalJSON methods, and honors IsZero for fields tagged omitzero, so
+// unset optionals are dropped from those fields just as encoding/json drops
+// them. Like encoding/json, it does not consider a struct empty for
+// omitempty, so use omitzero rather than omitempty on optional fields.
+package optgojson
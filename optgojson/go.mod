@@ -0,0 +1,14 @@
+module github.com/heucuva/optional/optgojson
+
+go 1.21
+
+require github.com/heucuva/optional v0.0.0
+
+require gopkg.in/yaml.v3 v3.0.1 // indirect
+
+require (
+	github.com/goccy/go-json v0.11.1
+	golang.org/x/exp v0.0.0-20220713135740-79cabaa25d75 // indirect
+)
+
+replace github.com/heucuva/optional => ../
@@ -0,0 +1,61 @@
+package optgojson_test
+
+import (
+	"testing"
+
+	gojson "github.com/goccy/go-json"
+	"github.com/heucuva/optional"
+)
+
+type record struct {
+	Name     optional.Value[string]     `json:"name,omitzero"`
+	Age      optional.Value[int]        `json:"age,omitzero"`
+	Nickname optional.Value[string]     `json:"nickname"`
+	Count    optional.Boxed[int]        `json:"count,omitzero"`
+	Password optional.Sensitive[string] `json:"password,omitzero"`
+}
+
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    record
+		expected string
+	}{
+		{"Unset", record{}, `{"nickname":null}`},
+		{"Zero", record{
+			Name:  optional.NewValue(""),
+			Age:   optional.NewValue(0),
+			Count: optional.NewBoxed(0),
+		}, `{"name":"","age":0,"nickname":null,"count":0}`},
+		{"Set", record{
+			Name:     optional.NewValue("Foo"),
+			Nickname: optional.NewValue("foo"),
+			Password: optional.NewSensitive("hunter2"),
+		}, `{"name":"Foo","nickname":"foo","password":"hunter2"}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			blob, err := gojson.Marshal(tc.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(blob) != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, blob)
+			}
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	var observed record
+	observed.Nickname.Set("foo")
+	if err := gojson.Unmarshal([]byte(`{"name":"Foo","age":5,"count":3}`), &observed); err != nil {
+		t.Fatal(err)
+	}
+	if observed.Name.MustGet() != "Foo" || observed.Age.MustGet() != 5 || observed.Count.Value().MustGet() != 3 {
+		t.Fatalf("unexpected %+v", observed)
+	}
+	if observed.Nickname.MustGet() != "foo" || observed.Password.IsSet() {
+		t.Fatalf("unexpected %+v", observed)
+	}
+}
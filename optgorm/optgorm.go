@@ -0,0 +1,157 @@
+// Package optgorm provides an optional value which GORM maps to a nullable
+// database column.
+//
+// optional.Value already implements sql.Scanner and driver.Valuer, but GORM
+// infers a column's type from a field's value, which is unavailable for an
+// unset optional. The Value in this package reports its type to GORM from T
+// instead, so schema migration produces the correct column types.
+package optgorm
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"time"
+
+	"github.com/heucuva/optional"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Value is an optional value stored in a nullable database column.
+// unset values are written as NULL, and NULL is read as an unset value.
+//
+// T must be a bool, number, string, []byte, or time.Time (or a type whose
+// underlying type is one of them); other types need an explicit column type
+// in a `gorm:"type:..."` tag.
+type Value[T any] struct {
+	v optional.Value[T]
+}
+
+// NewValue constructs a Value structure with a value already set into it
+func NewValue[T any](value T) Value[T] {
+	return Value[T]{v: optional.NewValue(value)}
+}
+
+// FromOptional converts an optional.Value into a Value
+func FromOptional[T any](v optional.Value[T]) Value[T] {
+	return Value[T]{v: v}
+}
+
+// Reset clears the memory on the value
+func (o *Value[T]) Reset() {
+	o.v.Reset()
+}
+
+// Set updates the value and sets the set flag
+func (o *Value[T]) Set(value T) {
+	o.v.Set(value)
+}
+
+// IsSet returns true if the value is set
+func (o Value[T]) IsSet() bool {
+	return o.v.IsSet()
+}
+
+// Get returns the value and its set flag
+func (o Value[T]) Get() (T, bool) {
+	return o.v.Get()
+}
+
+// Optional returns the value as an optional.Value
+func (o Value[T]) Optional() optional.Value[T] {
+	return o.v
+}
+
+// Scan reads a database column value into the Value.
+// NULL resets the value
+func (o *Value[T]) Scan(src any) error {
+	return o.v.Scan(src)
+}
+
+// Value outputs the value as a database/sql driver value, if it is set.
+// otherwise, it returns nil (SQL NULL)
+func (o Value[T]) Value() (driver.Value, error) {
+	return o.v.Value()
+}
+
+// GormValue outputs the value as a GORM expression, if it is set.
+// otherwise, it returns NULL
+func (o Value[T]) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if !o.v.IsSet() {
+		return clause.Expr{SQL: "NULL"}
+	}
+	return clause.Expr{SQL: "?", Vars: []any{o.v}}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// GormDataType returns the GORM data type of T.
+// it returns an empty string if T has no general data type
+func (o Value[T]) GormDataType() string {
+	return string(dataTypeOf(reflect.TypeOf((*T)(nil)).Elem()))
+}
+
+func dataTypeOf(t reflect.Type) schema.DataType {
+	switch {
+	case t.ConvertibleTo(timeType):
+		return schema.Time
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return schema.Bytes
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return schema.Bool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return schema.Int
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return schema.Uint
+	case reflect.Float32, reflect.Float64:
+		return schema.Float
+	case reflect.String:
+		return schema.String
+	}
+	return ""
+}
+
+// GormDBDataType returns the database column type of T. GORM sizes numeric
+// columns by the kind of the field, which for a Value is a struct, so the
+// size is taken from T unless the field sets one itself
+func (o Value[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if field.Size != 0 {
+		return ""
+	}
+	size := sizeOf(reflect.TypeOf((*T)(nil)).Elem())
+	if size == 0 {
+		return ""
+	}
+	sized := *field
+	sized.Size = size
+	return db.Dialector.DataTypeOf(&sized)
+}
+
+func sizeOf(t reflect.Type) int {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64, reflect.Float64:
+		return 64
+	case reflect.Int8, reflect.Uint8:
+		return 8
+	case reflect.Int16, reflect.Uint16:
+		return 16
+	case reflect.Int32, reflect.Uint32, reflect.Float32:
+		return 32
+	}
+	return 0
+}
+
+// MarshalJSON outputs the value of the Value, if it is set.
+// otherwise, it returns nil
+func (o Value[T]) MarshalJSON() ([]byte, error) {
+	return o.v.MarshalJSON()
+}
+
+// UnmarshalJSON unmarshals a value out of json and safely into our struct
+func (o *Value[T]) UnmarshalJSON(data []byte) error {
+	return o.v.UnmarshalJSON(data)
+}
@@ -0,0 +1,166 @@
+package optgorm_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/heucuva/optional/optgorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+type record struct {
+	ID      uint
+	Name    optgorm.Value[string]
+	Count   optgorm.Value[int32]
+	Enabled optgorm.Value[bool]
+	Score   optgorm.Value[float64]
+	Seen    optgorm.Value[time.Time]
+	Data    optgorm.Value[[]byte]
+}
+
+func TestDataType(t *testing.T) {
+	s, err := schema.Parse(&record{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]schema.DataType{
+		"Name":    schema.String,
+		"Count":   schema.Int,
+		"Enabled": schema.Bool,
+		"Score":   schema.Float,
+		"Seen":    schema.Time,
+		"Data":    schema.Bytes,
+	}
+	for name, dataType := range expected {
+		field := s.LookUpField(name)
+		if field == nil {
+			t.Fatalf("%s: field not found", name)
+		}
+		if field.DataType != dataType {
+			t.Errorf("%s: expected data type %q, got %q", name, dataType, field.DataType)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&record{}); err != nil {
+		t.Fatal(err)
+	}
+
+	seen := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	set := record{
+		Name:    optgorm.NewValue("foo"),
+		Count:   optgorm.NewValue[int32](3),
+		Enabled: optgorm.NewValue(false),
+		Score:   optgorm.NewValue(1.5),
+		Seen:    optgorm.NewValue(seen),
+		Data:    optgorm.NewValue([]byte("bar")),
+	}
+	unset := record{}
+	if err := db.Create(&set).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&unset).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("set", func(t *testing.T) {
+		var got record
+		if err := db.First(&got, set.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+		if name, _ := got.Name.Get(); name != "foo" {
+			t.Errorf("expected name foo, got %q", name)
+		}
+		if count, _ := got.Count.Get(); count != 3 {
+			t.Errorf("expected count 3, got %d", count)
+		}
+		if enabled, set := got.Enabled.Get(); !set || enabled {
+			t.Errorf("expected enabled to be set to false, got %v (set %v)", enabled, set)
+		}
+		if score, _ := got.Score.Get(); score != 1.5 {
+			t.Errorf("expected score 1.5, got %v", score)
+		}
+		if s, _ := got.Seen.Get(); !s.Equal(seen) {
+			t.Errorf("expected seen %v, got %v", seen, s)
+		}
+		if data, _ := got.Data.Get(); string(data) != "bar" {
+			t.Errorf("expected data bar, got %q", data)
+		}
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		var nulls int64
+		if err := db.Model(&record{}).Where("id = ? AND name IS NULL AND count IS NULL AND enabled IS NULL", unset.ID).Count(&nulls).Error; err != nil {
+			t.Fatal(err)
+		}
+		if nulls != 1 {
+			t.Fatal("expected unset values to be written as NULL")
+		}
+
+		var got record
+		if err := db.First(&got, unset.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+		if got.Name.IsSet() || got.Count.IsSet() || got.Enabled.IsSet() || got.Score.IsSet() || got.Seen.IsSet() || got.Data.IsSet() {
+			t.Errorf("expected all values to be unset, got %+v", got)
+		}
+	})
+
+	t.Run("update to NULL", func(t *testing.T) {
+		if err := db.Model(&record{}).Where("id = ?", set.ID).Update("name", optgorm.Value[string]{}).Error; err != nil {
+			t.Fatal(err)
+		}
+		var got record
+		if err := db.First(&got, set.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+		if got.Name.IsSet() {
+			t.Errorf("expected name to be unset, got %v", got.Name.Optional())
+		}
+	})
+}
+
+func TestColumnTypes(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&record{}); err != nil {
+		t.Fatal(err)
+	}
+	columns, err := db.Migrator().ColumnTypes(&record{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"name":    "text",
+		"count":   "integer",
+		"enabled": "numeric",
+		"score":   "real",
+		"seen":    "datetime",
+		"data":    "blob",
+	}
+	for _, column := range columns {
+		want, ok := expected[column.Name()]
+		if !ok {
+			continue
+		}
+		if got := strings.ToLower(column.DatabaseTypeName()); got != want {
+			t.Errorf("%s: expected column type %q, got %q", column.Name(), want, got)
+		}
+		if nullable, ok := column.Nullable(); ok && !nullable {
+			t.Errorf("%s: expected column to be nullable", column.Name())
+		}
+	}
+}
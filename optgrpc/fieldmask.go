@@ -0,0 +1,95 @@
+package optgrpc
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/heucuva/optional"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FieldMask builds the field mask paths for an optional patch struct: one
+// path for every set optional field, with the Go field names converted to
+// protobuf (snake_case) field names, such as "source_context.file_name"
+func FieldMask(patch any) ([]string, error) {
+	var paths []string
+	err := optional.WalkPresence(patch, func(path string, set bool) {
+		if set {
+			paths = append(paths, protoPath(path))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// ApplyFieldMask copies the fields of src named by the paths in mask into
+// dst, leaving every other field of dst untouched. A masked field which is
+// not populated in src is cleared in dst. Paths into nested messages are
+// separated by dots; repeated and map fields may only be masked as a whole.
+func ApplyFieldMask(dst, src proto.Message, mask []string) error {
+	d := dst.ProtoReflect()
+	if name := src.ProtoReflect().Descriptor().FullName(); d.Descriptor().FullName() != name {
+		return fmt.Errorf("optgrpc: cannot apply field mask from %s to %s", name, d.Descriptor().FullName())
+	}
+	// copied so that dst shares no memory with src
+	s := proto.Clone(src).ProtoReflect()
+	for _, path := range mask {
+		if err := applyPath(d, s, path, strings.Split(path, ".")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyPath(dst, src protoreflect.Message, path string, names []string) error {
+	fd := dst.Descriptor().Fields().ByName(protoreflect.Name(names[0]))
+	if fd == nil {
+		return fmt.Errorf("optgrpc: field mask path %q: %s has no field %q", path, dst.Descriptor().FullName(), names[0])
+	}
+	if len(names) == 1 {
+		if src.Has(fd) {
+			dst.Set(fd, src.Get(fd))
+		} else {
+			dst.Clear(fd)
+		}
+		return nil
+	}
+
+	if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+		return fmt.Errorf("optgrpc: field mask path %q: field %q is not a message", path, names[0])
+	}
+	if !src.Has(fd) && !dst.Has(fd) {
+		return nil
+	}
+	return applyPath(dst.Mutable(fd).Message(), src.Get(fd).Message(), path, names[1:])
+}
+
+// protoPath converts a dotted path of Go field names to protobuf field names
+func protoPath(path string) string {
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		names[i] = snakeCase(name)
+	}
+	return strings.Join(names, ".")
+}
+
+// snakeCase converts a Go field name to its conventional protobuf field
+// name, keeping initialisms together: UserID becomes user_id
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,113 @@
+package optgrpc_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/heucuva/optional"
+	"github.com/heucuva/optional/optgrpc"
+	"google.golang.org/protobuf/types/known/apipb"
+	"google.golang.org/protobuf/types/known/sourcecontextpb"
+)
+
+type apiPatch struct {
+	Name          optional.Value[string]
+	Version       optional.Value[string]
+	SourceContext struct {
+		FileName optional.Value[string]
+	}
+}
+
+func TestFieldMask(t *testing.T) {
+	var patch apiPatch
+	patch.Version.Set("v2")
+	patch.SourceContext.FileName.Set("api.proto")
+
+	mask, err := optgrpc.FieldMask(patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"version", "source_context.file_name"}
+	if !reflect.DeepEqual(expected, mask) {
+		t.Fatalf("expected %v, got %v", expected, mask)
+	}
+
+	t.Run("initialisms", func(t *testing.T) {
+		var patch struct {
+			UserID     optional.Value[int]
+			HTTPServer optional.Value[string]
+		}
+		patch.UserID.Set(1)
+		patch.HTTPServer.Set("localhost")
+		mask, err := optgrpc.FieldMask(&patch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []string{"user_id", "http_server"}
+		if !reflect.DeepEqual(expected, mask) {
+			t.Fatalf("expected %v, got %v", expected, mask)
+		}
+	})
+}
+
+func TestApplyFieldMask(t *testing.T) {
+	newDst := func() *apipb.Api {
+		return &apipb.Api{
+			Name:          "library",
+			Version:       "v1",
+			SourceContext: &sourcecontextpb.SourceContext{FileName: "old.proto"},
+		}
+	}
+	src := &apipb.Api{
+		Name:          "ignored",
+		Version:       "v2",
+		SourceContext: &sourcecontextpb.SourceContext{FileName: "api.proto"},
+	}
+
+	t.Run("masked fields", func(t *testing.T) {
+		dst := newDst()
+		if err := optgrpc.ApplyFieldMask(dst, src, []string{"version", "source_context.file_name"}); err != nil {
+			t.Fatal(err)
+		}
+		if dst.Name != "library" {
+			t.Errorf("expected unmasked name to be kept, got %q", dst.Name)
+		}
+		if dst.Version != "v2" {
+			t.Errorf("expected version v2, got %q", dst.Version)
+		}
+		if dst.SourceContext.FileName != "api.proto" {
+			t.Errorf("expected file name api.proto, got %q", dst.SourceContext.FileName)
+		}
+		if dst.SourceContext == src.SourceContext {
+			t.Error("expected dst not to share memory with src")
+		}
+	})
+
+	t.Run("unpopulated field clears", func(t *testing.T) {
+		dst := newDst()
+		if err := optgrpc.ApplyFieldMask(dst, &apipb.Api{}, []string{"source_context"}); err != nil {
+			t.Fatal(err)
+		}
+		if dst.SourceContext != nil {
+			t.Errorf("expected source context to be cleared, got %v", dst.SourceContext)
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		if err := optgrpc.ApplyFieldMask(newDst(), src, []string{"nope"}); err == nil {
+			t.Error("expected an error for an unknown field")
+		}
+	})
+
+	t.Run("path through scalar", func(t *testing.T) {
+		if err := optgrpc.ApplyFieldMask(newDst(), src, []string{"name.x"}); err == nil {
+			t.Error("expected an error for a path through a scalar field")
+		}
+	})
+
+	t.Run("mismatched types", func(t *testing.T) {
+		if err := optgrpc.ApplyFieldMask(newDst(), &sourcecontextpb.SourceContext{}, nil); err == nil {
+			t.Error("expected an error for mismatched message types")
+		}
+	})
+}
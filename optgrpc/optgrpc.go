@@ -0,0 +1,160 @@
+// Package optgrpc provides gRPC interceptors which record which optional
+// fields of each request and response were populated.
+//
+// Interceptors see the wire messages (usually generated protobuf types), so
+// they are given a Converter which maps a message to the optional-bearing
+// struct the service works with. The presence of every optional field in the
+// converted struct is then reported to a Recorder.
+//
+// For update RPCs, FieldMask builds a protobuf field mask from the set fields
+// of an optional patch struct, and ApplyFieldMask applies only the masked
+// fields of a message to another.
+package optgrpc
+
+import (
+	"context"
+
+	"github.com/heucuva/optional"
+	"google.golang.org/grpc"
+)
+
+// Direction is the direction in which a message was traveling
+type Direction int
+
+const (
+	// Request is a message sent from the client to the server
+	Request = Direction(iota)
+	// Response is a message sent from the server to the client
+	Response
+)
+
+func (d Direction) String() string {
+	if d == Response {
+		return "response"
+	}
+	return "request"
+}
+
+// Converter maps a wire message to an optional-bearing struct.
+// it returns false for messages that should not be recorded
+type Converter func(msg any) (any, bool)
+
+// Recorder is called once per optional field of each recorded message
+type Recorder func(ctx context.Context, method string, dir Direction, path string, set bool)
+
+type recorder struct {
+	convert Converter
+	record  Recorder
+}
+
+func (r *recorder) observe(ctx context.Context, method string, dir Direction, msg any) {
+	v := msg
+	if r.convert != nil {
+		var ok bool
+		if v, ok = r.convert(msg); !ok {
+			return
+		}
+	}
+	// messages which are nil or not structs have no optional fields to record
+	_ = optional.WalkPresence(v, func(path string, set bool) {
+		r.record(ctx, method, dir, path, set)
+	})
+}
+
+// UnaryServerInterceptor records the presence of optional fields in unary
+// requests and responses handled by a server.
+// a nil convert records the messages as they are
+func UnaryServerInterceptor(convert Converter, record Recorder) grpc.UnaryServerInterceptor {
+	r := &recorder{convert: convert, record: record}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		r.observe(ctx, info.FullMethod, Request, req)
+		resp, err := handler(ctx, req)
+		if err == nil {
+			r.observe(ctx, info.FullMethod, Response, resp)
+		}
+		return resp, err
+	}
+}
+
+// UnaryClientInterceptor records the presence of optional fields in unary
+// requests and responses sent by a client.
+// a nil convert records the messages as they are
+func UnaryClientInterceptor(convert Converter, record Recorder) grpc.UnaryClientInterceptor {
+	r := &recorder{convert: convert, record: record}
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		r.observe(ctx, method, Request, req)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil {
+			r.observe(ctx, method, Response, reply)
+		}
+		return err
+	}
+}
+
+// StreamServerInterceptor records the presence of optional fields in every
+// message received and sent on a server stream.
+// a nil convert records the messages as they are
+func StreamServerInterceptor(convert Converter, record Recorder) grpc.StreamServerInterceptor {
+	r := &recorder{convert: convert, record: record}
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, r: r, method: info.FullMethod})
+	}
+}
+
+// StreamClientInterceptor records the presence of optional fields in every
+// message sent and received on a client stream.
+// a nil convert records the messages as they are
+func StreamClientInterceptor(convert Converter, record Recorder) grpc.StreamClientInterceptor {
+	r := &recorder{convert: convert, record: record}
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &clientStream{ClientStream: cs, r: r, method: method}, nil
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	r      *recorder
+	method string
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.r.observe(s.Context(), s.method, Request, m)
+	return nil
+}
+
+func (s *serverStream) SendMsg(m any) error {
+	if err := s.ServerStream.SendMsg(m); err != nil {
+		return err
+	}
+	s.r.observe(s.Context(), s.method, Response, m)
+	return nil
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	r      *recorder
+	method string
+}
+
+func (s *clientStream) SendMsg(m any) error {
+	if err := s.ClientStream.SendMsg(m); err != nil {
+		return err
+	}
+	s.r.observe(s.Context(), s.method, Request, m)
+	return nil
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	if err := s.ClientStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.r.observe(s.Context(), s.method, Response, m)
+	return nil
+}
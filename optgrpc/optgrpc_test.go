@@ -0,0 +1,146 @@
+package optgrpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/heucuva/optional"
+	"github.com/heucuva/optional/optgrpc"
+	"google.golang.org/grpc"
+)
+
+// testWireRequest stands in for a generated protobuf message
+type testWireRequest struct {
+	Name *string
+}
+
+type testRequest struct {
+	Name optional.Value[string]
+}
+
+type testResponse struct {
+	ID optional.Value[int]
+}
+
+func convert(msg any) (any, bool) {
+	switch m := msg.(type) {
+	case *testWireRequest:
+		return testRequest{Name: optional.FromPtr(m.Name)}, true
+	case *testResponse:
+		return m, true
+	}
+	return nil, false
+}
+
+type observation struct {
+	method string
+	dir    optgrpc.Direction
+	path   string
+	set    bool
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	var observed []observation
+	interceptor := optgrpc.UnaryServerInterceptor(convert, func(ctx context.Context, method string, dir optgrpc.Direction, path string, set bool) {
+		observed = append(observed, observation{method, dir, path, set})
+	})
+
+	name := "Foo"
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Get"}
+	_, err := interceptor(context.Background(), &testWireRequest{Name: &name}, info, func(ctx context.Context, req any) (any, error) {
+		return &testResponse{}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []observation{
+		{"/test.Service/Get", optgrpc.Request, "Name", true},
+		{"/test.Service/Get", optgrpc.Response, "ID", false},
+	}
+	if len(observed) != len(expected) {
+		t.Fatalf("expected %d observations, got %+v", len(expected), observed)
+	}
+	for i := range expected {
+		if observed[i] != expected[i] {
+			t.Errorf("expected observation %d to be %+v, got %+v", i, expected[i], observed[i])
+		}
+	}
+}
+
+func TestUnaryServerInterceptorNilResponse(t *testing.T) {
+	tests := map[string]optgrpc.Converter{
+		"NoConverter": nil,
+		"NilConversion": func(msg any) (any, bool) {
+			return nil, true
+		},
+	}
+	for name, convert := range tests {
+		var observed []observation
+		interceptor := optgrpc.UnaryServerInterceptor(convert, func(ctx context.Context, method string, dir optgrpc.Direction, path string, set bool) {
+			observed = append(observed, observation{method, dir, path, set})
+		})
+		info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Delete"}
+		resp, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			return nil, nil
+		})
+		if err != nil || resp != nil {
+			t.Fatalf("%s: expected a nil response, got %v (%v)", name, resp, err)
+		}
+		if len(observed) != 0 {
+			t.Errorf("%s: expected no observations, got %+v", name, observed)
+		}
+	}
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	recv []any
+}
+
+func (s *testServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *testServerStream) RecvMsg(m any) error {
+	*(m.(*testWireRequest)) = *(s.recv[0].(*testWireRequest))
+	s.recv = s.recv[1:]
+	return nil
+}
+
+func (s *testServerStream) SendMsg(m any) error {
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	var observed []observation
+	interceptor := optgrpc.StreamServerInterceptor(convert, func(ctx context.Context, method string, dir optgrpc.Direction, path string, set bool) {
+		observed = append(observed, observation{method, dir, path, set})
+	})
+
+	stream := &testServerStream{recv: []any{&testWireRequest{}}}
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Watch"}
+	err := interceptor(nil, stream, info, func(srv any, ss grpc.ServerStream) error {
+		var req testWireRequest
+		if err := ss.RecvMsg(&req); err != nil {
+			return err
+		}
+		return ss.SendMsg(&testResponse{ID: optional.NewValue(1)})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []observation{
+		{"/test.Service/Watch", optgrpc.Request, "Name", false},
+		{"/test.Service/Watch", optgrpc.Response, "ID", true},
+	}
+	if len(observed) != len(expected) {
+		t.Fatalf("expected %d observations, got %+v", len(expected), observed)
+	}
+	for i := range expected {
+		if observed[i] != expected[i] {
+			t.Errorf("expected observation %d to be %+v, got %+v", i, expected[i], observed[i])
+		}
+	}
+}
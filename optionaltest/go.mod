@@ -0,0 +1,20 @@
+module github.com/heucuva/optional/optionaltest
+
+go 1.20
+
+require (
+	github.com/google/go-cmp v0.6.0
+	github.com/heucuva/optional v0.0.0
+	github.com/stretchr/testify v1.9.0
+	go.uber.org/mock v0.4.0
+	golang.org/x/exp v0.0.0-20220713135740-79cabaa25d75
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/stretchr/objx v0.5.2 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/heucuva/optional => ../
@@ -0,0 +1,111 @@
+// Package optionaltest provides matchers and comparison options for optional
+// values in tests, and conformance checks for types held by them.
+//
+// The matchers implement gomock's Matcher interface, so they can be passed
+// as expected arguments to gomock mocks directly:
+//
+//	store.EXPECT().Save(optionaltest.HasValue("Foo"))
+//
+// and can be used with testify through mock.MatchedBy, or in assertions:
+//
+//	store.On("Save", mock.MatchedBy(optionaltest.Unset().Matches))
+//	assert.True(t, optionaltest.IsSet().Matches(user.Name))
+//
+// TestValueSemantics and TestOrderedSemantics check that Value[T] obeys the
+// equality, cloning, ordering, and marshaling laws for a downstream type T.
+package optionaltest
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/heucuva/optional"
+)
+
+// Matcher matches optional values, or pointers to them.
+// it is compatible with gomock.Matcher
+type Matcher interface {
+	// Matches returns true if x is an optional value matching the matcher
+	Matches(x any) bool
+	// String describes what the matcher matches
+	String() string
+}
+
+// inspectable is implemented by the optional containers, and pointers to them
+type inspectable interface {
+	IsSet() bool
+	AsAny() (any, bool)
+}
+
+type matcher struct {
+	desc  string
+	match func(value any, set bool) bool
+}
+
+func (m matcher) Matches(x any) bool {
+	opt, ok := x.(inspectable)
+	if !ok {
+		return false
+	}
+	if rv := reflect.ValueOf(x); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return false
+	}
+	value, set := opt.AsAny()
+	return m.match(value, set)
+}
+
+func (m matcher) String() string {
+	return m.desc
+}
+
+// IsSet matches optional values that are set, whatever their value
+func IsSet() Matcher {
+	return matcher{
+		desc:  "is set",
+		match: func(_ any, set bool) bool { return set },
+	}
+}
+
+// Unset matches optional values that are unset
+func Unset() Matcher {
+	return matcher{
+		desc:  "is unset",
+		match: func(_ any, set bool) bool { return !set },
+	}
+}
+
+// HasValue matches optional values that are set to want, as compared by
+// reflect.DeepEqual. if want is itself a Matcher, such as a gomock matcher,
+// it is applied to the value instead
+func HasValue(want any) Matcher {
+	if inner, ok := want.(Matcher); ok {
+		return matcher{
+			desc:  fmt.Sprintf("has value that %s", inner),
+			match: func(value any, set bool) bool { return set && inner.Matches(value) },
+		}
+	}
+	return matcher{
+		desc:  fmt.Sprintf("has value %v", want),
+		match: func(value any, set bool) bool { return set && reflect.DeepEqual(value, want) },
+	}
+}
+
+// presence is what EquateOptionals compares optional values as
+type presence struct {
+	Set   bool
+	Value any
+}
+
+// EquateOptionals returns a cmp.Option which compares optional values by
+// their set flags and, when set, their values, rather than failing on their
+// unexported fields. values are compared recursively, so they may hold
+// optionals themselves
+func EquateOptionals() cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		return optional.IsOptionalType(p.Last().Type())
+	}, cmp.Transformer("optional", func(x any) presence {
+		value, set := x.(inspectable).AsAny()
+		return presence{Set: set, Value: value}
+	}))
+}
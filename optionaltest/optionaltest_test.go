@@ -0,0 +1,142 @@
+package optionaltest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/heucuva/optional"
+	"github.com/heucuva/optional/optionaltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	_ gomock.Matcher = optionaltest.IsSet()
+	_ gomock.Matcher = optionaltest.HasValue(5)
+)
+
+func TestMatchers(t *testing.T) {
+	set := optional.NewValue(5)
+	var unset optional.Value[int]
+
+	tests := []struct {
+		name     string
+		matcher  optionaltest.Matcher
+		value    any
+		expected bool
+	}{
+		{"IsSet", optionaltest.IsSet(), set, true},
+		{"IsSetUnset", optionaltest.IsSet(), unset, false},
+		{"IsSetPointer", optionaltest.IsSet(), &set, true},
+		{"IsSetNilPointer", optionaltest.IsSet(), (*optional.Value[int])(nil), false},
+		{"IsSetBoxed", optionaltest.IsSet(), optional.NewBoxed("Foo"), true},
+		{"IsSetPlain", optionaltest.IsSet(), 5, false},
+		{"Unset", optionaltest.Unset(), unset, true},
+		{"UnsetSet", optionaltest.Unset(), set, false},
+		{"UnsetNil", optionaltest.Unset(), nil, false},
+		{"HasValue", optionaltest.HasValue(5), set, true},
+		{"HasValueOther", optionaltest.HasValue(6), set, false},
+		{"HasValueType", optionaltest.HasValue(int64(5)), set, false},
+		{"HasValueUnset", optionaltest.HasValue(0), unset, false},
+		{"HasValueSlice", optionaltest.HasValue([]string{"a"}), optional.NewValue([]string{"a"}), true},
+		{"HasValueMatcher", optionaltest.HasValue(gomock.Not(6)), set, true},
+		{"HasValueMatcherUnset", optionaltest.HasValue(gomock.Any()), unset, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if observed := tc.matcher.Matches(tc.value); observed != tc.expected {
+				t.Fatalf("%s: expected %v, got %v", tc.matcher, tc.expected, observed)
+			}
+		})
+	}
+
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, "has value 5", optionaltest.HasValue(5).String())
+		assert.Equal(t, "has value that is anything", optionaltest.HasValue(gomock.Any()).String())
+	})
+}
+
+type store struct {
+	mock.Mock
+}
+
+func (s *store) Save(name optional.Value[string]) {
+	s.Called(name)
+}
+
+func TestTestify(t *testing.T) {
+	s := &store{}
+	s.On("Save", mock.MatchedBy(optionaltest.HasValue("Foo").Matches)).Once()
+	s.On("Save", mock.MatchedBy(optionaltest.Unset().Matches)).Once()
+
+	s.Save(optional.NewValue("Foo"))
+	s.Save(optional.Value[string]{})
+	s.AssertExpectations(t)
+
+	assert.True(t, optionaltest.IsSet().Matches(optional.NewValue("")))
+}
+
+func TestEquateOptionals(t *testing.T) {
+	type address struct {
+		City optional.Value[string]
+	}
+	type user struct {
+		Name    optional.Value[string]
+		Age     optional.Boxed[int]
+		Address optional.Value[address]
+	}
+
+	a := user{
+		Name:    optional.NewValue("Foo"),
+		Address: optional.NewValue(address{City: optional.NewValue("Oslo")}),
+	}
+	b := a
+	if diff := cmp.Diff(a, b, optionaltest.EquateOptionals()); diff != "" {
+		t.Fatalf("expected no difference, got %s", diff)
+	}
+
+	b.Address = optional.NewValue(address{City: optional.NewValue("Bergen")})
+	b.Age = optional.NewBoxed(0)
+	diff := cmp.Diff(a, b, optionaltest.EquateOptionals())
+	if diff == "" {
+		t.Fatal("expected a difference")
+	}
+	assert.Contains(t, diff, "Oslo")
+	assert.Contains(t, diff, "Bergen")
+
+	assert.False(t, cmp.Equal(optional.Value[int]{}, optional.NewValue(0), optionaltest.EquateOptionals()))
+}
+
+// testMoney marshals to json as a string, such as "5 EUR". its currency
+// must not be empty
+type testMoney struct {
+	Amount   int
+	Currency string
+}
+
+func (m testMoney) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("%d %s", m.Amount, m.Currency))
+}
+
+func (m *testMoney) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	_, err := fmt.Sscanf(s, "%d %s", &m.Amount, &m.Currency)
+	return err
+}
+
+func TestValueSemantics(t *testing.T) {
+	optionaltest.TestValueSemantics(t, testMoney{Currency: "USD"}, testMoney{Amount: 5, Currency: "EUR"})
+	optionaltest.TestValueSemantics(t, true, false)
+}
+
+func TestOrderedSemantics(t *testing.T) {
+	optionaltest.TestOrderedSemantics(t, 0, -1, 5, 3)
+	optionaltest.TestOrderedSemantics(t, "", "Foo", "Bar")
+	optionaltest.TestOrderedSemantics(t, 0.5, -2, 1e9)
+}
@@ -0,0 +1,144 @@
+package optionaltest
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/heucuva/optional"
+	"golang.org/x/exp/constraints"
+)
+
+// TestValueSemantics checks that the laws optional values are expected to
+// obey hold for Value[T], using samples as the values to set. it is meant
+// for authors of types used as T, such as types with their own marshaling
+// or Clone methods:
+//
+//	func TestMoneySemantics(t *testing.T) {
+//		optionaltest.TestValueSemantics(t, Money{}, Money{Amount: 5, Currency: "EUR"})
+//	}
+//
+// the laws checked are:
+//   - Equal is reflexive and symmetric, and unset values equal only each other
+//   - Clone returns a value equal to the original, and leaves unset values unset
+//   - set values round-trip through json and gob, and marshal to json as T does
+//   - unset values marshal to json as null, and round-trip through gob
+func TestValueSemantics[T comparable](t *testing.T, samples ...T) {
+	t.Helper()
+
+	values := []optional.Value[T]{{}}
+	for _, sample := range samples {
+		values = append(values, optional.NewValue(sample))
+	}
+
+	t.Run("Equal", func(t *testing.T) {
+		for _, a := range values {
+			if !optional.Equal(a, a) {
+				t.Errorf("%#v: expected to equal itself", a)
+			}
+			for _, b := range values {
+				if optional.Equal(a, b) != optional.Equal(b, a) {
+					t.Errorf("%#v, %#v: expected Equal to be symmetric", a, b)
+				}
+				if a.IsSet() != b.IsSet() && optional.Equal(a, b) {
+					t.Errorf("%#v, %#v: expected set and unset values to differ", a, b)
+				}
+			}
+		}
+	})
+
+	t.Run("Clone", func(t *testing.T) {
+		for _, v := range values {
+			clone := v.Clone()
+			if !optional.Equal(v, clone) {
+				t.Errorf("%#v: expected clone to be equal, got %#v", v, clone)
+			}
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		for _, v := range values {
+			blob, err := json.Marshal(v)
+			if err != nil {
+				t.Errorf("%#v: %v", v, err)
+				continue
+			}
+			value, set := v.Get()
+			if !set {
+				if string(blob) != "null" {
+					t.Errorf("%#v: expected null, got %s", v, blob)
+				}
+				continue
+			}
+			if expected, err := json.Marshal(value); err != nil || !bytes.Equal(blob, expected) {
+				t.Errorf("%#v: expected %s, got %s", v, expected, blob)
+			}
+			var observed optional.Value[T]
+			if err := json.Unmarshal(blob, &observed); err != nil {
+				t.Errorf("%#v: unmarshaling %s: %v", v, blob, err)
+			} else if !optional.Equal(v, observed) {
+				t.Errorf("%#v: expected to round-trip through %s, got %#v", v, blob, observed)
+			}
+		}
+	})
+
+	t.Run("Gob", func(t *testing.T) {
+		for _, v := range values {
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+				t.Errorf("%#v: %v", v, err)
+				continue
+			}
+			var observed optional.Value[T]
+			if err := gob.NewDecoder(&buf).Decode(&observed); err != nil {
+				t.Errorf("%#v: decoding: %v", v, err)
+			} else if !optional.Equal(v, observed) {
+				t.Errorf("%#v: expected to round-trip, got %#v", v, observed)
+			}
+		}
+	})
+}
+
+// TestOrderedSemantics checks the laws of TestValueSemantics, as well as
+// that Compare and Less order the samples consistently: Compare is
+// antisymmetric and transitive, agrees with Equal and Less, and orders
+// unset values first. samples must not include NaN, which never equals itself
+func TestOrderedSemantics[T constraints.Ordered](t *testing.T, samples ...T) {
+	t.Helper()
+	TestValueSemantics(t, samples...)
+
+	values := []optional.Value[T]{{}}
+	for _, sample := range samples {
+		values = append(values, optional.NewValue(sample))
+	}
+
+	t.Run("Compare", func(t *testing.T) {
+		for _, a := range values {
+			if !a.IsSet() {
+				for _, b := range values {
+					if b.IsSet() && optional.Compare(a, b) >= 0 {
+						t.Errorf("%#v, %#v: expected unset to order first", a, b)
+					}
+				}
+			}
+			for _, b := range values {
+				ab := optional.Compare(a, b)
+				if ab != -optional.Compare(b, a) {
+					t.Errorf("%#v, %#v: expected Compare to be antisymmetric", a, b)
+				}
+				if (ab == 0) != optional.Equal(a, b) {
+					t.Errorf("%#v, %#v: expected Compare to agree with Equal", a, b)
+				}
+				if (ab < 0) != optional.Less(a, b) {
+					t.Errorf("%#v, %#v: expected Compare to agree with Less", a, b)
+				}
+				for _, c := range values {
+					if ab <= 0 && optional.Compare(b, c) <= 0 && optional.Compare(a, c) > 0 {
+						t.Errorf("%#v, %#v, %#v: expected Compare to be transitive", a, b, c)
+					}
+				}
+			}
+		}
+	})
+}
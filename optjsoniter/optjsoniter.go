@@ -0,0 +1,46 @@
+// Package optjsoniter registers optional values with
+// github.com/json-iterator/go.
+//
+// jsoniter already encodes and decodes optionals through their MarshalJSON
+// and UnmarshalJSON methods, but it considers a struct type empty only when
+// it has no fields, so unset optionals in fields tagged omitempty are written
+// as null. Registering the Extension makes jsoniter drop them, as
+// encoding/json does for fields tagged omitzero.
+package optjsoniter
+
+import (
+	"unsafe"
+
+	"github.com/heucuva/optional"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+)
+
+// Extension is a jsoniter.Extension (when used as a pointer) which makes jsoniter consider unset
+// optionals empty, for omitempty
+type Extension struct {
+	jsoniter.DummyExtension
+}
+
+// Register registers the Extension with api
+func Register(api jsoniter.API) {
+	api.RegisterExtension(&Extension{})
+}
+
+// DecorateEncoder wraps the encoder of optional types, overriding IsEmpty
+func (*Extension) DecorateEncoder(typ reflect2.Type, encoder jsoniter.ValEncoder) jsoniter.ValEncoder {
+	if !optional.IsOptionalType(typ.Type1()) {
+		return encoder
+	}
+	return &presenceEncoder{ValEncoder: encoder, typ: typ}
+}
+
+type presenceEncoder struct {
+	jsoniter.ValEncoder
+	typ reflect2.Type
+}
+
+func (e *presenceEncoder) IsEmpty(ptr unsafe.Pointer) bool {
+	opt, ok := e.typ.UnsafeIndirect(ptr).(interface{ IsSet() bool })
+	return ok && !opt.IsSet()
+}
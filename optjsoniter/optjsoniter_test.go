@@ -0,0 +1,83 @@
+package optjsoniter_test
+
+import (
+	"testing"
+
+	"github.com/heucuva/optional"
+	"github.com/heucuva/optional/optjsoniter"
+	jsoniter "github.com/json-iterator/go"
+)
+
+type record struct {
+	Name     optional.Value[string]     `json:"name,omitempty"`
+	Age      optional.Value[int]        `json:"age,omitempty"`
+	Nickname optional.Value[string]     `json:"nickname"`
+	Count    optional.Boxed[int]        `json:"count,omitempty"`
+	Password optional.Sensitive[string] `json:"password,omitempty"`
+	Parent   *optional.Value[int]       `json:"parent,omitempty"`
+}
+
+func newAPI() jsoniter.API {
+	api := jsoniter.Config{EscapeHTML: true, SortMapKeys: true, ValidateJsonRawMessage: true}.Froze()
+	optjsoniter.Register(api)
+	return api
+}
+
+func TestMarshal(t *testing.T) {
+	api := newAPI()
+	tests := []struct {
+		name     string
+		value    record
+		expected string
+	}{
+		{"Unset", record{}, `{"nickname":null}`},
+		{"Zero", record{
+			Name:  optional.NewValue(""),
+			Age:   optional.NewValue(0),
+			Count: optional.NewBoxed(0),
+		}, `{"name":"","age":0,"nickname":null,"count":0}`},
+		{"Set", record{
+			Name:     optional.NewValue("Foo"),
+			Nickname: optional.NewValue("foo"),
+			Password: optional.NewSensitive("hunter2"),
+			Parent:   &optional.Value[int]{},
+		}, `{"name":"Foo","nickname":"foo","password":"hunter2","parent":null}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			blob, err := api.Marshal(tc.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(blob) != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, blob)
+			}
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	var observed record
+	observed.Nickname.Set("foo")
+	if err := newAPI().Unmarshal([]byte(`{"name":"Foo","age":5,"count":3}`), &observed); err != nil {
+		t.Fatal(err)
+	}
+	if observed.Name.MustGet() != "Foo" || observed.Age.MustGet() != 5 || observed.Count.Value().MustGet() != 3 {
+		t.Fatalf("unexpected %+v", observed)
+	}
+	if observed.Nickname.MustGet() != "foo" || observed.Password.IsSet() {
+		t.Fatalf("unexpected %+v", observed)
+	}
+}
+
+func TestUnregistered(t *testing.T) {
+	// without the extension, jsoniter never considers an optional empty
+	blob, err := jsoniter.ConfigCompatibleWithStandardLibrary.Marshal(record{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expected = `{"name":null,"age":null,"nickname":null,"count":null,"password":null}`
+	if string(blob) != expected {
+		t.Fatalf("expected %s, got %s", expected, blob)
+	}
+}
@@ -0,0 +1,186 @@
+// Package optjsonschema reflects JSON Schemas for types holding optional
+// values with github.com/invopop/jsonschema.
+//
+// Without it, an optional field is reflected as an opaque object of its
+// unexported fields. With it, an optional field is reflected as its element
+// type or null, and is never listed as required.
+package optjsonschema
+
+import (
+	"reflect"
+
+	"github.com/heucuva/optional"
+	"github.com/invopop/jsonschema"
+)
+
+// Reflect reflects the schema of v using r, with optional fields reflected
+// as their element type or null, and not required
+func Reflect(r *jsonschema.Reflector, v any) *jsonschema.Schema {
+	return ReflectFromType(r, reflect.TypeOf(v))
+}
+
+// ReflectFromType reflects the schema of t using r, with optional fields
+// reflected as their element type or null, and not required.
+//
+// The schema of an optional's element type is inlined rather than
+// referenced from the definitions, unless the type is recursive: a struct
+// met again within its own inlined schema references a definition of it,
+// which is added to the root schema. r is not modified, and a Mapper set on
+// it takes precedence over the optional mapping.
+func ReflectFromType(r *jsonschema.Reflector, t reflect.Type) *jsonschema.Schema {
+	m := &mapper{
+		next:      r.Mapper,
+		optional:  make(map[*jsonschema.Schema]bool),
+		inlining:  make(map[reflect.Type]bool),
+		recursive: make(map[reflect.Type]bool),
+	}
+	m.r = *r
+	m.r.Mapper = m.mapType
+
+	s := m.r.ReflectFromType(t)
+	m.define(s)
+	walk(s, func(s *jsonschema.Schema) {
+		if s.Properties == nil || len(s.Required) == 0 {
+			return
+		}
+		required := s.Required[:0]
+		for _, name := range s.Required {
+			if prop, ok := s.Properties.Get(name); !ok || !m.optional[prop] {
+				required = append(required, name)
+			}
+		}
+		s.Required = required
+	})
+	return s
+}
+
+type mapper struct {
+	r        jsonschema.Reflector
+	next     func(reflect.Type) *jsonschema.Schema
+	optional map[*jsonschema.Schema]bool
+	// depth counts the schemas being inlined
+	depth int
+	// inlining holds the structs being inlined, to detect recursion
+	inlining map[reflect.Type]bool
+	// entering is the struct about to be inlined, which is left to the
+	// reflector when it is mapped
+	entering reflect.Type
+	// recursive holds the structs which are referenced, and so need a
+	// definition
+	recursive map[reflect.Type]bool
+}
+
+func (m *mapper) mapType(t reflect.Type) *jsonschema.Schema {
+	if m.next != nil {
+		if s := m.next(t); s != nil {
+			return s
+		}
+	}
+	elem := optional.ElemType(t)
+	if elem == nil {
+		return m.mapStruct(t)
+	}
+
+	s := &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{m.inline(elem), {Type: "null"}},
+	}
+	m.optional[s] = true
+	return s
+}
+
+// mapStruct maps the struct t met while inlining a schema, referencing it if
+// it is already being inlined, and inlining it otherwise
+func (m *mapper) mapStruct(t reflect.Type) *jsonschema.Schema {
+	if m.depth == 0 || t.Kind() != reflect.Struct {
+		return nil
+	}
+	if t == m.entering {
+		m.entering = nil
+		return nil
+	}
+	if name := m.typeName(t); m.inlining[t] && name != "" {
+		m.recursive[t] = true
+		return &jsonschema.Schema{Ref: "#/$defs/" + name}
+	}
+	return m.inline(t)
+}
+
+// inline reflects the schema of t with no references
+func (m *mapper) inline(t reflect.Type) *jsonschema.Schema {
+	m.depth++
+	defer func() { m.depth-- }()
+	if t.Kind() == reflect.Struct {
+		m.inlining[t] = true
+		m.entering = t
+		defer delete(m.inlining, t)
+	}
+
+	sub := m.r
+	sub.DoNotReference = true
+	sub.Anonymous = true
+	sub.ExpandedStruct = false
+	s := sub.ReflectFromType(t)
+	s.Version = ""
+	s.Definitions = nil
+	return s
+}
+
+// define adds a definition of every recursive struct to the root schema s,
+// unless it already has one
+func (m *mapper) define(s *jsonschema.Schema) {
+	defined := make(map[reflect.Type]bool)
+	for len(defined) < len(m.recursive) {
+		for t := range m.recursive {
+			if defined[t] {
+				continue
+			}
+			defined[t] = true
+			name := m.typeName(t)
+			if _, ok := s.Definitions[name]; ok {
+				continue
+			}
+			// inlining the definition may find more recursive structs
+			def := m.inline(t)
+			if s.Definitions == nil {
+				s.Definitions = make(jsonschema.Definitions)
+			}
+			s.Definitions[name] = def
+		}
+	}
+}
+
+// typeName returns the name the reflector gives t in the definitions
+func (m *mapper) typeName(t reflect.Type) string {
+	if m.r.Namer != nil {
+		if name := m.r.Namer(t); name != "" {
+			return name
+		}
+	}
+	return t.Name()
+}
+
+// walk calls fn for s and every schema nested within it
+func walk(s *jsonschema.Schema, fn func(*jsonschema.Schema)) {
+	if s == nil {
+		return
+	}
+	fn(s)
+	for _, def := range s.Definitions {
+		walk(def, fn)
+	}
+	if s.Properties != nil {
+		for pair := s.Properties.Oldest(); pair != nil; pair = pair.Next() {
+			walk(pair.Value, fn)
+		}
+	}
+	for _, p := range s.PatternProperties {
+		walk(p, fn)
+	}
+	walk(s.AdditionalProperties, fn)
+	walk(s.Items, fn)
+	for _, list := range [][]*jsonschema.Schema{s.PrefixItems, s.AllOf, s.AnyOf, s.OneOf} {
+		for _, sub := range list {
+			walk(sub, fn)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package optjsonschema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/heucuva/optional"
+	"github.com/heucuva/optional/optjsonschema"
+	"github.com/invopop/jsonschema"
+)
+
+type address struct {
+	City optional.Value[string] `json:"city"`
+	Zip  string                 `json:"zip"`
+}
+
+type user struct {
+	ID      int                     `json:"id"`
+	Name    optional.Value[string]  `json:"name"`
+	Tags    optional.Value[[]int]   `json:"tags"`
+	Address optional.Value[address] `json:"address"`
+	Home    address                 `json:"home"`
+}
+
+func TestReflect(t *testing.T) {
+	r := &jsonschema.Reflector{Anonymous: true, ExpandedStruct: true}
+	s := optjsonschema.Reflect(r, user{})
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type":    "object",
+		"properties": map[string]any{
+			"id": map[string]any{"type": "integer"},
+			"name": map[string]any{"oneOf": []any{
+				map[string]any{"type": "string"},
+				map[string]any{"type": "null"},
+			}},
+			"tags": map[string]any{"oneOf": []any{
+				map[string]any{"type": "array", "items": map[string]any{"type": "integer"}},
+				map[string]any{"type": "null"},
+			}},
+			"address": map[string]any{"oneOf": []any{
+				map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"city": map[string]any{"oneOf": []any{
+							map[string]any{"type": "string"},
+							map[string]any{"type": "null"},
+						}},
+						"zip": map[string]any{"type": "string"},
+					},
+					"additionalProperties": false,
+					"required":             []any{"zip"},
+				},
+				map[string]any{"type": "null"},
+			}},
+			"home": map[string]any{"$ref": "#/$defs/address"},
+		},
+		"additionalProperties": false,
+		"required":             []any{"id", "home"},
+		"$defs": map[string]any{
+			"address": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"city": map[string]any{"oneOf": []any{
+						map[string]any{"type": "string"},
+						map[string]any{"type": "null"},
+					}},
+					"zip": map[string]any{"type": "string"},
+				},
+				"additionalProperties": false,
+				"required":             []any{"zip"},
+			},
+		},
+	}
+
+	want, _ := json.Marshal(expected)
+	gotJSON, _ := json.Marshal(got)
+	if string(want) != string(gotJSON) {
+		t.Fatalf("expected\n%s\ngot\n%s", want, gotJSON)
+	}
+
+	if r.Mapper != nil {
+		t.Error("expected the reflector not to be modified")
+	}
+}
+
+func TestReflectMapperPrecedence(t *testing.T) {
+	r := &jsonschema.Reflector{
+		Anonymous:      true,
+		ExpandedStruct: true,
+		Mapper: func(t reflect.Type) *jsonschema.Schema {
+			if t == reflect.TypeOf(optional.Value[string]{}) {
+				return &jsonschema.Schema{Type: "string", Format: "custom"}
+			}
+			return nil
+		},
+	}
+	s := optjsonschema.Reflect(r, address{})
+	prop, _ := s.Properties.Get("city")
+	if prop.Format != "custom" {
+		t.Fatalf("expected the reflector's mapper to be used, got %+v", prop)
+	}
+	if len(s.Required) != 2 {
+		t.Fatalf("expected a custom mapped field to stay required, got %v", s.Required)
+	}
+}
+
+type node struct {
+	Name     string                 `json:"name"`
+	Next     optional.Value[*node]  `json:"next"`
+	Children optional.Value[[]node] `json:"children"`
+}
+
+type tree struct {
+	Root optional.Value[node] `json:"root"`
+}
+
+func TestReflectRecursive(t *testing.T) {
+	for name, v := range map[string]any{"Root": node{}, "Nested": tree{}} {
+		t.Run(name, func(t *testing.T) {
+			s := optjsonschema.Reflect(&jsonschema.Reflector{}, v)
+			data, err := json.Marshal(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got map[string]any
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatal(err)
+			}
+			defs, _ := got["$defs"].(map[string]any)
+			if _, ok := defs["node"]; !ok {
+				t.Fatalf("expected a definition of node, got %s", data)
+			}
+			// every reference must resolve
+			var check func(v any)
+			check = func(v any) {
+				switch v := v.(type) {
+				case map[string]any:
+					if ref, ok := v["$ref"].(string); ok {
+						if _, ok := defs[strings.TrimPrefix(ref, "#/$defs/")]; !ok {
+							t.Errorf("unresolved reference %s", ref)
+						}
+					}
+					for _, sub := range v {
+						check(sub)
+					}
+				case []any:
+					for _, sub := range v {
+						check(sub)
+					}
+				}
+			}
+			check(got)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Package optmsgpack adds github.com/vmihailenco/msgpack/v5 support to
+// optional values, encoding unset values as nil.
+//
+// Go cannot attach the msgpack interfaces to every instantiation of a
+// generic type from outside its package, so each element type used in
+// msgpack payloads must be registered once, typically from an init function:
+//
+//	func init() {
+//		optmsgpack.Register[string]()
+//		optmsgpack.Register[int64]()
+//	}
+//
+// Without registration, msgpack falls back to the text encoding of the value,
+// which cannot tell an unset value from an empty one.
+package optmsgpack
+
+import (
+	"reflect"
+
+	"github.com/heucuva/optional"
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+// Register registers msgpack encoding and decoding of Value[T]
+func Register[T any]() {
+	msgpack.Register(optional.Value[T]{}, encodeValue[T], decodeValue[T])
+}
+
+func encodeValue[T any](enc *msgpack.Encoder, v reflect.Value) error {
+	value, set := v.Interface().(optional.Value[T]).Get()
+	if !set {
+		return enc.EncodeNil()
+	}
+	return enc.Encode(&value)
+}
+
+func decodeValue[T any](dec *msgpack.Decoder, v reflect.Value) error {
+	target := v.Addr().Interface().(*optional.Value[T])
+
+	code, err := dec.PeekCode()
+	if err != nil {
+		return err
+	}
+	if code == msgpcode.Nil {
+		target.Reset()
+		if err := dec.DecodeNil(); err != nil {
+			return err
+		}
+		optional.NotifyDecode[T](true)
+		return nil
+	}
+
+	var value T
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+	target.Set(value)
+	optional.NotifyDecode[T](false)
+	return nil
+}
@@ -0,0 +1,126 @@
+package optmsgpack_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/heucuva/optional"
+	"github.com/heucuva/optional/optmsgpack"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type testInner struct {
+	Value int
+}
+
+type testPayload struct {
+	Name   optional.Value[string]
+	Count  optional.Value[int]
+	Ratio  optional.Value[float64]
+	Tags   optional.Value[[]string]
+	Limits optional.Value[map[string]int]
+	Inner  optional.Value[testInner]
+	Data   optional.Value[[]byte]
+}
+
+func init() {
+	optmsgpack.Register[string]()
+	optmsgpack.Register[int]()
+	optmsgpack.Register[float64]()
+	optmsgpack.Register[[]string]()
+	optmsgpack.Register[map[string]int]()
+	optmsgpack.Register[testInner]()
+	optmsgpack.Register[[]byte]()
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload testPayload
+	}{
+		{"Unset", testPayload{}},
+		{"Zero", testPayload{
+			Name:   optional.NewValue(""),
+			Count:  optional.NewValue(0),
+			Ratio:  optional.NewValue(0.0),
+			Tags:   optional.NewValue([]string{}),
+			Limits: optional.NewValue(map[string]int{}),
+			Inner:  optional.NewValue(testInner{}),
+			Data:   optional.NewValue([]byte{}),
+		}},
+		{"Set", testPayload{
+			Name:   optional.NewValue("Foo"),
+			Count:  optional.NewValue(-5),
+			Ratio:  optional.NewValue(3.14159),
+			Tags:   optional.NewValue([]string{"a", "b"}),
+			Limits: optional.NewValue(map[string]int{"cpu": 2}),
+			Inner:  optional.NewValue(testInner{Value: 7}),
+			Data:   optional.NewValue([]byte{1, 2, 3}),
+		}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			blob, err := msgpack.Marshal(tc.payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var observed testPayload
+			if err := msgpack.Unmarshal(blob, &observed); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(observed, tc.payload) {
+				t.Fatalf("expected %#v, got %#v", tc.payload, observed)
+			}
+		})
+	}
+}
+
+func TestEncodeUnsetAsNil(t *testing.T) {
+	blob, err := msgpack.Marshal(optional.Value[string]{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blob) != 1 || blob[0] != 0xc0 {
+		t.Fatalf("expected msgpack nil, got %x", blob)
+	}
+}
+
+func TestDecodeNilResets(t *testing.T) {
+	target := optional.NewValue("Foo")
+	if err := msgpack.Unmarshal([]byte{0xc0}, &target); err != nil {
+		t.Fatal(err)
+	}
+	if target.IsSet() {
+		t.Fatal("expected value to be unset")
+	}
+}
+
+func TestOnDecode(t *testing.T) {
+	type decodeEvent struct {
+		typeName string
+		wasNull  bool
+	}
+	var events []decodeEvent
+	optional.OnDecode(func(typeName string, wasNull bool) {
+		events = append(events, decodeEvent{typeName: typeName, wasNull: wasNull})
+	})
+	defer optional.OnDecode(nil)
+
+	blob, err := msgpack.Marshal(testPayload{Name: optional.NewValue("Foo")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var observed testPayload
+	if err := msgpack.Unmarshal(blob, &observed); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 7 {
+		t.Fatalf("expected 7 events, got %d", len(events))
+	}
+	if events[0] != (decodeEvent{typeName: "string", wasNull: false}) {
+		t.Errorf("expected a set string, got %+v", events[0])
+	}
+	if events[1] != (decodeEvent{typeName: "int", wasNull: true}) {
+		t.Errorf("expected a null int, got %+v", events[1])
+	}
+}
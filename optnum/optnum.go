@@ -0,0 +1,105 @@
+// Package optnum provides helpers for optional numeric values.
+package optnum
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/heucuva/optional"
+	"golang.org/x/exp/constraints"
+)
+
+// Number is the set of numeric types supported by this package
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// Clamp limits a set value to the range [lo, hi].
+// an unset value is passed through unchanged
+func Clamp[T Number](v optional.Value[T], lo, hi T) optional.Value[T] {
+	value, set := v.Get()
+	if !set {
+		return v
+	}
+	if value < lo {
+		value = lo
+	} else if value > hi {
+		value = hi
+	}
+	return optional.NewValue(value)
+}
+
+// InRange returns true if a set value lies within the range [lo, hi].
+// an unset value is always considered to be in range
+func InRange[T Number](v optional.Value[T], lo, hi T) bool {
+	value, set := v.Get()
+	return !set || (value >= lo && value <= hi)
+}
+
+// ErrNotRepresentable is returned by Convert when a value cannot be
+// represented exactly in the target type
+var ErrNotRepresentable = errors.New("optnum: value not representable")
+
+// Convert converts a set value to another numeric type, failing if the
+// value overflows the target type or would lose precision in it.
+// an unset value converts to an unset value
+func Convert[To, From Number](v optional.Value[From]) (optional.Value[To], error) {
+	value, set := v.Get()
+	if !set {
+		return optional.Value[To]{}, nil
+	}
+
+	converted := To(value)
+	if isNaN(value) && isNaN(converted) {
+		return optional.NewValue(converted), nil
+	}
+	if From(converted) != value || (value < 0) != (converted < 0) {
+		return optional.Value[To]{}, fmt.Errorf("%w: %v as %T", ErrNotRepresentable, value, converted)
+	}
+	return optional.NewValue(converted), nil
+}
+
+func isNaN[T Number](value T) bool {
+	return value != value
+}
+
+// Add returns a + b, if both are set. otherwise, it returns an unset value
+func Add[T Number](a, b optional.Value[T]) optional.Value[T] {
+	return optional.Apply(a, b, func(x, y T) T { return x + y })
+}
+
+// Sub returns a - b, if both are set. otherwise, it returns an unset value
+func Sub[T Number](a, b optional.Value[T]) optional.Value[T] {
+	return optional.Apply(a, b, func(x, y T) T { return x - y })
+}
+
+// Mul returns a * b, if both are set. otherwise, it returns an unset value
+func Mul[T Number](a, b optional.Value[T]) optional.Value[T] {
+	return optional.Apply(a, b, func(x, y T) T { return x * y })
+}
+
+// Div returns a / b, if both are set and b is not zero.
+// otherwise, it returns an unset value
+func Div[T Number](a, b optional.Value[T]) optional.Value[T] {
+	if value, set := b.Get(); set && value == 0 {
+		return optional.Value[T]{}
+	}
+	return optional.Apply(a, b, func(x, y T) T { return x / y })
+}
+
+// Sum returns the sum of the set values, skipping unset ones as SQL's SUM
+// skips NULLs. if no value is set, it returns an unset value
+func Sum[T Number](values []optional.Value[T]) optional.Value[T] {
+	var sum optional.Value[T]
+	for _, v := range values {
+		value, set := v.Get()
+		if !set {
+			continue
+		}
+		if current, ok := sum.Get(); ok {
+			value += current
+		}
+		sum.Set(value)
+	}
+	return sum
+}
@@ -0,0 +1,180 @@
+package optnum_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/heucuva/optional"
+	"github.com/heucuva/optional/optnum"
+)
+
+func TestClamp(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    optional.Value[int]
+		expected optional.Value[int]
+	}{
+		{"Unset", optional.Value[int]{}, optional.Value[int]{}},
+		{"Below", optional.NewValue(-5), optional.NewValue(0)},
+		{"Within", optional.NewValue(5), optional.NewValue(5)},
+		{"Above", optional.NewValue(15), optional.NewValue(10)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			observed := optnum.Clamp(tc.value, 0, 10)
+			if observed != tc.expected {
+				t.Fatalf("expected %+v, got %+v", tc.expected, observed)
+			}
+		})
+	}
+	t.Run("Float", func(t *testing.T) {
+		observed, _ := optnum.Clamp(optional.NewValue(1.5), 0, 1).Get()
+		if observed != 1 {
+			t.Fatalf("expected 1, got %v", observed)
+		}
+	})
+}
+
+func TestInRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    optional.Value[uint8]
+		expected bool
+	}{
+		{"Unset", optional.Value[uint8]{}, true},
+		{"Below", optional.NewValue[uint8](1), false},
+		{"Low", optional.NewValue[uint8](2), true},
+		{"High", optional.NewValue[uint8](4), true},
+		{"Above", optional.NewValue[uint8](5), false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if observed := optnum.InRange(tc.value, 2, 4); observed != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, observed)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	t.Run("Unset", func(t *testing.T) {
+		observed, err := optnum.Convert[int8](optional.Value[int64]{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if observed.IsSet() {
+			t.Fatal("expected unset value")
+		}
+	})
+	t.Run("Widen", func(t *testing.T) {
+		observed, err := optnum.Convert[int64](optional.NewValue[int8](-5))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value := observed.MustGet(); value != -5 {
+			t.Fatalf("expected -5, got %v", value)
+		}
+	})
+	t.Run("Narrow", func(t *testing.T) {
+		observed, err := optnum.Convert[uint8](optional.NewValue(200))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value := observed.MustGet(); value != 200 {
+			t.Fatalf("expected 200, got %v", value)
+		}
+	})
+	t.Run("NaN", func(t *testing.T) {
+		observed, err := optnum.Convert[float32](optional.NewValue(math.NaN()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value := observed.MustGet(); !math.IsNaN(float64(value)) {
+			t.Fatalf("expected NaN, got %v", value)
+		}
+	})
+
+	failures := []struct {
+		name    string
+		convert func() error
+	}{
+		{"Overflow", func() error {
+			_, err := optnum.Convert[int8](optional.NewValue(300))
+			return err
+		}},
+		{"Negative", func() error {
+			_, err := optnum.Convert[uint64](optional.NewValue(-1))
+			return err
+		}},
+		{"Fraction", func() error {
+			_, err := optnum.Convert[int](optional.NewValue(1.5))
+			return err
+		}},
+		{"FloatOverflow", func() error {
+			_, err := optnum.Convert[float32](optional.NewValue(math.MaxFloat64))
+			return err
+		}},
+	}
+	for _, tc := range failures {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.convert(); !errors.Is(err, optnum.ErrNotRepresentable) {
+				t.Fatalf("expected ErrNotRepresentable, got %v", err)
+			}
+		})
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	var unset optional.Value[int]
+	six, two, zero := optional.NewValue(6), optional.NewValue(2), optional.NewValue(0)
+
+	tests := []struct {
+		name     string
+		observed optional.Value[int]
+		expected optional.Value[int]
+	}{
+		{"Add", optnum.Add(six, two), optional.NewValue(8)},
+		{"Sub", optnum.Sub(six, two), optional.NewValue(4)},
+		{"Mul", optnum.Mul(six, two), optional.NewValue(12)},
+		{"Div", optnum.Div(six, two), optional.NewValue(3)},
+		{"DivByZero", optnum.Div(six, zero), unset},
+		{"UnsetLeft", optnum.Add(unset, two), unset},
+		{"UnsetRight", optnum.Mul(six, unset), unset},
+		{"DivUnset", optnum.Div(unset, zero), unset},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.observed != tc.expected {
+				t.Fatalf("expected %+v, got %+v", tc.expected, tc.observed)
+			}
+		})
+	}
+
+	t.Run("Float", func(t *testing.T) {
+		observed := optnum.Div(optional.NewValue(1.0), optional.NewValue(4.0))
+		if observed != optional.NewValue(0.25) {
+			t.Fatalf("expected 0.25, got %+v", observed)
+		}
+	})
+}
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []optional.Value[float64]
+		expected optional.Value[float64]
+	}{
+		{"Nil", nil, optional.Value[float64]{}},
+		{"AllUnset", []optional.Value[float64]{{}, {}}, optional.Value[float64]{}},
+		{"SkipsUnset", []optional.Value[float64]{optional.NewValue(1.5), {}, optional.NewValue(2.0)}, optional.NewValue(3.5)},
+		{"Zero", []optional.Value[float64]{{}, optional.NewValue(0.0)}, optional.NewValue(0.0)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if observed := optnum.Sum(tc.values); observed != tc.expected {
+				t.Fatalf("expected %+v, got %+v", tc.expected, observed)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/heucuva/optional/optpgx
+
+go 1.20
+
+require (
+	github.com/heucuva/optional v0.0.0
+	github.com/jackc/pgx/v5 v5.5.5
+)
+
+require (
+	golang.org/x/exp v0.0.0-20220713135740-79cabaa25d75 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/heucuva/optional => ../
@@ -0,0 +1,153 @@
+// Package optpgx teaches pgx v5 to bind optional values as nullable
+// PostgreSQL parameters and to scan nullable columns into them.
+//
+// optional.Value implements driver.Valuer and sql.Scanner, which pgx falls
+// back to, but those only understand the database/sql driver types. Once
+// registered on a type map, optionals are unwrapped and handed to pgx's own
+// codecs instead, so any T pgx supports works, including arrays and composite
+// types stored as json or jsonb. Unset values are sent as NULL, and NULL is
+// scanned as an unset value.
+//
+// Register the package on each connection, e.g. in pgxpool's AfterConnect:
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		optpgx.Register(conn.TypeMap())
+//		return nil
+//	}
+package optpgx
+
+import (
+	"reflect"
+
+	"github.com/heucuva/optional"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// firstUserOID is the first OID PostgreSQL assigns to user-defined objects.
+// every built-in type has a lower OID
+const firstUserOID = 16384
+
+// Register makes every built-in type of m accept optional values, and adds an
+// encode plan for optionals sent as parameters of unknown type. Custom types
+// registered on m afterwards need to be registered with RegisterType instead.
+func Register(m *pgtype.Map) {
+	for oid := uint32(1); oid < firstUserOID; oid++ {
+		if t, ok := m.TypeForOID(oid); ok {
+			RegisterType(m, t)
+		}
+	}
+	m.TryWrapEncodePlanFuncs = append([]pgtype.TryWrapEncodePlanFunc{TryWrapEncodePlan}, m.TryWrapEncodePlanFuncs...)
+}
+
+// RegisterType registers t on m, wrapping its codec so it accepts optional
+// values. it can be used in place of m.RegisterType for custom types
+func RegisterType(m *pgtype.Map, t *pgtype.Type) {
+	c := t.Codec
+	if _, ok := c.(*codec); !ok {
+		c = &codec{Codec: c}
+	}
+	m.RegisterType(&pgtype.Type{Name: t.Name, OID: t.OID, Codec: c})
+}
+
+// TryWrapEncodePlan is a pgtype.TryWrapEncodePlanFunc which unwraps optional
+// values, encoding unset values as NULL
+func TryWrapEncodePlan(value any) (plan pgtype.WrappedEncodePlanNextSetter, nextValue any, ok bool) {
+	elem := elemType(reflect.TypeOf(value))
+	if elem == nil {
+		return nil, nil, false
+	}
+	return &encodePlan{}, reflect.Zero(elem).Interface(), true
+}
+
+// elemType returns the element type of the optional type t.
+// it returns nil if t is not an optional type, or if its element type is an
+// interface, which pgx cannot plan for
+func elemType(t reflect.Type) reflect.Type {
+	if t == nil {
+		return nil
+	}
+	elem := optional.ElemType(t)
+	if elem == nil || elem.Kind() == reflect.Interface {
+		return nil
+	}
+	return elem
+}
+
+type anyValuer interface {
+	AsAny() (any, bool)
+}
+
+type encodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (p *encodePlan) SetNext(next pgtype.EncodePlan) {
+	p.next = next
+}
+
+func (p *encodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	v, set := value.(anyValuer).AsAny()
+	if !set {
+		return nil, nil
+	}
+	return p.next.Encode(v, buf)
+}
+
+type scanPlan struct {
+	elem reflect.Type
+	next pgtype.ScanPlan
+}
+
+func (p *scanPlan) Scan(src []byte, dst any) error {
+	rv := reflect.ValueOf(dst).Elem()
+	if src == nil {
+		rv.Set(reflect.Zero(rv.Type()))
+		return nil
+	}
+
+	val := reflect.New(p.elem)
+	if err := p.next.Scan(src, val.Interface()); err != nil {
+		return err
+	}
+	o, err := optional.FromAny(val.Elem().Interface(), rv.Type())
+	if err != nil {
+		return err
+	}
+	rv.Set(reflect.ValueOf(o))
+	return nil
+}
+
+// codec wraps a pgx codec, planning optional values and targets by
+// unwrapping them to their element type. everything else is left to the
+// wrapped codec
+type codec struct {
+	pgtype.Codec
+}
+
+func (c *codec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	elem := elemType(reflect.TypeOf(value))
+	if elem == nil {
+		return c.Codec.PlanEncode(m, oid, format, value)
+	}
+	next := m.PlanEncode(oid, format, reflect.Zero(elem).Interface())
+	if next == nil {
+		return nil
+	}
+	return &encodePlan{next: next}
+}
+
+func (c *codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	t := reflect.TypeOf(target)
+	if t == nil || t.Kind() != reflect.Pointer {
+		return c.Codec.PlanScan(m, oid, format, target)
+	}
+	elem := elemType(t.Elem())
+	if elem == nil {
+		return c.Codec.PlanScan(m, oid, format, target)
+	}
+	next := m.PlanScan(oid, format, reflect.New(elem).Interface())
+	if next == nil {
+		return nil
+	}
+	return &scanPlan{elem: elem, next: next}
+}
@@ -0,0 +1,143 @@
+package optpgx_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/heucuva/optional"
+	"github.com/heucuva/optional/optpgx"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func newMap() *pgtype.Map {
+	m := pgtype.NewMap()
+	optpgx.Register(m)
+	return m
+}
+
+// roundTrip encodes src as oid in format, then scans the result into dst
+func roundTrip(t *testing.T, m *pgtype.Map, oid uint32, format int16, src, dst any) []byte {
+	t.Helper()
+	buf, err := m.Encode(oid, format, src, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Scan(oid, format, buf, dst); err != nil {
+		t.Fatal(err)
+	}
+	return buf
+}
+
+type document struct {
+	Name string `json:"name"`
+	Tags []string
+}
+
+func TestRoundTrip(t *testing.T) {
+	m := newMap()
+	formats := map[string]int16{
+		"binary": pgtype.BinaryFormatCode,
+		"text":   pgtype.TextFormatCode,
+	}
+
+	for name, format := range formats {
+		t.Run(name, func(t *testing.T) {
+			t.Run("int4", func(t *testing.T) {
+				var dst optional.Value[int32]
+				roundTrip(t, m, pgtype.Int4OID, format, optional.NewValue[int32](42), &dst)
+				if v, set := dst.Get(); !set || v != 42 {
+					t.Fatalf("expected 42, got %v", dst)
+				}
+			})
+			t.Run("text", func(t *testing.T) {
+				var dst optional.Value[string]
+				roundTrip(t, m, pgtype.TextOID, format, optional.NewValue("foo"), &dst)
+				if v, set := dst.Get(); !set || v != "foo" {
+					t.Fatalf("expected foo, got %v", dst)
+				}
+			})
+			t.Run("timestamptz", func(t *testing.T) {
+				when := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+				var dst optional.Value[time.Time]
+				roundTrip(t, m, pgtype.TimestamptzOID, format, optional.NewValue(when), &dst)
+				if v, set := dst.Get(); !set || !v.Equal(when) {
+					t.Fatalf("expected %v, got %v", when, dst)
+				}
+			})
+			t.Run("int4[]", func(t *testing.T) {
+				var dst optional.Value[[]int32]
+				roundTrip(t, m, pgtype.Int4ArrayOID, format, optional.NewValue([]int32{1, 2, 3}), &dst)
+				if v, set := dst.Get(); !set || !reflect.DeepEqual(v, []int32{1, 2, 3}) {
+					t.Fatalf("expected [1 2 3], got %v", dst)
+				}
+			})
+			t.Run("jsonb", func(t *testing.T) {
+				doc := document{Name: "foo", Tags: []string{"a", "b"}}
+				var dst optional.Value[document]
+				roundTrip(t, m, pgtype.JSONBOID, format, optional.NewValue(doc), &dst)
+				if v, set := dst.Get(); !set || !reflect.DeepEqual(v, doc) {
+					t.Fatalf("expected %v, got %v", doc, dst)
+				}
+			})
+			t.Run("boxed", func(t *testing.T) {
+				var dst optional.Boxed[int64]
+				roundTrip(t, m, pgtype.Int8OID, format, optional.NewBoxed[int64](7), &dst)
+				if v, set := dst.Get(); !set || v != 7 {
+					t.Fatalf("expected 7, got %v", dst.Value())
+				}
+			})
+		})
+	}
+}
+
+func TestNull(t *testing.T) {
+	m := newMap()
+	values := map[string]struct {
+		oid   uint32
+		value any
+	}{
+		"int4":   {pgtype.Int4OID, optional.Value[int32]{}},
+		"int4[]": {pgtype.Int4ArrayOID, optional.Value[[]int32]{}},
+		"jsonb":  {pgtype.JSONBOID, optional.Value[document]{}},
+	}
+	for name, v := range values {
+		t.Run(name, func(t *testing.T) {
+			buf, err := m.Encode(v.oid, pgtype.BinaryFormatCode, v.value, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if buf != nil {
+				t.Fatalf("expected NULL, got %q", buf)
+			}
+		})
+	}
+
+	t.Run("scan", func(t *testing.T) {
+		dst := optional.NewValue[int32](1)
+		if err := m.Scan(pgtype.Int4OID, pgtype.BinaryFormatCode, nil, &dst); err != nil {
+			t.Fatal(err)
+		}
+		if dst.IsSet() {
+			t.Fatalf("expected NULL to scan as unset, got %v", dst)
+		}
+	})
+}
+
+func TestUnknownOID(t *testing.T) {
+	m := newMap()
+	buf, err := m.Encode(0, pgtype.TextFormatCode, optional.NewValue([]int32{1, 2}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != "{1,2}" {
+		t.Fatalf("expected {1,2}, got %q", buf)
+	}
+	buf, err = m.Encode(0, pgtype.TextFormatCode, optional.Value[int32]{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf != nil {
+		t.Fatalf("expected NULL, got %q", buf)
+	}
+}
@@ -0,0 +1,17 @@
+module github.com/heucuva/optional/optsamber
+
+go 1.18
+
+require (
+	github.com/heucuva/optional v0.0.0
+	github.com/samber/lo v1.53.0
+	github.com/samber/mo v1.17.0
+)
+
+require (
+	golang.org/x/exp v0.0.0-20220713135740-79cabaa25d75 // indirect
+	golang.org/x/text v0.22.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/heucuva/optional => ../
@@ -0,0 +1,60 @@
+// Package optsamber bridges optional values with the github.com/samber/mo
+// and github.com/samber/lo libraries.
+package optsamber
+
+import (
+	"github.com/heucuva/optional"
+	"github.com/samber/mo"
+)
+
+// ToMoOption converts a Value into a mo.Option
+func ToMoOption[T any](v optional.Value[T]) mo.Option[T] {
+	if value, set := v.Get(); set {
+		return mo.Some(value)
+	}
+	return mo.None[T]()
+}
+
+// FromMoOption converts a mo.Option into a Value
+func FromMoOption[T any](o mo.Option[T]) optional.Value[T] {
+	if value, present := o.Get(); present {
+		return optional.NewValue(value)
+	}
+	return optional.Value[T]{}
+}
+
+// Unwrap is a lo.FilterMap iteratee that keeps only the set values,
+// e.g. lo.FilterMap(values, optsamber.Unwrap[T])
+func Unwrap[T any](v optional.Value[T], _ int) (T, bool) {
+	return v.Get()
+}
+
+// Wrap is a lo.Map iteratee that turns every item into a set Value,
+// e.g. lo.Map(items, optsamber.Wrap[T])
+func Wrap[T any](item T, _ int) optional.Value[T] {
+	return optional.NewValue(item)
+}
+
+// IsSet is a lo.Filter predicate that keeps only the set values,
+// e.g. lo.Filter(values, optsamber.IsSet[T])
+func IsSet[T any](v optional.Value[T], _ int) bool {
+	return v.IsSet()
+}
+
+// FromMoOptions converts a slice of mo.Option into a slice of Value
+func FromMoOptions[T any](options []mo.Option[T]) []optional.Value[T] {
+	values := make([]optional.Value[T], len(options))
+	for i, o := range options {
+		values[i] = FromMoOption(o)
+	}
+	return values
+}
+
+// ToMoOptions converts a slice of Value into a slice of mo.Option
+func ToMoOptions[T any](values []optional.Value[T]) []mo.Option[T] {
+	options := make([]mo.Option[T], len(values))
+	for i, v := range values {
+		options[i] = ToMoOption(v)
+	}
+	return options
+}
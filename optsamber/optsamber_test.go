@@ -0,0 +1,70 @@
+package optsamber_test
+
+import (
+	"testing"
+
+	"github.com/heucuva/optional"
+	"github.com/heucuva/optional/optsamber"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+func TestMoOption(t *testing.T) {
+	t.Run("ToSome", func(t *testing.T) {
+		o := optsamber.ToMoOption(optional.NewValue(5))
+		if value, present := o.Get(); !present || value != 5 {
+			t.Fatalf("expected Some(5), got %v", o)
+		}
+	})
+	t.Run("ToNone", func(t *testing.T) {
+		if o := optsamber.ToMoOption(optional.Value[int]{}); o.IsPresent() {
+			t.Fatalf("expected None, got %v", o)
+		}
+	})
+	t.Run("FromSome", func(t *testing.T) {
+		v := optsamber.FromMoOption(mo.Some("Foo"))
+		if value, set := v.Get(); !set || value != "Foo" {
+			t.Fatalf("expected set \"Foo\", got %v, %v", value, set)
+		}
+	})
+	t.Run("FromNone", func(t *testing.T) {
+		if v := optsamber.FromMoOption(mo.None[string]()); v.IsSet() {
+			t.Fatal("expected unset value")
+		}
+	})
+	t.Run("Slices", func(t *testing.T) {
+		values := optsamber.FromMoOptions(optsamber.ToMoOptions([]optional.Value[int]{
+			optional.NewValue(1),
+			{},
+		}))
+		if len(values) != 2 || !values[0].IsSet() || values[1].IsSet() {
+			t.Fatalf("unexpected round-trip result %v", values)
+		}
+	})
+}
+
+func TestLo(t *testing.T) {
+	values := []optional.Value[int]{
+		optional.NewValue(1),
+		{},
+		optional.NewValue(3),
+	}
+
+	t.Run("FilterMap", func(t *testing.T) {
+		observed := lo.FilterMap(values, optsamber.Unwrap[int])
+		if len(observed) != 2 || observed[0] != 1 || observed[1] != 3 {
+			t.Fatalf("expected [1 3], got %v", observed)
+		}
+	})
+	t.Run("Filter", func(t *testing.T) {
+		if observed := lo.Filter(values, optsamber.IsSet[int]); len(observed) != 2 {
+			t.Fatalf("expected 2 set values, got %d", len(observed))
+		}
+	})
+	t.Run("Map", func(t *testing.T) {
+		observed := lo.Map([]string{"a", "b"}, optsamber.Wrap[string])
+		if len(observed) != 2 || !observed[0].IsSet() || !observed[1].IsSet() {
+			t.Fatalf("expected 2 set values, got %v", observed)
+		}
+	})
+}
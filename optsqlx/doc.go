@@ -0,0 +1,10 @@
+// Package optsqlx tests that optional values work with
+// github.com/jmoiron/sqlx without any registration.
+//
+// sqlx binds named parameters (NamedExec, NamedQuery, BindNamed) by looking
+// up struct fields by their `db` tag and passing the field itself to
+// database/sql, so an optional.Value field is bound through its
+// driver.Valuer, with an unset value written as NULL. StructScan, Get, and
+// Select scan columns into the same fields through sql.Scanner, where NULL
+// resets the value and anything else is converted into the element type.
+package optsqlx
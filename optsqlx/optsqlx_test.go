@@ -0,0 +1,146 @@
+package optsqlx_test
+
+import (
+	"testing"
+	"time"
+
+	_ "github.com/glebarez/go-sqlite"
+	"github.com/heucuva/optional"
+	"github.com/jmoiron/sqlx"
+)
+
+type status string
+
+type user struct {
+	ID        int64                     `db:"id"`
+	Name      optional.Value[string]    `db:"name"`
+	Age       optional.Value[int32]     `db:"age"`
+	Score     optional.Value[float64]   `db:"score"`
+	Active    optional.Value[bool]      `db:"active"`
+	Status    optional.Value[status]    `db:"status"`
+	Avatar    optional.Value[[]byte]    `db:"avatar"`
+	CreatedAt optional.Value[time.Time] `db:"created_at"`
+}
+
+const schema = `CREATE TABLE users (
+	id INTEGER PRIMARY KEY,
+	name TEXT,
+	age INTEGER,
+	score REAL,
+	active BOOLEAN,
+	status TEXT,
+	avatar BLOB,
+	created_at DATETIME
+)`
+
+func openDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+	db, err := sqlx.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	db.SetMaxOpenConns(1)
+	db.MustExec(schema)
+	return db
+}
+
+const insert = `INSERT INTO users (id, name, age, score, active, status, avatar, created_at)
+	VALUES (:id, :name, :age, :score, :active, :status, :avatar, :created_at)`
+
+func TestRoundTrip(t *testing.T) {
+	db := openDB(t)
+	created := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	set := user{
+		ID:        1,
+		Name:      optional.NewValue("Foo"),
+		Age:       optional.NewValue[int32](30),
+		Score:     optional.NewValue(1.5),
+		Active:    optional.NewValue(true),
+		Status:    optional.NewValue[status]("admin"),
+		Avatar:    optional.NewValue([]byte{1, 2, 3}),
+		CreatedAt: optional.NewValue(created),
+	}
+	unset := user{ID: 2}
+	for _, u := range []user{set, unset} {
+		if _, err := db.NamedExec(insert, u); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got user
+	if err := db.Get(&got, `SELECT * FROM users WHERE id = 1`); err != nil {
+		t.Fatal(err)
+	}
+	if got.Name.MustGet() != "Foo" || got.Age.MustGet() != 30 || got.Score.MustGet() != 1.5 ||
+		!got.Active.MustGet() || got.Status.MustGet() != "admin" || string(got.Avatar.MustGet()) != "\x01\x02\x03" {
+		t.Fatalf("unexpected row %+v", got)
+	}
+	if !got.CreatedAt.MustGet().Equal(created) {
+		t.Fatalf("expected %v, got %v", created, got.CreatedAt.MustGet())
+	}
+
+	// scanning over a previously set row must reset the values
+	if err := db.Get(&got, `SELECT * FROM users WHERE id = 2`); err != nil {
+		t.Fatal(err)
+	}
+	for name, v := range map[string]interface{ IsSet() bool }{
+		"name": got.Name, "age": got.Age, "score": got.Score, "active": got.Active,
+		"status": got.Status, "avatar": got.Avatar, "created_at": got.CreatedAt,
+	} {
+		if v.IsSet() {
+			t.Fatalf("expected %s to be unset", name)
+		}
+	}
+
+	var nulls int
+	if err := db.Get(&nulls, `SELECT COUNT(*) FROM users WHERE name IS NULL AND created_at IS NULL`); err != nil {
+		t.Fatal(err)
+	}
+	if nulls != 1 {
+		t.Fatalf("expected 1 row of NULLs, got %d", nulls)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	db := openDB(t)
+	for i, name := range []optional.Value[string]{optional.NewValue("Foo"), {}, optional.NewValue("Bar")} {
+		if _, err := db.NamedExec(insert, user{ID: int64(i + 1), Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var users []user
+	if err := db.Select(&users, `SELECT id, name FROM users ORDER BY id`); err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 3 {
+		t.Fatalf("expected 3 users, got %d", len(users))
+	}
+	if users[0].Name.MustGet() != "Foo" || users[1].Name.IsSet() || users[2].Name.MustGet() != "Bar" {
+		t.Fatalf("unexpected users %+v", users)
+	}
+
+	rows, err := db.NamedQuery(`SELECT id, name FROM users WHERE name = :name`, map[string]any{
+		"name": optional.NewValue("Bar"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var found []user
+	for rows.Next() {
+		var u user
+		if err := rows.StructScan(&u); err != nil {
+			t.Fatal(err)
+		}
+		found = append(found, u)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].ID != 3 {
+		t.Fatalf("unexpected rows %+v", found)
+	}
+}
@@ -0,0 +1,51 @@
+// Package optstr provides helpers for optional strings, such as those read
+// from configuration.
+package optstr
+
+import (
+	"strings"
+
+	"github.com/heucuva/optional"
+)
+
+// TrimSpace removes leading and trailing white space from a set value.
+// an unset value is passed through unchanged
+func TrimSpace(v optional.Value[string]) optional.Value[string] {
+	return optional.Map(v, strings.TrimSpace)
+}
+
+// ToLower lowercases a set value.
+// an unset value is passed through unchanged
+func ToLower(v optional.Value[string]) optional.Value[string] {
+	return optional.Map(v, strings.ToLower)
+}
+
+// ToUpper uppercases a set value.
+// an unset value is passed through unchanged
+func ToUpper(v optional.Value[string]) optional.Value[string] {
+	return optional.Map(v, strings.ToUpper)
+}
+
+// NonEmpty returns the value trimmed of leading and trailing white space,
+// or an unset value if it is unset or blank
+func NonEmpty(v optional.Value[string]) optional.Value[string] {
+	value, set := v.Get()
+	if !set {
+		return v
+	}
+	if value = strings.TrimSpace(value); value == "" {
+		return optional.Value[string]{}
+	}
+	return optional.NewValue(value)
+}
+
+// Join concatenates the set values, separated by sep, skipping unset ones
+func Join(values []optional.Value[string], sep string) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		if value, set := v.Get(); set {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, sep)
+}
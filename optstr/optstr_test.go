@@ -0,0 +1,54 @@
+package optstr_test
+
+import (
+	"testing"
+
+	"github.com/heucuva/optional"
+	"github.com/heucuva/optional/optstr"
+)
+
+func TestTransforms(t *testing.T) {
+	var unset optional.Value[string]
+	tests := []struct {
+		name     string
+		observed optional.Value[string]
+		expected optional.Value[string]
+	}{
+		{"TrimSpace", optstr.TrimSpace(optional.NewValue("  Foo \n")), optional.NewValue("Foo")},
+		{"TrimSpaceUnset", optstr.TrimSpace(unset), unset},
+		{"ToLower", optstr.ToLower(optional.NewValue("FoO")), optional.NewValue("foo")},
+		{"ToLowerUnset", optstr.ToLower(unset), unset},
+		{"ToUpper", optstr.ToUpper(optional.NewValue("FoO")), optional.NewValue("FOO")},
+		{"NonEmpty", optstr.NonEmpty(optional.NewValue(" Foo ")), optional.NewValue("Foo")},
+		{"NonEmptyBlank", optstr.NonEmpty(optional.NewValue(" \t")), unset},
+		{"NonEmptyEmpty", optstr.NonEmpty(optional.NewValue("")), unset},
+		{"NonEmptyUnset", optstr.NonEmpty(unset), unset},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.observed != tc.expected {
+				t.Fatalf("expected %+v, got %+v", tc.expected, tc.observed)
+			}
+		})
+	}
+}
+
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []optional.Value[string]
+		expected string
+	}{
+		{"Nil", nil, ""},
+		{"AllUnset", []optional.Value[string]{{}, {}}, ""},
+		{"SkipsUnset", []optional.Value[string]{optional.NewValue("a"), {}, optional.NewValue("b")}, "a,b"},
+		{"KeepsEmpty", []optional.Value[string]{optional.NewValue(""), optional.NewValue("b")}, ",b"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if observed := optstr.Join(tc.values, ","); observed != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, observed)
+			}
+		})
+	}
+}
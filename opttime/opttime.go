@@ -0,0 +1,305 @@
+// Package opttime decodes optional timestamps whose format varies between
+// sources, such as partner feeds that disagree on layouts, time zones, and
+// whether timestamps are unix numbers. It also provides helpers for parsing,
+// formatting, comparing, and doing arithmetic on optional times and
+// durations, which propagate unset values.
+package opttime
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/heucuva/optional"
+)
+
+// Decoder holds the options used to decode timestamps
+type Decoder struct {
+	// Location is used for timestamps that do not specify a time zone,
+	// and for unix timestamps. nil means UTC
+	Location *time.Location
+	// Layouts are the accepted layouts, tried in order. empty means
+	// time.RFC3339Nano. The first layout is also used for encoding
+	Layouts []string
+	// DetectUnix accepts unix timestamps, given as numbers or numeric
+	// strings, detecting from their magnitude whether they are in
+	// seconds, milliseconds, microseconds, or nanoseconds
+	DetectUnix bool
+}
+
+// ParseError is returned when a timestamp matches none of the accepted formats
+type ParseError struct {
+	Value string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("opttime: cannot parse %q as a timestamp", e.Value)
+}
+
+func (d *Decoder) location() *time.Location {
+	if d.Location == nil {
+		return time.UTC
+	}
+	return d.Location
+}
+
+func (d *Decoder) layouts() []string {
+	if len(d.Layouts) == 0 {
+		return []string{time.RFC3339Nano}
+	}
+	return d.Layouts
+}
+
+// Parse decodes a timestamp out of s.
+// an empty (or all-whitespace) s results in an unset value
+func (d *Decoder) Parse(s string) (optional.Value[time.Time], error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return optional.Value[time.Time]{}, nil
+	}
+
+	if d.DetectUnix {
+		if t, ok := d.parseUnix(s); ok {
+			return optional.NewValue(t), nil
+		}
+	}
+	for _, layout := range d.layouts() {
+		if t, err := time.ParseInLocation(layout, s, d.location()); err == nil {
+			return optional.NewValue(t), nil
+		}
+	}
+	return optional.Value[time.Time]{}, &ParseError{Value: s}
+}
+
+// DecodeJSON decodes a timestamp out of a json string or, if DetectUnix is
+// set, a json number. json null results in an unset value
+func (d *Decoder) DecodeJSON(data []byte) (optional.Value[time.Time], error) {
+	if len(data) == 0 || string(data) == "null" {
+		return optional.Value[time.Time]{}, nil
+	}
+	if data[0] != '"' {
+		if !d.DetectUnix {
+			return optional.Value[time.Time]{}, &ParseError{Value: string(data)}
+		}
+		return d.Parse(string(data))
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return optional.Value[time.Time]{}, err
+	}
+	return d.Parse(s)
+}
+
+// Format encodes t in the decoder's location, with the first accepted layout,
+// so that layouts without a time zone still round-trip
+func (d *Decoder) Format(t time.Time) string {
+	return t.In(d.location()).Format(d.layouts()[0])
+}
+
+// parseUnix decodes a unix timestamp, inferring its unit from its magnitude:
+// seconds reach 1e11 only after the year 5000, so any larger number is taken
+// to be in a finer unit
+func (d *Decoder) parseUnix(s string) (time.Time, bool) {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		var t time.Time
+		switch abs := math.Abs(float64(i)); {
+		case abs < 1e11:
+			t = time.Unix(i, 0)
+		case abs < 1e14:
+			t = time.UnixMilli(i)
+		case abs < 1e17:
+			t = time.UnixMicro(i)
+		default:
+			t = time.Unix(0, i)
+		}
+		return t.In(d.location()), true
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return time.Time{}, false
+	}
+	var scale float64
+	switch abs := math.Abs(f); {
+	case abs < 1e11:
+		scale = 1e9
+	case abs < 1e14:
+		scale = 1e6
+	case abs < 1e17:
+		scale = 1e3
+	default:
+		scale = 1
+	}
+	sec, frac := math.Modf(f * scale / 1e9)
+	return time.Unix(int64(sec), int64(frac*1e9)).In(d.location()), true
+}
+
+// Profile names a Decoder configuration at the type level, so Time fields
+// can be decoded with it by encoding/json. Profiles are usually empty struct
+// types:
+//
+//	type partnerFeed struct{}
+//
+//	func (partnerFeed) Decoder() *opttime.Decoder { return &partnerFeedDecoder }
+type Profile interface {
+	Decoder() *Decoder
+}
+
+// Time is an optional timestamp which is encoded and decoded in json and
+// text using the Decoder of its profile P
+type Time[P Profile] struct {
+	v optional.Value[time.Time]
+}
+
+// NewTime constructs a Time structure with a value already set into it
+func NewTime[P Profile](value time.Time) Time[P] {
+	return Time[P]{v: optional.NewValue(value)}
+}
+
+func decoder[P Profile]() *Decoder {
+	var p P
+	return p.Decoder()
+}
+
+// Reset clears the memory on the value
+func (o *Time[P]) Reset() {
+	o.v.Reset()
+}
+
+// Set updates the value and sets the set flag
+func (o *Time[P]) Set(value time.Time) {
+	o.v.Set(value)
+}
+
+// IsSet returns true if the value is set
+func (o Time[P]) IsSet() bool {
+	return o.v.IsSet()
+}
+
+// IsZero returns true if the value is unset, for omitzero and omitempty
+func (o Time[P]) IsZero() bool {
+	return o.v.IsZero()
+}
+
+// Get returns the value and its set flag
+func (o Time[P]) Get() (time.Time, bool) {
+	return o.v.Get()
+}
+
+// Optional returns the value as an optional.Value
+func (o Time[P]) Optional() optional.Value[time.Time] {
+	return o.v
+}
+
+// MarshalText outputs the value in the profile's first layout, if it is set.
+// otherwise, it returns empty text
+func (o Time[P]) MarshalText() ([]byte, error) {
+	value, set := o.v.Get()
+	if !set {
+		return []byte{}, nil
+	}
+	return []byte(decoder[P]().Format(value)), nil
+}
+
+// UnmarshalText decodes a value with the profile's decoder.
+// empty text resets the value
+func (o *Time[P]) UnmarshalText(text []byte) error {
+	v, err := decoder[P]().Parse(string(text))
+	if err != nil {
+		return err
+	}
+	o.v = v
+	return nil
+}
+
+// MarshalJSON outputs the value in the profile's first layout, if it is set.
+// otherwise, it returns nil
+func (o Time[P]) MarshalJSON() ([]byte, error) {
+	value, set := o.v.Get()
+	if !set {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(decoder[P]().Format(value))
+}
+
+// UnmarshalJSON decodes a value with the profile's decoder
+func (o *Time[P]) UnmarshalJSON(data []byte) error {
+	v, err := decoder[P]().DecodeJSON(data)
+	if err != nil {
+		return err
+	}
+	o.v = v
+	return nil
+}
+
+// RFC3339 is a Profile which encodes timestamps in UTC with time.RFC3339,
+// and decodes them with time.RFC3339Nano, for json fields holding whole
+// second timestamps:
+//
+//	Expires opttime.Time[opttime.RFC3339] `json:"expires"`
+type RFC3339 struct{}
+
+var rfc3339Decoder = Decoder{
+	Layouts: []string{time.RFC3339, time.RFC3339Nano},
+}
+
+// Decoder returns the RFC3339 profile's Decoder
+func (RFC3339) Decoder() *Decoder {
+	return &rfc3339Decoder
+}
+
+// Parse decodes a timestamp out of s with layout, in UTC if the layout has
+// no time zone. an empty (or all-whitespace) s results in an unset value
+func Parse(layout, s string) (optional.Value[time.Time], error) {
+	d := Decoder{Layouts: []string{layout}}
+	return d.Parse(s)
+}
+
+// Format encodes a set value with layout.
+// an unset value results in an unset string
+func Format(v optional.Value[time.Time], layout string) optional.Value[string] {
+	return optional.Map(v, func(t time.Time) string {
+		return t.Format(layout)
+	})
+}
+
+// ParseDuration decodes a duration out of s, as time.ParseDuration does.
+// an empty (or all-whitespace) s results in an unset value
+func ParseDuration(s string) (optional.Value[time.Duration], error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return optional.Value[time.Duration]{}, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return optional.Value[time.Duration]{}, err
+	}
+	return optional.NewValue(d), nil
+}
+
+// Before reports whether a is before b, if both are set.
+// otherwise, it returns an unset value (see package tribool)
+func Before(a, b optional.Value[time.Time]) optional.Value[bool] {
+	return optional.Apply(a, b, time.Time.Before)
+}
+
+// After reports whether a is after b, if both are set.
+// otherwise, it returns an unset value (see package tribool)
+func After(a, b optional.Value[time.Time]) optional.Value[bool] {
+	return optional.Apply(a, b, time.Time.After)
+}
+
+// Sub returns the duration a - b, if both are set.
+// otherwise, it returns an unset value
+func Sub(a, b optional.Value[time.Time]) optional.Value[time.Duration] {
+	return optional.Apply(a, b, time.Time.Sub)
+}
+
+// Add returns t + d, if both are set.
+// otherwise, it returns an unset value
+func Add(t optional.Value[time.Time], d optional.Value[time.Duration]) optional.Value[time.Time] {
+	return optional.Apply(t, d, time.Time.Add)
+}
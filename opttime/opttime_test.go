@@ -0,0 +1,234 @@
+package opttime_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/heucuva/optional"
+	"github.com/heucuva/optional/opttime"
+)
+
+var testFeedDecoder = opttime.Decoder{
+	Location:   time.FixedZone("EST", -5*60*60),
+	Layouts:    []string{"2006-01-02 15:04:05", time.RFC3339, "02/01/2006"},
+	DetectUnix: true,
+}
+
+type testFeed struct{}
+
+func (testFeed) Decoder() *opttime.Decoder {
+	return &testFeedDecoder
+}
+
+func TestDecoderParse(t *testing.T) {
+	expected := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"LayoutWithoutZone", "2024-03-01 07:30:00"},
+		{"LayoutWithZone", "2024-03-01T12:30:00Z"},
+		{"UnixSeconds", "1709296200"},
+		{"UnixMillis", "1709296200000"},
+		{"UnixMicros", "1709296200000000"},
+		{"UnixNanos", "1709296200000000000"},
+		{"UnixFractionalSeconds", "1709296200.0"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			observed, err := testFeedDecoder.Parse(tc.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value := observed.MustGet(); !value.Equal(expected) {
+				t.Fatalf("expected %v, got %v", expected, value)
+			}
+		})
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		observed, err := testFeedDecoder.Parse("  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if observed.IsSet() {
+			t.Fatal("expected unset value")
+		}
+	})
+	t.Run("Location", func(t *testing.T) {
+		observed, err := testFeedDecoder.Parse("01/03/2024")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, offset := observed.MustGet().Zone(); offset != -5*60*60 {
+			t.Fatalf("expected EST offset, got %d", offset)
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := testFeedDecoder.Parse("yesterday")
+		var parseErr *opttime.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("expected ParseError, got %v", err)
+		}
+	})
+	t.Run("Default", func(t *testing.T) {
+		var d opttime.Decoder
+		if _, err := d.Parse("1709296200"); err == nil {
+			t.Fatal("expected failure without DetectUnix, but got success")
+		}
+		observed, err := d.Parse("2024-03-01T12:30:00Z")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value := observed.MustGet(); !value.Equal(expected) {
+			t.Fatalf("expected %v, got %v", expected, value)
+		}
+	})
+}
+
+func TestTime(t *testing.T) {
+	type testRecord struct {
+		Created opttime.Time[testFeed] `json:"created"`
+		Updated opttime.Time[testFeed] `json:"updated"`
+		Deleted opttime.Time[testFeed] `json:"deleted"`
+	}
+
+	var record testRecord
+	if err := json.Unmarshal([]byte(`{"created":1709296200,"updated":"2024-03-01T12:30:00Z","deleted":null}`), &record); err != nil {
+		t.Fatal(err)
+	}
+	created, _ := record.Created.Get()
+	updated, _ := record.Updated.Get()
+	if !created.Equal(updated) {
+		t.Fatalf("expected %v to equal %v", created, updated)
+	}
+	if record.Deleted.IsSet() {
+		t.Fatal("expected deleted to be unset")
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		blob, err := json.Marshal(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		const expected = `{"created":"2024-03-01 07:30:00","updated":"2024-03-01 07:30:00","deleted":null}`
+		if string(blob) != expected {
+			t.Fatalf("expected %s, got %s", expected, blob)
+		}
+	})
+	t.Run("Text", func(t *testing.T) {
+		var target opttime.Time[testFeed]
+		if err := target.UnmarshalText([]byte("1709296200000")); err != nil {
+			t.Fatal(err)
+		}
+		text, err := target.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(text) != "2024-03-01 07:30:00" {
+			t.Fatalf("unexpected text %s", text)
+		}
+	})
+}
+
+func TestRFC3339(t *testing.T) {
+	type testRecord struct {
+		Expires opttime.Time[opttime.RFC3339] `json:"expires"`
+		Revoked opttime.Time[opttime.RFC3339] `json:"revoked"`
+	}
+
+	var record testRecord
+	if err := json.Unmarshal([]byte(`{"expires":"2024-03-01T07:30:00.5-05:00","revoked":null}`), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Revoked.IsSet() {
+		t.Fatal("expected revoked to be unset")
+	}
+	blob, err := json.Marshal(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const expected = `{"expires":"2024-03-01T12:30:00Z","revoked":null}`
+	if string(blob) != expected {
+		t.Fatalf("expected %s, got %s", expected, blob)
+	}
+}
+
+func TestHelpers(t *testing.T) {
+	earlier := optional.NewValue(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	later := optional.NewValue(time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC))
+	var unset optional.Value[time.Time]
+
+	t.Run("Parse", func(t *testing.T) {
+		observed, err := opttime.Parse("2006-01-02 15:04", "2024-03-01 12:30")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !observed.MustGet().Equal(later.MustGet()) {
+			t.Fatalf("expected %v, got %v", later, observed)
+		}
+		if observed, err = opttime.Parse(time.RFC3339, " "); err != nil || observed.IsSet() {
+			t.Fatalf("expected unset, got %v (%v)", observed, err)
+		}
+		var parseErr *opttime.ParseError
+		if _, err = opttime.Parse(time.RFC3339, "yesterday"); !errors.As(err, &parseErr) {
+			t.Fatalf("expected a ParseError, got %v", err)
+		}
+	})
+	t.Run("Format", func(t *testing.T) {
+		if observed := opttime.Format(later, time.Kitchen); observed != optional.NewValue("12:30PM") {
+			t.Fatalf("unexpected %v", observed)
+		}
+		if observed := opttime.Format(unset, time.Kitchen); observed.IsSet() {
+			t.Fatalf("expected unset, got %v", observed)
+		}
+	})
+	t.Run("ParseDuration", func(t *testing.T) {
+		observed, err := opttime.ParseDuration("1h30m")
+		if err != nil || observed != optional.NewValue(90*time.Minute) {
+			t.Fatalf("expected 1h30m, got %v (%v)", observed, err)
+		}
+		if observed, err = opttime.ParseDuration(""); err != nil || observed.IsSet() {
+			t.Fatalf("expected unset, got %v (%v)", observed, err)
+		}
+		if _, err = opttime.ParseDuration("soon"); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+	t.Run("Compare", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			observed optional.Value[bool]
+			expected optional.Value[bool]
+		}{
+			{"Before", opttime.Before(earlier, later), optional.NewValue(true)},
+			{"NotBefore", opttime.Before(later, earlier), optional.NewValue(false)},
+			{"After", opttime.After(later, earlier), optional.NewValue(true)},
+			{"BeforeUnset", opttime.Before(earlier, unset), optional.Value[bool]{}},
+			{"AfterUnset", opttime.After(unset, later), optional.Value[bool]{}},
+		}
+		for _, tc := range tests {
+			if tc.observed != tc.expected {
+				t.Fatalf("%s: expected %v, got %v", tc.name, tc.expected, tc.observed)
+			}
+		}
+	})
+	t.Run("Arithmetic", func(t *testing.T) {
+		if observed := opttime.Sub(later, earlier); observed != optional.NewValue(30*time.Minute) {
+			t.Fatalf("expected 30m, got %v", observed)
+		}
+		if observed := opttime.Sub(later, unset); observed.IsSet() {
+			t.Fatalf("expected unset, got %v", observed)
+		}
+		observed := opttime.Add(earlier, optional.NewValue(30*time.Minute))
+		if !observed.MustGet().Equal(later.MustGet()) {
+			t.Fatalf("expected %v, got %v", later, observed)
+		}
+		if observed := opttime.Add(earlier, optional.Value[time.Duration]{}); observed.IsSet() {
+			t.Fatalf("expected unset, got %v", observed)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+// Package optvalidator registers optional values with
+// github.com/go-playground/validator, so validation tags on optional fields
+// apply to the value they hold.
+//
+// A set optional is validated as its value. An unset optional is validated
+// as a missing value, so it passes tags guarded by omitempty and fails
+// required, which is what PATCH-style request validation needs:
+//
+//	type PatchUser struct {
+//		Name optional.Value[string] `validate:"omitempty,min=3"`
+//	}
+package optvalidator
+
+import (
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/heucuva/optional"
+)
+
+// Register registers optional types with v. Each of types is either an
+// optional value (e.g. optional.Value[string]{}) or a struct, or pointer to
+// a struct, whose optional fields are registered, including those of nested
+// structs. validator matches custom types exactly, so every instantiation
+// of an optional in use must be registered.
+func Register(v *validator.Validate, types ...any) {
+	seen := make(map[reflect.Type]bool)
+	for _, t := range types {
+		register(v, reflect.TypeOf(t), seen)
+	}
+}
+
+func register(v *validator.Validate, t reflect.Type, seen map[reflect.Type]bool) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || seen[t] {
+		return
+	}
+	seen[t] = true
+
+	if optional.IsOptionalType(t) {
+		v.RegisterCustomTypeFunc(unwrap, reflect.Zero(t).Interface())
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			register(v, t.Field(i).Type, seen)
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		register(v, t.Elem(), seen)
+	}
+}
+
+type anyValuer interface {
+	AsAny() (any, bool)
+}
+
+// unwrap is the validator.CustomTypeFunc for optionals. it returns the value
+// of a set optional, and nil for an unset one
+func unwrap(field reflect.Value) any {
+	if opt, ok := field.Interface().(anyValuer); ok {
+		if value, set := opt.AsAny(); set {
+			return value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package optvalidator_test
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/heucuva/optional"
+	"github.com/heucuva/optional/optvalidator"
+)
+
+type address struct {
+	City optional.Value[string] `validate:"omitempty,min=2"`
+}
+
+type patchUser struct {
+	Name    optional.Value[string] `validate:"omitempty,min=3"`
+	Age     optional.Value[int]    `validate:"omitempty,gte=0,lte=150"`
+	Email   optional.Value[string] `validate:"required,email"`
+	Address *address
+	Tags    []optional.Value[string] `validate:"dive,omitempty,alpha"`
+}
+
+func TestRegister(t *testing.T) {
+	v := validator.New()
+	optvalidator.Register(v, patchUser{})
+
+	valid := patchUser{Email: optional.NewValue("foo@example.com")}
+	tests := []struct {
+		name  string
+		patch func(p *patchUser)
+		valid bool
+	}{
+		{"all unset", func(p *patchUser) {}, true},
+		{"set and valid", func(p *patchUser) {
+			p.Name.Set("Foo")
+			p.Age.Set(30)
+		}, true},
+		{"set too short", func(p *patchUser) { p.Name.Set("Fo") }, false},
+		{"set out of range", func(p *patchUser) { p.Age.Set(200) }, false},
+		{"required unset", func(p *patchUser) { p.Email.Reset() }, false},
+		{"required invalid", func(p *patchUser) { p.Email.Set("foo") }, false},
+		{"nested valid", func(p *patchUser) {
+			p.Address = &address{City: optional.NewValue("Oslo")}
+		}, true},
+		{"nested invalid", func(p *patchUser) {
+			p.Address = &address{City: optional.NewValue("O")}
+		}, false},
+		{"dive", func(p *patchUser) {
+			p.Tags = []optional.Value[string]{optional.NewValue("foo"), {}}
+		}, true},
+		{"dive invalid", func(p *patchUser) {
+			p.Tags = []optional.Value[string]{optional.NewValue("f00")}
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid
+			tt.patch(&p)
+			err := v.Struct(p)
+			if tt.valid && err != nil {
+				t.Fatalf("expected valid, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Fatal("expected a validation error")
+			}
+		})
+	}
+}
+
+func TestRegisterOptional(t *testing.T) {
+	v := validator.New()
+	optvalidator.Register(v, optional.Value[string]{})
+
+	if err := v.Var(optional.NewValue("foo"), "min=3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Var(optional.NewValue("fo"), "min=3"); err == nil {
+		t.Fatal("expected a validation error")
+	}
+	if err := v.Var(optional.Value[string]{}, "omitempty,min=3"); err != nil {
+		t.Fatal(err)
+	}
+}
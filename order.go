@@ -0,0 +1,59 @@
+package optional
+
+import "golang.org/x/exp/constraints"
+
+// Compare returns -1 if a is less than b, 0 if they are equal, and +1 if a is
+// greater than b. An unset value is less than any set value, and two unset
+// values are equal. Like cmp.Compare, a NaN is less than any other number and
+// equal to another NaN, so the ordering is total and can be used with
+// slices.SortFunc.
+func Compare[T constraints.Ordered](a, b Value[T]) int {
+	switch {
+	case !a.set && !b.set:
+		return 0
+	case !a.set:
+		return -1
+	case !b.set:
+		return 1
+	}
+
+	x, y := a.value, b.value
+	xNaN, yNaN := isNaN(x), isNaN(y)
+	switch {
+	case xNaN && yNaN:
+		return 0
+	case xNaN || x < y:
+		return -1
+	case yNaN || x > y:
+		return 1
+	}
+	return 0
+}
+
+// Less returns true if a is less than b, as ordered by Compare
+func Less[T constraints.Ordered](a, b Value[T]) bool {
+	return Compare(a, b) < 0
+}
+
+// Min returns the lesser of a and b, as ordered by Compare, so it returns an
+// unset value if either is unset
+func Min[T constraints.Ordered](a, b Value[T]) Value[T] {
+	if Compare(b, a) < 0 {
+		return b
+	}
+	return a
+}
+
+// Max returns the greater of a and b, as ordered by Compare, so it returns
+// an unset value only if both are unset
+func Max[T constraints.Ordered](a, b Value[T]) Value[T] {
+	if Compare(b, a) > 0 {
+		return b
+	}
+	return a
+}
+
+// isNaN reports whether x is a NaN, without requiring x to be a float
+func isNaN[T constraints.Ordered](x T) bool {
+	return x != x
+}
@@ -0,0 +1,59 @@
+package optional_test
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestCompare(t *testing.T) {
+	unset := optional.Value[int]{}
+	one := optional.NewValue(1)
+	two := optional.NewValue(2)
+
+	t.Run("Compare", func(t *testing.T) {
+		expect(t, "unset unset", 0, optional.Compare(unset, unset))
+		expect(t, "unset set", -1, optional.Compare(unset, one))
+		expect(t, "set unset", 1, optional.Compare(one, unset))
+		expect(t, "less", -1, optional.Compare(one, two))
+		expect(t, "greater", 1, optional.Compare(two, one))
+		expect(t, "equal", 0, optional.Compare(two, two))
+		expect(t, "unset zero", -1, optional.Compare(unset, optional.NewValue(0)))
+	})
+	t.Run("NaN", func(t *testing.T) {
+		nan := optional.NewValue(math.NaN())
+		zero := optional.NewValue(0.0)
+		expect(t, "nan nan", 0, optional.Compare(nan, nan))
+		expect(t, "nan zero", -1, optional.Compare(nan, zero))
+		expect(t, "zero nan", 1, optional.Compare(zero, nan))
+		expect(t, "unset nan", -1, optional.Compare(optional.Value[float64]{}, nan))
+	})
+	t.Run("Less", func(t *testing.T) {
+		expect(t, "unset one", true, optional.Less(unset, one))
+		expect(t, "one one", false, optional.Less(one, one))
+	})
+	t.Run("Min", func(t *testing.T) {
+		expect(t, "one two", 1, optional.Min(one, two).MustGet())
+		expect(t, "two one", 1, optional.Min(two, one).MustGet())
+		expect(t, "unset", false, optional.Min(one, unset).IsSet())
+	})
+	t.Run("Max", func(t *testing.T) {
+		expect(t, "one two", 2, optional.Max(one, two).MustGet())
+		expect(t, "unset", 1, optional.Max(unset, one).MustGet())
+		expect(t, "both unset", false, optional.Max(unset, unset).IsSet())
+	})
+	t.Run("Sort", func(t *testing.T) {
+		values := []optional.Value[string]{
+			optional.NewValue("b"), {}, optional.NewValue("a"), {},
+		}
+		sort.Slice(values, func(i, j int) bool {
+			return optional.Less(values[i], values[j])
+		})
+		expect(t, "0 set", false, values[0].IsSet())
+		expect(t, "1 set", false, values[1].IsSet())
+		expect(t, "2", "a", values[2].MustGet())
+		expect(t, "3", "b", values[3].MustGet())
+	})
+}
@@ -0,0 +1,317 @@
+package optional
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PatchOption configures the behavior of ApplyPatch and Diff
+type PatchOption func(*patchConfig)
+
+type patchConfig struct {
+	tag string
+}
+
+// PatchTag makes ApplyPatch and Diff match fields by the name in the given
+// struct tag (e.g. "json"), falling back to the field name when it is absent
+func PatchTag(tag string) PatchOption {
+	return func(c *patchConfig) {
+		c.tag = tag
+	}
+}
+
+// ApplyPatch writes the value of every set optional field of the struct
+// patch into the matching field of the struct dst points to, which is
+// usually a different type holding plain fields. Fields are matched by name
+// (see PatchTag). Unset optionals leave dst untouched, and so do plain fields
+// of patch, apart from nested structs (or pointers to them), which are
+// applied recursively to the matching nested struct in dst, allocating it if
+// needed.
+//
+// An optional or nested struct field of patch with no match in dst, or a
+// value which cannot be assigned to its match, is an error. dst may have
+// been partially updated when an error is returned.
+func ApplyPatch(dst, patch any, opts ...PatchOption) error {
+	var cfg patchConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() {
+		return errors.New("optional: patch destination must be a non-nil pointer")
+	}
+	dv = dv.Elem()
+
+	pv := reflect.ValueOf(patch)
+	if !pv.IsValid() {
+		return nil
+	}
+	for pv.Kind() == reflect.Pointer {
+		if pv.IsNil() {
+			return nil
+		}
+		pv = pv.Elem()
+	}
+
+	if dv.Kind() != reflect.Struct || pv.Kind() != reflect.Struct || IsOptionalType(pv.Type()) {
+		return fmt.Errorf("optional: cannot apply patch %v to %v", pv.Type(), dv.Type())
+	}
+	return cfg.apply("", dv, pv)
+}
+
+func (c *patchConfig) apply(prefix string, dv, pv reflect.Value) error {
+	dstFields := make(map[string]int)
+	dt := dv.Type()
+	for i := 0; i < dt.NumField(); i++ {
+		if field := dt.Field(i); field.IsExported() {
+			dstFields[c.fieldName(field)] = i
+		}
+	}
+
+	pt := pv.Type()
+	for i := 0; i < pt.NumField(); i++ {
+		field := pt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fv := pv.Field(i)
+		isOptional := IsOptionalType(fv.Type())
+		if !isOptional {
+			for fv.Kind() == reflect.Pointer && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() != reflect.Struct {
+				continue
+			}
+		}
+
+		path := prefix + field.Name
+		j, ok := dstFields[c.fieldName(field)]
+		if !ok {
+			return fmt.Errorf("optional: patching %s: no matching field in %v", path, dt)
+		}
+		df := dv.Field(j)
+
+		if isOptional {
+			if !fv.Interface().(anyOptional).IsSet() {
+				continue
+			}
+			if err := patchField(path, df, fv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// a plain nested struct holds a patch of its own
+		if !hasSetOptional(fv) {
+			continue
+		}
+		for df.Kind() == reflect.Pointer {
+			if df.IsNil() {
+				df.Set(reflect.New(df.Type().Elem()))
+			}
+			df = df.Elem()
+		}
+		if df.Kind() != reflect.Struct || IsOptionalType(df.Type()) {
+			return fmt.Errorf("optional: patching %s: cannot apply patch %v to %v", path, fv.Type(), df.Type())
+		}
+		if err := c.apply(path+".", df, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *patchConfig) fieldName(field reflect.StructField) string {
+	if c.tag != "" {
+		if name, _, _ := strings.Cut(field.Tag.Get(c.tag), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// patchField writes the value of the set optional pv into df
+func patchField(path string, df, pv reflect.Value) error {
+	value, _ := pv.Interface().(anyOptional).AsAny()
+
+	if IsOptionalType(df.Type()) {
+		// unlike FromAny, a nil value is set rather than treated as unset
+		ptr := reflect.New(df.Type())
+		if err := ptr.Interface().(anySetter).setAny(value); err != nil {
+			return fmt.Errorf("optional: patching %s: %w", path, err)
+		}
+		df.Set(ptr.Elem())
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch {
+	case !rv.IsValid():
+		df.Set(reflect.Zero(df.Type()))
+	case rv.Type().AssignableTo(df.Type()):
+		df.Set(rv)
+	case isNumberKind(rv.Kind()) && isNumberKind(df.Kind()):
+		converted, err := convertNumber(rv, df.Type())
+		if err != nil {
+			return fmt.Errorf("optional: patching %s: %w", path, err)
+		}
+		df.Set(converted)
+	default:
+		return fmt.Errorf("optional: patching %s: cannot use %v as %v", path, rv.Type(), df.Type())
+	}
+	return nil
+}
+
+var errFoundSet = errors.New("found set optional")
+
+// hasSetOptional returns true if any optional in the struct rv is set
+func hasSetOptional(rv reflect.Value) bool {
+	err := walkOptionals("", rv, func(path string, opt anyOptional) error {
+		if opt.IsSet() {
+			return errFoundSet
+		}
+		return nil
+	})
+	return err != nil
+}
+
+// Diff is the inverse of ApplyPatch: it sets the optional fields of the patch
+// struct patch points to wherever the matching fields of the structs old and
+// new differ, holding new's value, so that applying the patch to old yields
+// new. old and new must be the same type. Fields are matched as they are by
+// ApplyPatch, and nested patch structs are filled in recursively, allocated
+// only when they hold a difference.
+//
+// A patch cannot unset a field, so an optional which is set in old but unset
+// in new is left out of the patch.
+func Diff(patch, old, new any, opts ...PatchOption) error {
+	var cfg patchConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	pv := reflect.ValueOf(patch)
+	if pv.Kind() != reflect.Pointer || pv.IsNil() {
+		return errors.New("optional: patch must be a non-nil pointer")
+	}
+	pv = pv.Elem()
+	if pv.Kind() != reflect.Struct || IsOptionalType(pv.Type()) {
+		return fmt.Errorf("optional: cannot diff into %v", pv.Type())
+	}
+
+	ov, err := structValue(old)
+	if err != nil {
+		return err
+	}
+	nv, err := structValue(new)
+	if err != nil {
+		return err
+	}
+	if ov.Type() != nv.Type() {
+		return fmt.Errorf("optional: cannot diff %v with %v", ov.Type(), nv.Type())
+	}
+
+	_, err = cfg.diff("", pv, ov, nv)
+	return err
+}
+
+// diff fills in the patch pv from the structs ov and nv, returning true if
+// any field was set
+func (c *patchConfig) diff(prefix string, pv, ov, nv reflect.Value) (bool, error) {
+	fields := make(map[string]int)
+	mt := ov.Type()
+	for i := 0; i < mt.NumField(); i++ {
+		if field := mt.Field(i); field.IsExported() {
+			fields[c.fieldName(field)] = i
+		}
+	}
+
+	changed := false
+	pt := pv.Type()
+	for i := 0; i < pt.NumField(); i++ {
+		field := pt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		isOptional := IsOptionalType(field.Type)
+		nestedType := field.Type
+		if !isOptional {
+			for nestedType.Kind() == reflect.Pointer {
+				nestedType = nestedType.Elem()
+			}
+			if nestedType.Kind() != reflect.Struct || IsOptionalType(nestedType) {
+				continue
+			}
+		}
+
+		path := prefix + field.Name
+		j, ok := fields[c.fieldName(field)]
+		if !ok {
+			return false, fmt.Errorf("optional: diffing %s: no matching field in %v", path, mt)
+		}
+		of, nf := ov.Field(j), nv.Field(j)
+
+		if isOptional {
+			oldValue, newValue := fieldValue(of, true), fieldValue(nf, true)
+			if EqualFunc(oldValue, newValue, func(x, y any) bool { return reflect.DeepEqual(x, y) }) {
+				continue
+			}
+			value, set := newValue.Get()
+			if !set {
+				continue
+			}
+			ptr := reflect.New(field.Type)
+			if err := ptr.Interface().(anySetter).setAny(value); err != nil {
+				return false, fmt.Errorf("optional: diffing %s: %w", path, err)
+			}
+			pv.Field(i).Set(ptr.Elem())
+			changed = true
+			continue
+		}
+
+		of, nf = derefStruct(of), derefStruct(nf)
+		if of.Kind() != reflect.Struct || IsOptionalType(of.Type()) {
+			return false, fmt.Errorf("optional: diffing %s: cannot diff %v into %v", path, of.Type(), field.Type)
+		}
+		nested := reflect.New(nestedType).Elem()
+		nestedChanged, err := c.diff(path+".", nested, of, nf)
+		if err != nil {
+			return false, err
+		}
+		if !nestedChanged {
+			continue
+		}
+		// wrap the nested patch in as many pointers as the field needs
+		target := nested
+		for target.Type() != field.Type {
+			ptr := reflect.New(target.Type())
+			ptr.Elem().Set(target)
+			target = ptr
+		}
+		pv.Field(i).Set(target)
+		changed = true
+	}
+	return changed, nil
+}
+
+// derefStruct dereferences pointers to structs, treating a nil pointer as
+// the struct's zero value
+func derefStruct(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			t := v.Type().Elem()
+			for t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+			return reflect.Zero(t)
+		}
+		v = v.Elem()
+	}
+	return v
+}
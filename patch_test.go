@@ -0,0 +1,240 @@
+package optional_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+type patchAddress struct {
+	City string
+	Zip  string
+}
+
+type patchUser struct {
+	Name     string
+	Age      int64
+	Nickname optional.Value[string]
+	Email    *string
+	Address  patchAddress
+	Billing  *patchAddress
+	Internal string
+}
+
+type patchAddressPatch struct {
+	City optional.Value[string]
+	Zip  optional.Value[string]
+}
+
+type patchUserPatch struct {
+	Name     optional.Value[string]
+	Age      optional.Value[int]
+	Nickname optional.Value[string]
+	Email    optional.Value[*string]
+	Address  patchAddressPatch
+	Billing  *patchAddressPatch
+	Note     string // plain fields are not part of the patch
+}
+
+func newPatchUser() patchUser {
+	return patchUser{
+		Name:     "Foo",
+		Age:      30,
+		Nickname: optional.NewValue("foo"),
+		Address:  patchAddress{City: "Oslo", Zip: "0150"},
+		Internal: "secret",
+	}
+}
+
+func TestApplyPatch(t *testing.T) {
+	t.Run("Set", func(t *testing.T) {
+		user := newPatchUser()
+		patch := patchUserPatch{
+			Name: optional.NewValue("Bar"),
+			Age:  optional.NewValue(31),
+		}
+		if err := optional.ApplyPatch(&user, patch); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "name", "Bar", user.Name)
+		expect(t, "age", 31, user.Age)
+		expect(t, "nickname", "foo", user.Nickname.MustGet())
+		expect(t, "internal", "secret", user.Internal)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		user := newPatchUser()
+		if err := optional.ApplyPatch(&user, patchUserPatch{}); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "name", "Foo", user.Name)
+		expect(t, "billing allocated", false, user.Billing != nil)
+	})
+	t.Run("OptionalDestination", func(t *testing.T) {
+		user := newPatchUser()
+		patch := patchUserPatch{Nickname: optional.NewValue("")}
+		if err := optional.ApplyPatch(&user, &patch); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "nickname set", true, user.Nickname.IsSet())
+		expect(t, "nickname", "", user.Nickname.MustGet())
+	})
+	t.Run("SetToNil", func(t *testing.T) {
+		user := newPatchUser()
+		email := "foo@example.com"
+		user.Email = &email
+		patch := patchUserPatch{Email: optional.NewValue[*string](nil)}
+		if err := optional.ApplyPatch(&user, patch); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "email nil", true, user.Email == nil)
+	})
+	t.Run("Nested", func(t *testing.T) {
+		user := newPatchUser()
+		patch := patchUserPatch{
+			Address: patchAddressPatch{Zip: optional.NewValue("0151")},
+			Billing: &patchAddressPatch{City: optional.NewValue("Bergen")},
+		}
+		if err := optional.ApplyPatch(&user, patch); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "city", "Oslo", user.Address.City)
+		expect(t, "zip", "0151", user.Address.Zip)
+		expect(t, "billing city", "Bergen", user.Billing.City)
+	})
+	t.Run("Tag", func(t *testing.T) {
+		var dst struct {
+			FullName string `json:"name"`
+		}
+		patch := struct {
+			Name optional.Value[string] `json:"name"`
+		}{Name: optional.NewValue("Foo")}
+		if err := optional.ApplyPatch(&dst, patch, optional.PatchTag("json")); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "name", "Foo", dst.FullName)
+	})
+	t.Run("NoMatch", func(t *testing.T) {
+		var dst struct{ Name string }
+		patch := struct{ Nmae optional.Value[string] }{}
+		err := optional.ApplyPatch(&dst, patch)
+		expect(t, "error", true, err != nil && strings.Contains(err.Error(), "Nmae"))
+	})
+	t.Run("TypeMismatch", func(t *testing.T) {
+		user := newPatchUser()
+		patch := struct {
+			Address struct{ Zip optional.Value[int] }
+		}{}
+		patch.Address.Zip.Set(151)
+		err := optional.ApplyPatch(&user, patch)
+		expect(t, "error", true, err != nil && strings.Contains(err.Error(), "Address.Zip"))
+	})
+	t.Run("NumberNotRepresentable", func(t *testing.T) {
+		type smallUser struct {
+			Age   uint8
+			Score int
+		}
+		tests := []struct {
+			name  string
+			patch any
+		}{
+			{"Overflow", struct{ Age optional.Value[int] }{optional.NewValue(300)}},
+			{"Negative", struct{ Age optional.Value[int] }{optional.NewValue(-1)}},
+			{"Truncated", struct{ Score optional.Value[float64] }{optional.NewValue(2.9)}},
+		}
+		for _, tc := range tests {
+			user := smallUser{Age: 30, Score: 1}
+			err := optional.ApplyPatch(&user, tc.patch)
+			expect(t, tc.name+" error", true, err != nil)
+			expect(t, tc.name+" age", uint8(30), user.Age)
+			expect(t, tc.name+" score", 1, user.Score)
+		}
+
+		user := smallUser{}
+		err := optional.ApplyPatch(&user, struct{ Score optional.Value[float64] }{optional.NewValue(3.0)})
+		expect(t, "whole float error", false, err != nil)
+		expect(t, "whole float score", 3, user.Score)
+	})
+	t.Run("OptionalTypeMismatch", func(t *testing.T) {
+		user := newPatchUser()
+		patch := struct{ Nickname optional.Value[int] }{optional.NewValue(1)}
+		expect(t, "error", true, optional.ApplyPatch(&user, patch) != nil)
+	})
+	t.Run("NestedMismatch", func(t *testing.T) {
+		user := newPatchUser()
+		patch := struct {
+			Name patchAddressPatch
+		}{Name: patchAddressPatch{City: optional.NewValue("Oslo")}}
+		expect(t, "error", true, optional.ApplyPatch(&user, patch) != nil)
+	})
+	t.Run("InvalidArguments", func(t *testing.T) {
+		user := newPatchUser()
+		expect(t, "non-pointer", true, optional.ApplyPatch(user, patchUserPatch{}) != nil)
+		expect(t, "non-struct", true, optional.ApplyPatch(&user, 5) != nil)
+		expect(t, "nil patch", true, optional.ApplyPatch(&user, (*patchUserPatch)(nil)) == nil)
+		expect(t, "untyped nil patch", true, optional.ApplyPatch(&user, nil) == nil)
+	})
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("Changes", func(t *testing.T) {
+		old := newPatchUser()
+		updated := newPatchUser()
+		updated.Name = "Bar"
+		updated.Address.Zip = "0151"
+		updated.Billing = &patchAddress{City: "Bergen"}
+
+		var patch patchUserPatch
+		if err := optional.Diff(&patch, old, &updated); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "name", "Bar", patch.Name.MustGet())
+		expect(t, "age set", false, patch.Age.IsSet())
+		expect(t, "nickname set", false, patch.Nickname.IsSet())
+		expect(t, "city set", false, patch.Address.City.IsSet())
+		expect(t, "zip", "0151", patch.Address.Zip.MustGet())
+		expect(t, "billing city", "Bergen", patch.Billing.City.MustGet())
+		expect(t, "billing zip set", false, patch.Billing.Zip.IsSet())
+
+		if err := optional.ApplyPatch(&old, patch); err != nil {
+			t.Fatal(err)
+		}
+		changes, err := optional.CompareStructs(old, updated)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "round trip changes", 0, len(changes))
+	})
+	t.Run("NoChanges", func(t *testing.T) {
+		var patch patchUserPatch
+		if err := optional.Diff(&patch, newPatchUser(), newPatchUser()); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "name set", false, patch.Name.IsSet())
+		expect(t, "billing allocated", false, patch.Billing != nil)
+	})
+	t.Run("Unset", func(t *testing.T) {
+		updated := newPatchUser()
+		updated.Nickname.Reset()
+		var patch patchUserPatch
+		if err := optional.Diff(&patch, newPatchUser(), updated); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "nickname set", false, patch.Nickname.IsSet())
+	})
+	t.Run("Errors", func(t *testing.T) {
+		var patch patchUserPatch
+		expect(t, "non-pointer", true, optional.Diff(patch, newPatchUser(), newPatchUser()) != nil)
+		expect(t, "mismatched", true, optional.Diff(&patch, newPatchUser(), patchAddress{}) != nil)
+		expect(t, "nil old", true, optional.Diff(&patch, nil, newPatchUser()) != nil)
+		expect(t, "nil new", true, optional.Diff(&patch, newPatchUser(), nil) != nil)
+
+		var typo struct{ Nmae optional.Value[string] }
+		expect(t, "no match", true, optional.Diff(&typo, newPatchUser(), newPatchUser()) != nil)
+
+		var wrong struct{ Name optional.Value[int] }
+		updated := newPatchUser()
+		updated.Name = "Bar"
+		expect(t, "type mismatch", true, optional.Diff(&wrong, newPatchUser(), updated) != nil)
+	})
+}
@@ -0,0 +1,53 @@
+package optional
+
+import "errors"
+
+var (
+	// ErrPresenceBitmapTooShort is returned by UnpackPresence when the bitmap
+	// does not have a bit for every requested value
+	ErrPresenceBitmapTooShort = errors.New("optional: presence bitmap too short")
+	// ErrPresenceValueCount is returned by UnpackPresence when the number of
+	// dense values does not match the number of bits set in the bitmap
+	ErrPresenceValueCount = errors.New("optional: presence value count mismatch")
+)
+
+// PackPresence separates a batch of optional values into a presence bitmap
+// and a dense slice containing only the set values, in order.
+// bit i of the bitmap (least significant bit first) is set if values[i] is set
+func PackPresence[T any](values []Value[T]) ([]byte, []T) {
+	bitmap := make([]byte, (len(values)+7)/8)
+	dense := make([]T, 0, len(values))
+	for i, v := range values {
+		if !v.set {
+			continue
+		}
+		bitmap[i/8] |= 1 << (i % 8)
+		dense = append(dense, v.value)
+	}
+	return bitmap, dense
+}
+
+// UnpackPresence is the inverse of PackPresence, rebuilding a batch of n
+// optional values out of a presence bitmap and its dense values
+func UnpackPresence[T any](n int, bitmap []byte, dense []T) ([]Value[T], error) {
+	if len(bitmap) < (n+7)/8 {
+		return nil, ErrPresenceBitmapTooShort
+	}
+
+	values := make([]Value[T], n)
+	next := 0
+	for i := range values {
+		if bitmap[i/8]&(1<<(i%8)) == 0 {
+			continue
+		}
+		if next >= len(dense) {
+			return nil, ErrPresenceValueCount
+		}
+		values[i].Set(dense[next])
+		next++
+	}
+	if next != len(dense) {
+		return nil, ErrPresenceValueCount
+	}
+	return values, nil
+}
@@ -0,0 +1,57 @@
+package optional_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestPackPresence(t *testing.T) {
+	values := make([]optional.Value[int], 10)
+	values[0].Set(1)
+	values[3].Set(0)
+	values[9].Set(9)
+
+	bitmap, dense := optional.PackPresence(values)
+	expect(t, "len(bitmap)", 2, len(bitmap))
+	expect(t, "bitmap[0]", byte(0b00001001), bitmap[0])
+	expect(t, "bitmap[1]", byte(0b00000010), bitmap[1])
+	expect(t, "len(dense)", 3, len(dense))
+	expect(t, "dense[1]", 0, dense[1])
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		observed, err := optional.UnpackPresence(len(values), bitmap, dense)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "len", len(values), len(observed))
+		for i := range values {
+			if observed[i] != values[i] {
+				t.Fatalf("expected values[%d] to be %+v, got %+v", i, values[i], observed[i])
+			}
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		bitmap, dense := optional.PackPresence[string](nil)
+		observed, err := optional.UnpackPresence(0, bitmap, dense)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "len", 0, len(observed))
+	})
+	t.Run("BitmapTooShort", func(t *testing.T) {
+		_, err := optional.UnpackPresence(len(values), bitmap[:1], dense)
+		if !errors.Is(err, optional.ErrPresenceBitmapTooShort) {
+			t.Fatalf("expected ErrPresenceBitmapTooShort, got %v", err)
+		}
+	})
+	t.Run("ValueCount", func(t *testing.T) {
+		if _, err := optional.UnpackPresence(len(values), bitmap, dense[:2]); !errors.Is(err, optional.ErrPresenceValueCount) {
+			t.Fatalf("expected ErrPresenceValueCount, got %v", err)
+		}
+		if _, err := optional.UnpackPresence(len(values), bitmap, append(dense, 10)); !errors.Is(err, optional.ErrPresenceValueCount) {
+			t.Fatalf("expected ErrPresenceValueCount, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,20 @@
+package optional
+
+// FromPtr constructs a Value out of a pointer-for-optional.
+// a nil pointer results in an unset value
+func FromPtr[T any](ptr *T) Value[T] {
+	if ptr == nil {
+		return Value[T]{}
+	}
+	return NewValue(*ptr)
+}
+
+// Ptr returns a pointer to a copy of the value, if it is set.
+// otherwise, it returns nil
+func (o Value[T]) Ptr() *T {
+	if !o.set {
+		return nil
+	}
+	value := o.value
+	return &value
+}
@@ -0,0 +1,35 @@
+package optional_test
+
+import (
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestFromPtr(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		expect(t, "set", false, optional.FromPtr[int](nil).IsSet())
+	})
+	t.Run("NonNil", func(t *testing.T) {
+		value := 5
+		target := optional.FromPtr(&value)
+		value = 10
+		expect(t, "value", 5, target.MustGet())
+	})
+}
+
+func TestValuePtr(t *testing.T) {
+	t.Run("Unset", func(t *testing.T) {
+		var target optional.Value[int]
+		if target.Ptr() != nil {
+			t.Fatal("expected nil pointer")
+		}
+	})
+	t.Run("Set", func(t *testing.T) {
+		target := optional.NewValue(5)
+		ptr := target.Ptr()
+		expect(t, "value", 5, *ptr)
+		*ptr = 10
+		expect(t, "original", 5, target.MustGet())
+	})
+}
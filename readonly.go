@@ -0,0 +1,55 @@
+package optional
+
+// ReadOnly is an immutable view of an optional value.
+// It exposes the accessors of Value, but none of its mutators, so
+// optional state can be handed out without risking callers changing it.
+type ReadOnly[T any] struct {
+	v Value[T]
+}
+
+// Freeze returns an immutable view of the value
+func (o Value[T]) Freeze() ReadOnly[T] {
+	return ReadOnly[T]{v: o}
+}
+
+// IsSet returns true if the value is set
+func (r ReadOnly[T]) IsSet() bool {
+	return r.v.IsSet()
+}
+
+// IsZero returns true if the value is unset, for omitzero and omitempty
+func (r ReadOnly[T]) IsZero() bool {
+	return r.v.IsZero()
+}
+
+// Get returns the value and its set flag
+func (r ReadOnly[T]) Get() (T, bool) {
+	return r.v.Get()
+}
+
+// Map returns a new view holding the result of fn applied to the value,
+// if it is set. otherwise, it returns an unset view
+func (r ReadOnly[T]) Map(fn func(T) T) ReadOnly[T] {
+	if !r.v.set {
+		return ReadOnly[T]{}
+	}
+	return NewValue(fn(r.v.value)).Freeze()
+}
+
+// Thaw returns a mutable copy of the value.
+// changes made to the copy are not reflected in the view
+func (r ReadOnly[T]) Thaw() Value[T] {
+	return r.v
+}
+
+// MarshalJSON outputs the value of the view, if it is set.
+// otherwise, it returns nil
+func (r ReadOnly[T]) MarshalJSON() ([]byte, error) {
+	return r.v.MarshalJSON()
+}
+
+// MarshalYAML outputs the value of the view, if it is set.
+// otherwise, it returns nil
+func (r ReadOnly[T]) MarshalYAML() (any, error) {
+	return r.v.MarshalYAML()
+}
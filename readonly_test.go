@@ -0,0 +1,41 @@
+package optional_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestReadOnly(t *testing.T) {
+	t.Run("Freeze", func(t *testing.T) {
+		source := optional.NewValue(5)
+		frozen := source.Freeze()
+		source.Set(10)
+		encounteredValue, encounteredSet := frozen.Get()
+		expect(t, "set", true, encounteredSet)
+		expect(t, "value", 5, encounteredValue)
+	})
+	t.Run("Thaw", func(t *testing.T) {
+		frozen := optional.NewValue("Foo").Freeze()
+		thawed := frozen.Thaw()
+		thawed.Reset()
+		expect(t, "frozen set", true, frozen.IsSet())
+		expect(t, "thawed set", false, thawed.IsSet())
+	})
+	t.Run("Map", func(t *testing.T) {
+		double := func(v int) int { return v * 2 }
+		encounteredValue, encounteredSet := optional.NewValue(21).Freeze().Map(double).Get()
+		expect(t, "set", true, encounteredSet)
+		expect(t, "value", 42, encounteredValue)
+		var unset optional.Value[int]
+		expect(t, "unset", false, unset.Freeze().Map(double).IsSet())
+	})
+	t.Run("JSON", func(t *testing.T) {
+		blob, err := json.Marshal(optional.NewValue(5).Freeze())
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", "5", string(blob))
+	})
+}
@@ -0,0 +1,82 @@
+package optional
+
+import "reflect"
+
+// anyOptional is implemented by the optional containers in this package.
+// It lets the reflection-based helpers inspect an optional without
+// knowing its element type at compile time.
+type anyOptional interface {
+	IsSet() bool
+	AsAny() (any, bool)
+	elemType() reflect.Type
+}
+
+var anyOptionalType = reflect.TypeOf((*anyOptional)(nil)).Elem()
+
+// anySetter is implemented by pointers to the optional containers
+type anySetter interface {
+	setAny(val any) error
+}
+
+// IsOptionalType returns true if the type is one of the optional containers
+// in this package (Value, Boxed, ReadOnly, Strict, Sensitive, or Encoded)
+func IsOptionalType(t reflect.Type) bool {
+	return t != nil && t.Kind() == reflect.Struct && t.Implements(anyOptionalType)
+}
+
+// ElemType returns the element type of an optional type (e.g. int for Value[int]).
+// it returns nil if t is not an optional type
+func ElemType(t reflect.Type) reflect.Type {
+	if !IsOptionalType(t) {
+		return nil
+	}
+	return reflect.Zero(t).Interface().(anyOptional).elemType()
+}
+
+// NewOfType returns a pointer to a new, unset optional of type t.
+// it returns the zero reflect.Value if t is not an optional type
+//
+// Go cannot instantiate generic types at runtime, so t must be the optional
+// type itself (e.g. Value[int]) and not its element type.
+func NewOfType(t reflect.Type) reflect.Value {
+	if !IsOptionalType(t) {
+		return reflect.Value{}
+	}
+	return reflect.New(t)
+}
+
+// UnwrapAny returns the value held by the optional rv, as a reflect.Value
+// of the optional's element type, along with its set flag. an unset optional
+// yields the element type's zero value. anything that is not an optional is
+// returned unchanged, as set, so that plain and optional struct fields can be
+// read alike. an invalid rv yields an invalid value, as unset.
+//
+// like rv.Interface, it panics if rv was obtained through unexported fields.
+func UnwrapAny(rv reflect.Value) (reflect.Value, bool) {
+	if !rv.IsValid() {
+		return rv, false
+	}
+	if !IsOptionalType(rv.Type()) {
+		return rv, true
+	}
+
+	opt := rv.Interface().(anyOptional)
+	out := reflect.New(opt.elemType()).Elem()
+	value, set := opt.AsAny()
+	if set && value != nil {
+		out.Set(reflect.ValueOf(value))
+	}
+	return out, set
+}
+
+func (o Value[T]) elemType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (o Boxed[T]) elemType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (r ReadOnly[T]) elemType() reflect.Type {
+	return r.v.elemType()
+}
@@ -0,0 +1,86 @@
+package optional_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestIsOptionalType(t *testing.T) {
+	expect(t, "Value", true, optional.IsOptionalType(reflect.TypeOf(optional.Value[int]{})))
+	expect(t, "Boxed", true, optional.IsOptionalType(reflect.TypeOf(optional.Boxed[int]{})))
+	expect(t, "ReadOnly", true, optional.IsOptionalType(reflect.TypeOf(optional.ReadOnly[int]{})))
+	expect(t, "*Value", false, optional.IsOptionalType(reflect.TypeOf(&optional.Value[int]{})))
+	expect(t, "int", false, optional.IsOptionalType(reflect.TypeOf(0)))
+	expect(t, "struct", false, optional.IsOptionalType(reflect.TypeOf(struct{}{})))
+	expect(t, "nil", false, optional.IsOptionalType(nil))
+}
+
+func TestElemType(t *testing.T) {
+	type testStruct struct{}
+	if observed := optional.ElemType(reflect.TypeOf(optional.Value[testStruct]{})); observed != reflect.TypeOf(testStruct{}) {
+		t.Fatalf("expected testStruct, got %v", observed)
+	}
+	if observed := optional.ElemType(reflect.TypeOf(optional.Boxed[[]string]{})); observed != reflect.TypeOf([]string{}) {
+		t.Fatalf("expected []string, got %v", observed)
+	}
+	if observed := optional.ElemType(reflect.TypeOf(0)); observed != nil {
+		t.Fatalf("expected nil, got %v", observed)
+	}
+}
+
+func TestNewOfType(t *testing.T) {
+	ptr := optional.NewOfType(reflect.TypeOf(optional.Value[string]{}))
+	target, ok := ptr.Interface().(*optional.Value[string])
+	if !ok {
+		t.Fatalf("expected *optional.Value[string], got %v", ptr.Type())
+	}
+	expect(t, "set", false, target.IsSet())
+	target.Set("Foo")
+	value, _ := ptr.Elem().Interface().(optional.Value[string]).Get()
+	expect(t, "value", "Foo", value)
+
+	expect(t, "invalid", false, optional.NewOfType(reflect.TypeOf("")).IsValid())
+}
+
+func TestUnwrapAny(t *testing.T) {
+	var record struct {
+		Name  optional.Value[string]
+		Count optional.Boxed[int]
+		Err   optional.Value[error]
+		Plain float64
+	}
+	record.Name.Set("Foo")
+	record.Err.Set(nil)
+	record.Plain = 1.5
+	rv := reflect.ValueOf(record)
+
+	t.Run("Set", func(t *testing.T) {
+		observed, set := optional.UnwrapAny(rv.Field(0))
+		expect(t, "set", true, set)
+		expect(t, "value", "Foo", observed.String())
+	})
+	t.Run("Unset", func(t *testing.T) {
+		observed, set := optional.UnwrapAny(rv.Field(1))
+		expect(t, "set", false, set)
+		expect(t, "type", true, observed.Type() == reflect.TypeOf(0))
+		expect(t, "zero", true, observed.IsZero())
+	})
+	t.Run("NilInterface", func(t *testing.T) {
+		observed, set := optional.UnwrapAny(rv.Field(2))
+		expect(t, "set", true, set)
+		expect(t, "kind", true, observed.Kind() == reflect.Interface)
+		expect(t, "nil", true, observed.IsNil())
+	})
+	t.Run("Plain", func(t *testing.T) {
+		observed, set := optional.UnwrapAny(rv.Field(3))
+		expect(t, "set", true, set)
+		expect(t, "value", 1.5, observed.Float())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		observed, set := optional.UnwrapAny(reflect.Value{})
+		expect(t, "set", false, set)
+		expect(t, "valid", false, observed.IsValid())
+	})
+}
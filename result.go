@@ -0,0 +1,79 @@
+package optional
+
+// Result is either a value or an error. It is the companion of Value for
+// pipelines where a missing value needs an explanation
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok constructs a Result holding value
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+// Err constructs a Result holding err, which should not be nil
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// NewResult constructs a Result from the results of a function returning
+// (T, error). value is discarded if err is not nil
+func NewResult[T any](value T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(value)
+}
+
+// OkOr converts a Value into a Result holding its value, if it is set.
+// otherwise, the Result holds err
+func OkOr[T any](v Value[T], err error) Result[T] {
+	if v.set {
+		return Ok(v.value)
+	}
+	return Err[T](err)
+}
+
+// IsOk returns true if the Result holds a value
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// Get returns the value and the error of the Result
+func (r Result[T]) Get() (T, error) {
+	return r.value, r.err
+}
+
+// Err returns the error of the Result, if it holds one.
+// otherwise, it returns nil
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// Optional converts the Result into a Value holding its value, if it holds
+// one. otherwise, the error is discarded and it returns an unset Value
+func (r Result[T]) Optional() Value[T] {
+	if r.err != nil {
+		return Value[T]{}
+	}
+	return NewValue(r.value)
+}
+
+// MapResult returns a Result holding the result of fn applied to the value of
+// r, if r holds a value. otherwise, it returns a Result holding r's error
+func MapResult[T, U any](r Result[T], fn func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return Ok(fn(r.value))
+}
+
+// AndThen returns the result of fn applied to the value of r, if r holds a
+// value. otherwise, it returns a Result holding r's error
+func AndThen[T, U any](r Result[T], fn func(T) Result[U]) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return fn(r.value)
+}
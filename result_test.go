@@ -0,0 +1,51 @@
+package optional_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestResult(t *testing.T) {
+	errTest := errors.New("test")
+
+	t.Run("Ok", func(t *testing.T) {
+		r := optional.Ok(5)
+		value, err := r.Get()
+		expect(t, "value", 5, value)
+		expect(t, "err nil", true, err == nil)
+		expect(t, "ok", true, r.IsOk())
+		expect(t, "optional", 5, r.Optional().MustGet())
+	})
+	t.Run("Err", func(t *testing.T) {
+		r := optional.Err[int](errTest)
+		_, err := r.Get()
+		expect(t, "err", true, errors.Is(err, errTest))
+		expect(t, "Err", true, errors.Is(r.Err(), errTest))
+		expect(t, "ok", false, r.IsOk())
+		expect(t, "optional set", false, r.Optional().IsSet())
+	})
+	t.Run("NewResult", func(t *testing.T) {
+		expect(t, "ok", 42, optional.NewResult(strconv.Atoi("42")).Optional().MustGet())
+		expect(t, "err", false, optional.NewResult(strconv.Atoi("x")).IsOk())
+	})
+	t.Run("OkOr", func(t *testing.T) {
+		expect(t, "set", 1, optional.OkOr(optional.NewValue(1), errTest).Optional().MustGet())
+		expect(t, "unset", true, errors.Is(optional.OkOr(optional.Value[int]{}, errTest).Err(), errTest))
+	})
+	t.Run("MapResult", func(t *testing.T) {
+		double := func(v int) int { return v * 2 }
+		expect(t, "ok", 4, optional.MapResult(optional.Ok(2), double).Optional().MustGet())
+		expect(t, "err", true, errors.Is(optional.MapResult(optional.Err[int](errTest), double).Err(), errTest))
+	})
+	t.Run("AndThen", func(t *testing.T) {
+		parse := func(s string) optional.Result[int] {
+			return optional.NewResult(strconv.Atoi(s))
+		}
+		expect(t, "ok", 7, optional.AndThen(optional.Ok("7"), parse).Optional().MustGet())
+		expect(t, "inner err", false, optional.AndThen(optional.Ok("x"), parse).IsOk())
+		expect(t, "outer err", true, errors.Is(optional.AndThen(optional.Err[string](errTest), parse).Err(), errTest))
+	})
+}
@@ -0,0 +1,119 @@
+package optional
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ScanRow scans the current row of rows into the struct dest points to,
+// matching each column to the field with the same name in its `db` tag, or
+// failing that, to the field with the same name, ignoring case. Fields
+// tagged `db:"-"` are skipped, and embedded structs without a tag have their
+// fields promoted. Value[T] fields scan SQL NULL as unset, while other
+// fields follow the rules of rows.Scan.
+//
+// It is an error for a column to have no matching field; fields without a
+// matching column are left untouched.
+func ScanRow(rows *sql.Rows, dest any) error {
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("optional: scan destination must be a non-nil pointer to a struct, got %T", dest)
+	}
+	dv = dv.Elem()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	fields := make(map[string][]int)
+	collectScanFields(dv.Type(), nil, fields)
+
+	targets := make([]any, len(columns))
+	for i, column := range columns {
+		index, ok := lookupScanField(fields, column)
+		if !ok {
+			return fmt.Errorf("optional: no field for column %q in %v", column, dv.Type())
+		}
+		targets[i] = scanField(dv, index).Addr().Interface()
+	}
+	return rows.Scan(targets...)
+}
+
+// collectScanFields maps the column names of the fields of t to their
+// indexes. fields found at a shallower depth take precedence, as they would
+// when promoted
+func collectScanFields(t reflect.Type, prefix []int, fields map[string][]int) {
+	var embedded []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		tag := field.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" && field.Anonymous {
+			embedded = append(embedded, field)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, ok := fields[name]; !ok {
+			fields[name] = append(append([]int(nil), prefix...), i)
+		}
+	}
+
+	for _, field := range embedded {
+		ft := field.Type
+		if ft.Kind() == reflect.Pointer {
+			if !field.IsExported() {
+				// a nil pointer to it could not be allocated
+				continue
+			}
+			ft = ft.Elem()
+		}
+		if ft.Kind() != reflect.Struct || IsOptionalType(ft) {
+			continue
+		}
+		collectScanFields(ft, append(append([]int(nil), prefix...), field.Index...), fields)
+	}
+}
+
+// lookupScanField finds the field for column, preferring an exact match
+// but falling back to a case-insensitive one
+func lookupScanField(fields map[string][]int, column string) ([]int, bool) {
+	if index, ok := fields[column]; ok {
+		return index, true
+	}
+	for name, index := range fields {
+		if strings.EqualFold(name, column) {
+			return index, true
+		}
+	}
+	return nil, false
+}
+
+// scanField returns the field of v at index, allocating the nil pointers to
+// embedded structs along the way
+func scanField(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
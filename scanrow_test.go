@@ -0,0 +1,176 @@
+package optional_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+// testRowsDriver serves fixed result sets, keyed by query
+type testRowsDriver struct{}
+
+type testResult struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+var testResults = map[string]testResult{
+	"users": {
+		columns: []string{"id", "name", "Email", "age", "created_by"},
+		rows: [][]driver.Value{
+			{int64(1), "Foo", "foo@example.com", int64(42), "admin"},
+			{int64(2), nil, nil, nil, nil},
+		},
+	},
+	"unknown": {
+		columns: []string{"id", "nickname"},
+		rows:    [][]driver.Value{{int64(1), "Bar"}},
+	},
+}
+
+func (testRowsDriver) Open(string) (driver.Conn, error) {
+	return testRowsConn{}, nil
+}
+
+type testRowsConn struct{}
+
+func (testRowsConn) Prepare(query string) (driver.Stmt, error) {
+	return testRowsStmt(query), nil
+}
+
+func (testRowsConn) Close() error {
+	return nil
+}
+
+func (testRowsConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+type testRowsStmt string
+
+func (testRowsStmt) Close() error {
+	return nil
+}
+
+func (testRowsStmt) NumInput() int {
+	return 0
+}
+
+func (testRowsStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s testRowsStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &testRows{result: testResults[string(s)]}, nil
+}
+
+type testRows struct {
+	result testResult
+	next   int
+}
+
+func (r *testRows) Columns() []string {
+	return r.result.columns
+}
+
+func (r *testRows) Close() error {
+	return nil
+}
+
+func (r *testRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.next])
+	r.next++
+	return nil
+}
+
+func init() {
+	sql.Register("optional-test-rows", testRowsDriver{})
+}
+
+func queryTestRows(t *testing.T, query string) *sql.Rows {
+	t.Helper()
+	db, err := sql.Open("optional-test-rows", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	rows, err := db.Query(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { rows.Close() })
+	return rows
+}
+
+type testAudit struct {
+	CreatedBy optional.Value[string] `db:"created_by"`
+}
+
+type testUser struct {
+	ID       int64                  `db:"id"`
+	Name     optional.Value[string] `db:"name"`
+	Email    optional.Value[string]
+	Age      optional.Value[int32] `db:"age"`
+	Password string                `db:"-"`
+	testAudit
+}
+
+func TestScanRow(t *testing.T) {
+	rows := queryTestRows(t, "users")
+
+	var users []testUser
+	for rows.Next() {
+		var user testUser
+		if err := optional.ScanRow(rows, &user); err != nil {
+			t.Fatal(err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, "len", 2, len(users))
+
+	t.Run("Set", func(t *testing.T) {
+		user := users[0]
+		expect(t, "id", int64(1), user.ID)
+		expect(t, "name", "Foo", user.Name.MustGet())
+		expect(t, "email", "foo@example.com", user.Email.MustGet())
+		expect(t, "age", int32(42), user.Age.MustGet())
+		expect(t, "created_by", "admin", user.CreatedBy.MustGet())
+	})
+	t.Run("Null", func(t *testing.T) {
+		user := users[1]
+		expect(t, "id", int64(2), user.ID)
+		expect(t, "name", false, user.Name.IsSet())
+		expect(t, "email", false, user.Email.IsSet())
+		expect(t, "age", false, user.Age.IsSet())
+		expect(t, "created_by", false, user.CreatedBy.IsSet())
+	})
+	t.Run("UnknownColumn", func(t *testing.T) {
+		rows := queryTestRows(t, "unknown")
+		if !rows.Next() {
+			t.Fatal("expected a row")
+		}
+		var user testUser
+		if err := optional.ScanRow(rows, &user); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+	t.Run("NotStruct", func(t *testing.T) {
+		var id int64
+		if err := optional.ScanRow(rows, &id); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+		if err := optional.ScanRow(rows, testUser{}); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package optional
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// redactedText is printed in place of the value of a Sensitive
+const redactedText = "***"
+
+// Sensitive is an optional value that masks its contents whenever it is
+// printed, logged, or dumped, while still marshaling normally.
+// It is meant for optional secrets such as tokens or personal data.
+//
+// json, yaml, text, and gob encodings hold the value itself, as does a
+// database/sql Scan. Value returns the optional rather than a driver.Value,
+// so a Sensitive is written to a database with SQLValue instead.
+type Sensitive[T any] struct {
+	v Value[T]
+}
+
+// NewSensitive constructs a Sensitive structure with a value already set into it
+func NewSensitive[T any](value T) Sensitive[T] {
+	return Sensitive[T]{v: NewValue(value)}
+}
+
+// Sensitive converts the value into a Sensitive
+func (o Value[T]) Sensitive() Sensitive[T] {
+	return Sensitive[T]{v: o}
+}
+
+// Value converts the Sensitive into a Value
+func (s Sensitive[T]) Value() Value[T] {
+	return s.v
+}
+
+// Reset clears the memory on the value
+func (s *Sensitive[T]) Reset() {
+	s.v.Reset()
+}
+
+// Set updates the value and sets the set flag
+func (s *Sensitive[T]) Set(value T) {
+	s.v.Set(value)
+}
+
+// IsSet returns true if the value is set
+func (s Sensitive[T]) IsSet() bool {
+	return s.v.set
+}
+
+// IsZero returns true if the value is unset, for omitzero and omitempty
+func (s Sensitive[T]) IsZero() bool {
+	return s.v.IsZero()
+}
+
+// Get returns the value and its set flag
+func (s Sensitive[T]) Get() (T, bool) {
+	return s.v.Get()
+}
+
+// String renders the value masked as Some(***), if it is set.
+// otherwise, it returns None
+func (s Sensitive[T]) String() string {
+	if !s.v.set {
+		return "None"
+	}
+	return "Some(" + redactedText + ")"
+}
+
+// GoString renders the value masked, which is what the %#v verb prints
+func (s Sensitive[T]) GoString() string {
+	elem := reflect.TypeOf((*T)(nil)).Elem()
+	if !s.v.set {
+		return "optional.Sensitive[" + elem.String() + "]{}"
+	}
+	return "optional.NewSensitive[" + elem.String() + "](" + redactedText + ")"
+}
+
+// Format masks the value under every verb, printing GoString for %#v and
+// String for the rest
+func (s Sensitive[T]) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		io.WriteString(f, s.GoString())
+	case verb == 'q':
+		io.WriteString(f, strconv.Quote(s.String()))
+	default:
+		io.WriteString(f, s.String())
+	}
+}
+
+// MarshalJSON outputs the value of the Sensitive, if it is set.
+// otherwise, it returns nil
+func (s Sensitive[T]) MarshalJSON() ([]byte, error) {
+	return s.v.MarshalJSON()
+}
+
+// UnmarshalJSON unmarshals a value out of json and safely into our struct
+func (s *Sensitive[T]) UnmarshalJSON(data []byte) error {
+	return s.v.UnmarshalJSON(data)
+}
+
+// MarshalYAML outputs the value of the Sensitive, if it is set.
+// otherwise, it returns nil
+func (s Sensitive[T]) MarshalYAML() (any, error) {
+	return s.v.MarshalYAML()
+}
+
+// UnmarshalYAML unmarshals a value out of yaml and safely into our struct
+func (s *Sensitive[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	return s.v.UnmarshalYAML(unmarshal)
+}
+
+// MarshalText outputs the value of the Sensitive as text, if it is set.
+// otherwise, it returns empty text
+func (s Sensitive[T]) MarshalText() ([]byte, error) {
+	return s.v.MarshalText()
+}
+
+// UnmarshalText unmarshals a value out of text and safely into our struct.
+// empty text resets the value
+func (s *Sensitive[T]) UnmarshalText(text []byte) error {
+	return s.v.UnmarshalText(text)
+}
+
+// GobEncode outputs the set flag of the Sensitive, followed by the value
+// itself if it is set
+func (s Sensitive[T]) GobEncode() ([]byte, error) {
+	return s.v.GobEncode()
+}
+
+// GobDecode decodes a value out of gob and safely into our struct
+func (s *Sensitive[T]) GobDecode(data []byte) error {
+	return s.v.GobDecode(data)
+}
+
+// SQLValue outputs the value of the Sensitive as a database/sql driver
+// value, if it is set. otherwise, it returns nil (SQL NULL)
+func (s Sensitive[T]) SQLValue() (driver.Value, error) {
+	return s.v.Value()
+}
+
+// Scan reads a database/sql column value safely into our struct.
+// SQL NULL resets the value
+func (s *Sensitive[T]) Scan(src any) error {
+	return s.v.Scan(src)
+}
+
+// AsAny returns the value as an `any` along with its set flag, for code that
+// needs to inspect an optional without knowing its type at compile time
+func (s Sensitive[T]) AsAny() (any, bool) {
+	return s.v.AsAny()
+}
+
+func (s Sensitive[T]) elemType() reflect.Type {
+	return s.v.elemType()
+}
+
+// SetAny sets the value to val, converting it to T as FromAny does.
+// a nil val resets the value
+func (s *Sensitive[T]) SetAny(val any) error {
+	return s.v.SetAny(val)
+}
+
+func (s *Sensitive[T]) setAny(val any) error {
+	return s.v.setAny(val)
+}
+
+func (s Sensitive[T]) redacted() {}
+
+// redactor is implemented by the optional containers whose value must not
+// be printed
+type redactor interface {
+	redacted()
+}
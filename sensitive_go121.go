@@ -0,0 +1,10 @@
+//go:build go1.21
+
+package optional
+
+import "log/slog"
+
+// LogValue masks the value when it is logged with log/slog
+func (s Sensitive[T]) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
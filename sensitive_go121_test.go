@@ -0,0 +1,24 @@
+//go:build go1.21
+
+package optional_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestSensitiveLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Info("login", "token", optional.NewSensitive("hunter2"))
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Fatalf("expected log output to be masked, got %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "token=Some(***)") {
+		t.Fatalf("unexpected log output %s", buf.String())
+	}
+}
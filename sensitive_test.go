@@ -0,0 +1,98 @@
+package optional_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestSensitive(t *testing.T) {
+	type testCredentials struct {
+		User  optional.Value[string]
+		Token optional.Sensitive[string]
+	}
+	creds := testCredentials{
+		User:  optional.NewValue("foo"),
+		Token: optional.NewSensitive("hunter2"),
+	}
+
+	t.Run("Format", func(t *testing.T) {
+		for _, verb := range []string{"%v", "%+v", "%#v", "%s"} {
+			if observed := fmt.Sprintf(verb, creds); strings.Contains(observed, "hunter2") {
+				t.Errorf("expected %s output to be masked, got %s", verb, observed)
+			}
+		}
+		for _, verb := range []string{"%d", "%x", "%+v", "%q", "%10s"} {
+			if observed := fmt.Sprintf(verb, creds.Token); strings.Contains(observed, "hunter2") || strings.Contains(observed, "68756e74657232") {
+				t.Errorf("expected %s output to be masked, got %s", verb, observed)
+			}
+		}
+		expect(t, "%d", "Some(***)", fmt.Sprintf("%d", optional.NewSensitive(1234)))
+		expect(t, "%x", "Some(***)", fmt.Sprintf("%x", optional.NewSensitive(1234)))
+		expect(t, "%+v", "{Token:Some(***)}", fmt.Sprintf("%+v", struct{ Token optional.Sensitive[int] }{optional.NewSensitive(1234)}))
+		expect(t, "%T", "optional.Sensitive[int]", fmt.Sprintf("%T", optional.NewSensitive(1234)))
+		expect(t, "%#v", "optional.NewSensitive[int](***)", fmt.Sprintf("%#v", optional.NewSensitive(1234)))
+		expect(t, "String", "Some(***)", creds.Token.String())
+		expect(t, "String (unset)", "None", optional.Sensitive[string]{}.String())
+	})
+	t.Run("Dump", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := optional.Dump(&buf, creds); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "dump", "User: set foo\nToken: set ***\n", buf.String())
+	})
+	t.Run("JSON", func(t *testing.T) {
+		blob, err := json.Marshal(creds)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", `{"User":"foo","Token":"hunter2"}`, string(blob))
+
+		var observed testCredentials
+		if err := json.Unmarshal(blob, &observed); err != nil {
+			t.Fatal(err)
+		}
+		value, _ := observed.Token.Get()
+		expect(t, "token", "hunter2", value)
+	})
+	t.Run("Encodings", func(t *testing.T) {
+		text, err := creds.Token.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "text", "hunter2", string(text))
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(creds.Token); err != nil {
+			t.Fatal(err)
+		}
+		var decoded optional.Sensitive[string]
+		if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+			t.Fatal(err)
+		}
+		value, _ := decoded.Get()
+		expect(t, "gob", "hunter2", value)
+
+		var scanned optional.Sensitive[string]
+		if err := scanned.Scan([]byte("hunter2")); err != nil {
+			t.Fatal(err)
+		}
+		driverValue, err := scanned.SQLValue()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "sql", "hunter2", driverValue.(string))
+	})
+	t.Run("Value", func(t *testing.T) {
+		target := optional.NewValue("hunter2").Sensitive()
+		expect(t, "value", "hunter2", target.Value().MustGet())
+		target.Reset()
+		expect(t, "set", false, target.IsSet())
+	})
+}
@@ -0,0 +1,146 @@
+package optional
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// SingleFlight deduplicates concurrent lookups of the same key, in the manner
+// of golang.org/x/sync/singleflight, and additionally remembers lookups that
+// confirmed a key is absent (returned an unset value) for a configurable
+// window. This protects a backend from storms of requests for keys that do
+// not exist, which a cache of set values alone cannot do.
+//
+// The zero SingleFlight deduplicates lookups but does not remember absent
+// keys. A SingleFlight must not be copied after first use.
+//
+// Expired absent results are swept whenever the number remembered doubles,
+// so memory stays proportional to the keys confirmed absent within the last
+// AbsentTTL. MaxAbsent bounds it further.
+type SingleFlight[K comparable, V any] struct {
+	// AbsentTTL is how long a confirmed-absent result is remembered
+	AbsentTTL time.Duration
+	// MaxAbsent is the most absent results remembered at once, if positive.
+	// when it is reached, an arbitrary result is forgotten to make room
+	MaxAbsent int
+
+	mu     sync.Mutex
+	calls  map[K]*flightCall[V]
+	absent map[K]time.Time
+	// sweepAt is the number of absent results which triggers a sweep
+	sweepAt int
+}
+
+// minAbsentSweep is the fewest absent results which trigger a sweep
+const minAbsentSweep = 64
+
+// ErrSingleFlightPanic is returned by SingleFlight.Do to the callers waiting
+// on a lookup whose fn panicked. The panic itself propagates to the caller
+// which ran fn.
+var ErrSingleFlightPanic = errors.New("optional: single flight lookup panicked")
+
+type flightCall[V any] struct {
+	wg    sync.WaitGroup
+	value Value[V]
+	err   error
+}
+
+// NewSingleFlight constructs a SingleFlight which remembers confirmed-absent
+// results for absentTTL
+func NewSingleFlight[K comparable, V any](absentTTL time.Duration) *SingleFlight[K, V] {
+	return &SingleFlight[K, V]{AbsentTTL: absentTTL}
+}
+
+// Do returns the result of fn for key. If a lookup for key is already in
+// flight, Do waits for it and returns its result instead of calling fn.
+// If key was recently confirmed absent, Do returns an unset value without
+// calling fn. shared reports whether the result came from another caller's
+// lookup or from the absent cache. If fn panics, nothing is remembered, and
+// the callers waiting on the lookup get ErrSingleFlightPanic.
+func (s *SingleFlight[K, V]) Do(key K, fn func() (Value[V], error)) (value Value[V], shared bool, err error) {
+	s.mu.Lock()
+	if expiry, ok := s.absent[key]; ok {
+		if time.Now().Before(expiry) {
+			s.mu.Unlock()
+			return Value[V]{}, true, nil
+		}
+		delete(s.absent, key)
+	}
+	if c, ok := s.calls[key]; ok {
+		s.mu.Unlock()
+		c.wg.Wait()
+		return c.value, true, c.err
+	}
+	if s.calls == nil {
+		s.calls = make(map[K]*flightCall[V])
+	}
+	c := &flightCall[V]{}
+	c.wg.Add(1)
+	s.calls[key] = c
+	s.mu.Unlock()
+
+	normalReturn := false
+	defer func() {
+		if !normalReturn {
+			// fn panicked, so its result says nothing about key
+			c.value, c.err = Value[V]{}, ErrSingleFlightPanic
+		}
+		s.mu.Lock()
+		delete(s.calls, key)
+		if c.err == nil && !c.value.IsSet() && s.AbsentTTL > 0 {
+			s.rememberAbsent(key)
+		}
+		s.mu.Unlock()
+		c.wg.Done()
+	}()
+
+	c.value, c.err = fn()
+	normalReturn = true
+	return c.value, false, c.err
+}
+
+// rememberAbsent remembers key as absent, first making room for it.
+// s.mu must be held
+func (s *SingleFlight[K, V]) rememberAbsent(key K) {
+	now := time.Now()
+	if s.absent == nil {
+		s.absent = make(map[K]time.Time)
+	}
+	if _, ok := s.absent[key]; !ok {
+		if len(s.absent) >= s.sweepAt {
+			for k, expiry := range s.absent {
+				if !now.Before(expiry) {
+					delete(s.absent, k)
+				}
+			}
+			s.sweepAt = 2 * len(s.absent)
+			if s.sweepAt < minAbsentSweep {
+				s.sweepAt = minAbsentSweep
+			}
+		}
+		if s.MaxAbsent > 0 && len(s.absent) >= s.MaxAbsent {
+			for k := range s.absent {
+				delete(s.absent, k)
+				break
+			}
+		}
+	}
+	s.absent[key] = now.Add(s.AbsentTTL)
+}
+
+// AbsentLen returns the number of absent results remembered, including
+// expired ones which have not been swept yet
+func (s *SingleFlight[K, V]) AbsentLen() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.absent)
+}
+
+// Forget drops any remembered absent result for key, so the next call to Do
+// performs a fresh lookup. Use it when key is known to have been created.
+func (s *SingleFlight[K, V]) Forget(key K) {
+	s.mu.Lock()
+	delete(s.absent, key)
+	s.mu.Unlock()
+}
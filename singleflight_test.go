@@ -0,0 +1,162 @@
+package optional_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/heucuva/optional"
+)
+
+func TestSingleFlight(t *testing.T) {
+	t.Run("Deduplicates", func(t *testing.T) {
+		var sf optional.SingleFlight[string, int]
+		var calls int32
+		release := make(chan struct{})
+		started := make(chan struct{})
+
+		var wg sync.WaitGroup
+		results := make([]int, 5)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				value, _, err := sf.Do("key", func() (optional.Value[int], error) {
+					atomic.AddInt32(&calls, 1)
+					close(started)
+					<-release
+					return optional.NewValue(42), nil
+				})
+				if err != nil {
+					t.Error(err)
+				}
+				results[i] = value.MustGet()
+			}(i)
+			if i == 0 {
+				<-started
+			}
+		}
+		// give the remaining callers a chance to join the flight
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		expect(t, "calls", int32(1), atomic.LoadInt32(&calls))
+		for _, result := range results {
+			expect(t, "result", 42, result)
+		}
+	})
+	t.Run("AbsentCache", func(t *testing.T) {
+		sf := optional.NewSingleFlight[string, int](20 * time.Millisecond)
+
+		calls := 0
+		lookup := func() (optional.Value[int], error) {
+			calls++
+			return optional.Value[int]{}, nil
+		}
+
+		value, shared, _ := sf.Do("missing", lookup)
+		expect(t, "set", false, value.IsSet())
+		expect(t, "shared", false, shared)
+
+		_, shared, _ = sf.Do("missing", lookup)
+		expect(t, "shared", true, shared)
+		expect(t, "calls", 1, calls)
+
+		time.Sleep(30 * time.Millisecond)
+		sf.Do("missing", lookup)
+		expect(t, "calls after expiry", 2, calls)
+
+		sf.Forget("missing")
+		sf.Do("missing", lookup)
+		expect(t, "calls after forget", 3, calls)
+	})
+	t.Run("AbsentBounded", func(t *testing.T) {
+		lookup := func() (optional.Value[int], error) {
+			return optional.Value[int]{}, nil
+		}
+
+		// expired results are swept as distinct keys keep missing
+		sf := optional.NewSingleFlight[int, int](time.Millisecond)
+		for i := 0; i < 1000; i++ {
+			sf.Do(i, lookup)
+			if i%100 == 99 {
+				time.Sleep(2 * time.Millisecond)
+			}
+		}
+		if n := sf.AbsentLen(); n > 300 {
+			t.Errorf("expected expired results to be swept, but %d remain", n)
+		}
+
+		// live results are capped by MaxAbsent
+		sf = optional.NewSingleFlight[int, int](time.Hour)
+		sf.MaxAbsent = 10
+		for i := 0; i < 1000; i++ {
+			sf.Do(i, lookup)
+		}
+		expect(t, "capped", 10, sf.AbsentLen())
+		_, shared, _ := sf.Do(999, lookup)
+		expect(t, "latest remembered", true, shared)
+	})
+	t.Run("ErrorsNotCached", func(t *testing.T) {
+		sf := optional.NewSingleFlight[string, int](time.Minute)
+		calls := 0
+		lookup := func() (optional.Value[int], error) {
+			calls++
+			return optional.Value[int]{}, errors.New("backend down")
+		}
+		if _, _, err := sf.Do("key", lookup); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+		sf.Do("key", lookup)
+		expect(t, "calls", 2, calls)
+	})
+	t.Run("PanicNotCached", func(t *testing.T) {
+		sf := optional.NewSingleFlight[string, int](time.Minute)
+		release := make(chan struct{})
+		started := make(chan struct{})
+
+		panicked := make(chan any)
+		go func() {
+			defer func() { panicked <- recover() }()
+			sf.Do("key", func() (optional.Value[int], error) {
+				close(started)
+				<-release
+				panic("backend crashed")
+			})
+		}()
+		<-started
+
+		waiterErr := make(chan error)
+		go func() {
+			_, _, err := sf.Do("key", func() (optional.Value[int], error) {
+				return optional.Value[int]{}, errors.New("joined too late")
+			})
+			waiterErr <- err
+		}()
+		// give the waiter a chance to join the flight
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+
+		if r := <-panicked; r != "backend crashed" {
+			t.Fatalf("expected the panic to propagate, got %v", r)
+		}
+		if err := <-waiterErr; !errors.Is(err, optional.ErrSingleFlightPanic) {
+			t.Fatalf("expected ErrSingleFlightPanic, got %v", err)
+		}
+
+		calls := 0
+		value, shared, err := sf.Do("key", func() (optional.Value[int], error) {
+			calls++
+			return optional.NewValue(42), nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "calls", 1, calls)
+		expect(t, "shared", false, shared)
+		expect(t, "value", 42, value.MustGet())
+	})
+}
@@ -0,0 +1,114 @@
+package optional
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Strict is an optional value whose accessors refuse to hand out a zero
+// value when it is unset: Get returns an error and MustGet panics.
+// It is meant for domains where silently reading an unset value as its zero
+// value is a bug.
+type Strict[T any] struct {
+	v Value[T]
+}
+
+// NewStrict constructs a Strict structure with a value already set into it
+func NewStrict[T any](value T) Strict[T] {
+	return Strict[T]{v: NewValue(value)}
+}
+
+// Strict converts the value into a Strict
+func (o Value[T]) Strict() Strict[T] {
+	return Strict[T]{v: o}
+}
+
+// Value converts the Strict into a Value
+func (s Strict[T]) Value() Value[T] {
+	return s.v
+}
+
+// Reset clears the memory on the value
+func (s *Strict[T]) Reset() {
+	s.v.Reset()
+}
+
+// Set updates the value and sets the set flag
+func (s *Strict[T]) Set(value T) {
+	s.v.Set(value)
+}
+
+// IsSet returns true if the value is set
+func (s Strict[T]) IsSet() bool {
+	return s.v.set
+}
+
+// IsZero returns true if the value is unset, for omitzero and omitempty
+func (s Strict[T]) IsZero() bool {
+	return s.v.IsZero()
+}
+
+// Get returns the value, if it is set.
+// otherwise, it returns an error wrapping ErrNotSet
+func (s Strict[T]) Get() (T, error) {
+	if !s.v.set {
+		var empty T
+		return empty, s.notSet()
+	}
+	return s.v.value, nil
+}
+
+// MustGet returns the value, if it is set.
+// otherwise, it panics with an error wrapping ErrNotSet
+func (s Strict[T]) MustGet() T {
+	if !s.v.set {
+		panic(s.notSet())
+	}
+	return s.v.value
+}
+
+func (s Strict[T]) notSet() error {
+	return fmt.Errorf("%w: Strict[%v]", ErrNotSet, reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// MarshalJSON outputs the value of the Strict, if it is set.
+// otherwise, it returns nil
+func (s Strict[T]) MarshalJSON() ([]byte, error) {
+	return s.v.MarshalJSON()
+}
+
+// UnmarshalJSON unmarshals a value out of json and safely into our struct
+func (s *Strict[T]) UnmarshalJSON(data []byte) error {
+	return s.v.UnmarshalJSON(data)
+}
+
+// MarshalYAML outputs the value of the Strict, if it is set.
+// otherwise, it returns nil
+func (s Strict[T]) MarshalYAML() (any, error) {
+	return s.v.MarshalYAML()
+}
+
+// UnmarshalYAML unmarshals a value out of yaml and safely into our struct
+func (s *Strict[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	return s.v.UnmarshalYAML(unmarshal)
+}
+
+// AsAny returns the value as an `any` along with its set flag, for code that
+// needs to inspect an optional without knowing its type at compile time
+func (s Strict[T]) AsAny() (any, bool) {
+	return s.v.AsAny()
+}
+
+func (s Strict[T]) elemType() reflect.Type {
+	return s.v.elemType()
+}
+
+// SetAny sets the value to val, converting it to T as FromAny does.
+// a nil val resets the value
+func (s *Strict[T]) SetAny(val any) error {
+	return s.v.SetAny(val)
+}
+
+func (s *Strict[T]) setAny(val any) error {
+	return s.v.setAny(val)
+}
@@ -0,0 +1,74 @@
+package optional_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestStrict(t *testing.T) {
+	t.Run("Set", func(t *testing.T) {
+		target := optional.NewStrict(5)
+		value, err := target.Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "value", 5, value)
+		expect(t, "MustGet", 5, target.MustGet())
+	})
+	t.Run("Unset", func(t *testing.T) {
+		var target optional.Strict[int]
+		_, err := target.Get()
+		if !errors.Is(err, optional.ErrNotSet) {
+			t.Fatalf("expected ErrNotSet, got %v", err)
+		}
+		expect(t, "message", "optional: value not set: Strict[int]", err.Error())
+	})
+	t.Run("MustGetPanics", func(t *testing.T) {
+		defer func() {
+			if err, ok := recover().(error); !ok || !errors.Is(err, optional.ErrNotSet) {
+				t.Fatalf("expected panic with ErrNotSet, got %v", err)
+			}
+		}()
+		var target optional.Strict[string]
+		target.MustGet()
+	})
+	t.Run("Conversion", func(t *testing.T) {
+		target := optional.NewValue("Foo").Strict()
+		expect(t, "set", true, target.IsSet())
+		value, set := target.Value().Get()
+		expect(t, "value set", true, set)
+		expect(t, "value", "Foo", value)
+		target.Reset()
+		expect(t, "reset", false, target.Value().IsSet())
+	})
+	t.Run("JSON", func(t *testing.T) {
+		type testStruct struct {
+			A optional.Strict[int] `json:"a"`
+			B optional.Strict[int] `json:"b"`
+		}
+		var observed testStruct
+		if err := json.Unmarshal([]byte(`{"a":5}`), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "a", 5, observed.A.MustGet())
+		expect(t, "b set", false, observed.B.IsSet())
+		blob, err := json.Marshal(observed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", `{"a":5,"b":null}`, string(blob))
+	})
+	t.Run("Reflection", func(t *testing.T) {
+		typ := reflect.TypeOf(optional.Strict[int]{})
+		expect(t, "IsOptionalType", true, optional.IsOptionalType(typ))
+		observed, err := optional.FromAny(5, typ)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "FromAny", 5, observed.(optional.Strict[int]).MustGet())
+	})
+}
@@ -0,0 +1,53 @@
+package optional
+
+// TemplateFuncs returns functions for text/template and html/template which
+// work on any optional, and can be installed with Funcs:
+//
+//	{{ if isSet .Name }}Hello, {{ value .Name }}{{ end }}
+//	{{ .Name | valueOr "stranger" }}
+//
+// Value's own methods can also be called from templates; IsSet, GetOrZero,
+// and GetOr (e.g. {{ .Name.GetOr "stranger" }}) are the template-safe ones.
+// Value's Value method is the database/sql driver.Valuer, which fails for
+// types the database/sql driver cannot represent, so use GetOrZero instead.
+//
+// The value of a Sensitive is rendered masked.
+func TemplateFuncs() map[string]any {
+	return map[string]any{
+		"isSet":   templateIsSet,
+		"value":   templateValue,
+		"valueOr": templateValueOr,
+	}
+}
+
+// templateIsSet returns true if v is a set optional.
+// anything else is considered set if it is not nil
+func templateIsSet(v any) bool {
+	if opt, ok := v.(anyOptional); ok {
+		return opt.IsSet()
+	}
+	return v != nil
+}
+
+// templateValue returns the value of the optional v, or the zero value of
+// its element type if it is unset. anything else is returned as it is
+func templateValue(v any) any {
+	opt, ok := v.(anyOptional)
+	if !ok {
+		return v
+	}
+	if _, ok := opt.(redactor); ok && opt.IsSet() {
+		return redactedText
+	}
+	value, _ := opt.AsAny()
+	return value
+}
+
+// templateValueOr returns the value of the optional v, if it is set.
+// otherwise, it returns def
+func templateValueOr(def, v any) any {
+	if !templateIsSet(v) {
+		return def
+	}
+	return templateValue(v)
+}
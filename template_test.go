@@ -0,0 +1,52 @@
+package optional_test
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/heucuva/optional"
+)
+
+type templateData struct {
+	Name     optional.Value[string]
+	Count    optional.Boxed[int]
+	Password optional.Sensitive[string]
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	const text = `{{ if isSet .Name }}{{ value .Name }}{{ else }}-{{ end }}` +
+		`|{{ value .Count }}|{{ .Name | valueOr "anon" }}|{{ .Password | valueOr "none" }}` +
+		`|{{ .Name.GetOr "stranger" }}|{{ .Name.GetOrZero }}`
+
+	render := func(t *testing.T, data templateData) string {
+		t.Helper()
+		tmpl := template.Must(template.New("test").Funcs(optional.TemplateFuncs()).Parse(text))
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, data); err != nil {
+			t.Fatal(err)
+		}
+		return sb.String()
+	}
+
+	t.Run("Set", func(t *testing.T) {
+		data := templateData{
+			Name:     optional.NewValue("Foo"),
+			Count:    optional.NewBoxed(3),
+			Password: optional.NewSensitive("hunter2"),
+		}
+		expect(t, "output", "Foo|3|Foo|***|Foo|Foo", render(t, data))
+	})
+	t.Run("Unset", func(t *testing.T) {
+		expect(t, "output", "-|0|anon|none|stranger|", render(t, templateData{}))
+	})
+	t.Run("HTML", func(t *testing.T) {
+		tmpl := htmltemplate.Must(htmltemplate.New("test").Funcs(optional.TemplateFuncs()).Parse(`{{ value .Name }}`))
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, templateData{Name: optional.NewValue("<b>")}); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "output", "&lt;b&gt;", sb.String())
+	})
+}
@@ -0,0 +1,52 @@
+// Package tribool implements Kleene three-valued logic over optional bools,
+// where an unset value means "unknown" and propagates accordingly.
+package tribool
+
+import "github.com/heucuva/optional"
+
+var (
+	// True is a set value of true
+	True = optional.NewValue(true)
+	// False is a set value of false
+	False = optional.NewValue(false)
+	// Unknown is an unset value
+	Unknown = optional.Value[bool]{}
+)
+
+// Not returns the negation of v.
+// unknown stays unknown
+func Not(v optional.Value[bool]) optional.Value[bool] {
+	b, set := v.Get()
+	if !set {
+		return Unknown
+	}
+	return optional.NewValue(!b)
+}
+
+// And returns the conjunction of a and b.
+// false wins over unknown, which wins over true
+func And(a, b optional.Value[bool]) optional.Value[bool] {
+	av, aSet := a.Get()
+	bv, bSet := b.Get()
+	switch {
+	case (aSet && !av) || (bSet && !bv):
+		return False
+	case aSet && bSet:
+		return True
+	}
+	return Unknown
+}
+
+// Or returns the disjunction of a and b.
+// true wins over unknown, which wins over false
+func Or(a, b optional.Value[bool]) optional.Value[bool] {
+	av, aSet := a.Get()
+	bv, bSet := b.Get()
+	switch {
+	case (aSet && av) || (bSet && bv):
+		return True
+	case aSet && bSet:
+		return False
+	}
+	return Unknown
+}
@@ -0,0 +1,62 @@
+package tribool_test
+
+import (
+	"testing"
+
+	"github.com/heucuva/optional"
+	"github.com/heucuva/optional/tribool"
+)
+
+func name(v optional.Value[bool]) string {
+	b, set := v.Get()
+	switch {
+	case !set:
+		return "Unknown"
+	case b:
+		return "True"
+	}
+	return "False"
+}
+
+func TestNot(t *testing.T) {
+	tests := []struct {
+		in, expected optional.Value[bool]
+	}{
+		{tribool.True, tribool.False},
+		{tribool.False, tribool.True},
+		{tribool.Unknown, tribool.Unknown},
+	}
+	for _, tc := range tests {
+		t.Run(name(tc.in), func(t *testing.T) {
+			if observed := tribool.Not(tc.in); observed != tc.expected {
+				t.Fatalf("expected %s, got %s", name(tc.expected), name(observed))
+			}
+		})
+	}
+}
+
+func TestAndOr(t *testing.T) {
+	tests := []struct {
+		a, b    optional.Value[bool]
+		and, or optional.Value[bool]
+	}{
+		{tribool.True, tribool.True, tribool.True, tribool.True},
+		{tribool.True, tribool.False, tribool.False, tribool.True},
+		{tribool.True, tribool.Unknown, tribool.Unknown, tribool.True},
+		{tribool.False, tribool.False, tribool.False, tribool.False},
+		{tribool.False, tribool.Unknown, tribool.False, tribool.Unknown},
+		{tribool.Unknown, tribool.Unknown, tribool.Unknown, tribool.Unknown},
+	}
+	for _, tc := range tests {
+		t.Run(name(tc.a)+name(tc.b), func(t *testing.T) {
+			for _, operands := range [][2]optional.Value[bool]{{tc.a, tc.b}, {tc.b, tc.a}} {
+				if observed := tribool.And(operands[0], operands[1]); observed != tc.and {
+					t.Fatalf("expected And to be %s, got %s", name(tc.and), name(observed))
+				}
+				if observed := tribool.Or(operands[0], operands[1]); observed != tc.or {
+					t.Fatalf("expected Or to be %s, got %s", name(tc.or), name(observed))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownFields is the type of a capture field, which receives the object
+// keys that do not match any other field of its struct when decoding with
+// the CaptureUnknown decoder option. A capture field is marked with the
+// `optional:"unknown"` tag, and should be hidden from encoding/json:
+//
+//	type Document struct {
+//		Name    optional.Value[string] `json:"name"`
+//		Unknown optional.UnknownFields `json:"-" optional:"unknown"`
+//	}
+//
+// The capture field is left unset if there were no unknown keys.
+// MarshalJSONWithUnknown writes the captured keys back out. For yaml, see
+// UnmarshalYAMLWithUnknown and MarshalYAMLWithUnknown.
+type UnknownFields = Value[map[string]json.RawMessage]
+
+var unknownFieldsType = reflect.TypeOf(UnknownFields{})
+
+// captureFieldIndex returns the index of the capture field of the struct
+// type t, or -1 if it has none
+func captureFieldIndex(t reflect.Type) int {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Type == unknownFieldsType && field.Tag.Get("optional") == "unknown" {
+			return i
+		}
+	}
+	return -1
+}
+
+// knownJSONKeys returns the json names of the fields of the struct type t,
+// including the fields promoted from embedded structs
+func knownJSONKeys(t reflect.Type, keys []string) []string {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" && field.Anonymous {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				keys = knownJSONKeys(ft, keys)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		keys = append(keys, name)
+	}
+	return keys
+}
+
+func isKnownJSONKey(known []string, key string) bool {
+	for _, name := range known {
+		if name == key || strings.EqualFold(name, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// captureUnknownFields sets the capture field of the struct rv, if it has
+// one, to the members of obj that do not match its fields, as written
+func captureUnknownFields(rv reflect.Value, obj map[string]json.RawMessage) {
+	index := captureFieldIndex(rv.Type())
+	if index < 0 || !rv.Field(index).CanSet() {
+		return
+	}
+
+	known := knownJSONKeys(rv.Type(), nil)
+	var unknown map[string]json.RawMessage
+	for key, raw := range obj {
+		if isKnownJSONKey(known, key) {
+			continue
+		}
+		if unknown == nil {
+			unknown = make(map[string]json.RawMessage)
+		}
+		// the payload may be reused by the caller
+		unknown[key] = append(json.RawMessage(nil), raw...)
+	}
+
+	var captured UnknownFields
+	if unknown != nil {
+		captured.Set(unknown)
+	}
+	rv.Field(index).Set(reflect.ValueOf(captured))
+}
+
+// MarshalJSONWithUnknown marshals v to json, writing the keys held by every
+// capture field (see UnknownFields) back into the object of its struct, after
+// the struct's own keys, which take precedence. Captured values are written
+// as they were decoded, so a document decoded with the CaptureUnknown option
+// round-trips without loss, apart from the order of its keys and any
+// insignificant whitespace.
+func MarshalJSONWithUnknown(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := restoreUnknownFields(&buf, reflect.ValueOf(v), data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type jsonMember struct {
+	key   string
+	value json.RawMessage
+}
+
+// jsonMembers splits the json object raw into its members, in order
+func jsonMembers(raw json.RawMessage) ([]jsonMember, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	var members []jsonMember
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		members = append(members, jsonMember{key: key, value: value})
+	}
+	return members, nil
+}
+
+// restoreUnknownFields writes raw, the json encoding of rv, to buf, adding
+// the captured keys to the objects of its structs
+func restoreUnknownFields(buf *bytes.Buffer, rv reflect.Value, raw json.RawMessage) error {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			break
+		}
+		rv = rv.Elem()
+	}
+	if rv.IsValid() && IsOptionalType(rv.Type()) {
+		rv, _ = UnwrapAny(rv)
+	}
+
+	switch {
+	case !rv.IsValid():
+	case rv.Kind() == reflect.Struct && jsonKind(raw) == '{':
+		return restoreUnknownStruct(buf, rv, raw)
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String && jsonKind(raw) == '{':
+		members, err := jsonMembers(raw)
+		if err != nil {
+			return err
+		}
+		return writeJSONMembers(buf, members, nil, func(key string) reflect.Value {
+			return rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()))
+		})
+	case (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && jsonKind(raw) == '[':
+		var arr []json.RawMessage
+		if err := json.Unmarshal(raw, &arr); err != nil {
+			return err
+		}
+		buf.WriteByte('[')
+		for i, elem := range arr {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			var ev reflect.Value
+			if i < rv.Len() {
+				ev = rv.Index(i)
+			}
+			if err := restoreUnknownFields(buf, ev, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	}
+	buf.Write(raw)
+	return nil
+}
+
+func restoreUnknownStruct(buf *bytes.Buffer, rv reflect.Value, raw json.RawMessage) error {
+	members, err := jsonMembers(raw)
+	if err != nil {
+		return err
+	}
+	fields := make(map[string][]int)
+	for _, field := range jsonFields(rv.Type()) {
+		fields[field.name] = field.index
+	}
+
+	var captured []jsonMember
+	if index := captureFieldIndex(rv.Type()); index >= 0 {
+		unknown := rv.Field(index).Interface().(UnknownFields).GetOrZero()
+		keys := make([]string, 0, len(unknown))
+		for key := range unknown {
+			if _, ok := fields[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			captured = append(captured, jsonMember{key: key, value: unknown[key]})
+		}
+	}
+
+	return writeJSONMembers(buf, members, captured, func(key string) reflect.Value {
+		index, ok := fields[key]
+		if !ok {
+			return reflect.Value{}
+		}
+		fv, _ := jsonFieldValue(rv, index)
+		return fv
+	})
+}
+
+// writeJSONMembers writes an object holding members, whose values encode the
+// values found by lookup, followed by the captured members
+func writeJSONMembers(buf *bytes.Buffer, members, captured []jsonMember, lookup func(key string) reflect.Value) error {
+	buf.WriteByte('{')
+	for i, member := range members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeJSONKey(buf, member.key); err != nil {
+			return err
+		}
+		if err := restoreUnknownFields(buf, lookup(member.key), member.value); err != nil {
+			return err
+		}
+	}
+	for i, member := range captured {
+		if i > 0 || len(members) > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeJSONKey(buf, member.key); err != nil {
+			return err
+		}
+		if err := json.Compact(buf, member.value); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func writeJSONKey(buf *bytes.Buffer, key string) error {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	buf.WriteByte(':')
+	return nil
+}
@@ -0,0 +1,151 @@
+package optional_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestCaptureUnknown(t *testing.T) {
+	type testAddress struct {
+		City    optional.Value[string] `json:"city"`
+		Unknown optional.UnknownFields `json:"-" optional:"unknown"`
+	}
+	type testDocument struct {
+		Name    optional.Value[string] `json:"name"`
+		Address testAddress            `json:"address"`
+		Unknown optional.UnknownFields `json:"-" optional:"unknown"`
+	}
+
+	payload := []byte(`{"name":"Foo","Version":2,"big":12345678901234567890,"extra":{"a":[1,2]},"address":{"city":"Bar","zip":"12345"}}`)
+	ctx := optional.WithDecoderOptions(context.Background(), optional.DecoderOptions{
+		CaptureUnknown: true,
+		Strict:         true,
+	})
+
+	var doc testDocument
+	if err := optional.UnmarshalJSONContext(ctx, payload, &doc); err != nil {
+		t.Fatal(err)
+	}
+	unknown := doc.Unknown.MustGet()
+	expect(t, "len", 3, len(unknown))
+	expect(t, "Version", "2", string(unknown["Version"]))
+	expect(t, "big", "12345678901234567890", string(unknown["big"]))
+	expect(t, "extra", `{"a":[1,2]}`, string(unknown["extra"]))
+	expect(t, "address.zip", `"12345"`, string(doc.Address.Unknown.MustGet()["zip"]))
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		blob, err := optional.MarshalJSONWithUnknown(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `{"name":"Foo","address":{"city":"Bar","zip":"12345"},"Version":2,"big":12345678901234567890,"extra":{"a":[1,2]}}`
+		expect(t, "json", expected, string(blob))
+	})
+	t.Run("AsWritten", func(t *testing.T) {
+		var doc testDocument
+		payload := []byte(`{"name":"Foo","extra":{"z":1.50, "a":1e2}}`)
+		if err := optional.UnmarshalJSONContext(ctx, payload, &doc); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "extra", `{"z":1.50, "a":1e2}`, string(doc.Unknown.MustGet()["extra"]))
+		blob, err := optional.MarshalJSONWithUnknown(&doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `{"name":"Foo","address":{"city":null},"extra":{"z":1.50,"a":1e2}}`
+		expect(t, "json", expected, string(blob))
+	})
+	t.Run("Nested", func(t *testing.T) {
+		type testList struct {
+			Items []optional.Value[testAddress] `json:"items"`
+		}
+		var list testList
+		payload := []byte(`{"items":[{"city":"Bar","zip":"1"},{"city":"Baz"}]}`)
+		if err := optional.UnmarshalJSONContext(ctx, payload, &list); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "zip", `"1"`, string(list.Items[0].MustGet().Unknown.MustGet()["zip"]))
+		blob, err := optional.MarshalJSONWithUnknown(list)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", `{"items":[{"city":"Bar","zip":"1"},{"city":"Baz"}]}`, string(blob))
+	})
+	t.Run("NoUnknown", func(t *testing.T) {
+		var doc testDocument
+		if err := optional.UnmarshalJSONContext(ctx, []byte(`{"NAME":"Foo"}`), &doc); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "set", false, doc.Unknown.IsSet())
+		expect(t, "name", "Foo", doc.Name.MustGet())
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		var doc testDocument
+		if err := optional.UnmarshalJSONContext(context.Background(), payload, &doc); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "set", false, doc.Unknown.IsSet())
+	})
+}
+
+func TestCaptureUnknownYAML(t *testing.T) {
+	type testAddress struct {
+		City    optional.Value[string] `yaml:"city"`
+		Unknown optional.UnknownFields `yaml:"-" optional:"unknown"`
+	}
+	type testDocument struct {
+		Name    optional.Value[string] `yaml:"name"`
+		Address testAddress            `yaml:"address"`
+		Unknown optional.UnknownFields `yaml:"-" optional:"unknown"`
+	}
+
+	payload := []byte(`name: Foo
+version: 2
+big: 12345678901234567890
+extra:
+  z: [1, two]
+  a: true
+address:
+  city: Bar
+  zip: "12345"
+`)
+	var doc testDocument
+	if err := optional.UnmarshalYAMLWithUnknown(payload, &doc); err != nil {
+		t.Fatal(err)
+	}
+	unknown := doc.Unknown.MustGet()
+	expect(t, "len", 3, len(unknown))
+	expect(t, "name", "Foo", doc.Name.MustGet())
+	expect(t, "version", "2", string(unknown["version"]))
+	expect(t, "big", "12345678901234567890", string(unknown["big"]))
+	expect(t, "extra", `{"z":[1,"two"],"a":true}`, string(unknown["extra"]))
+	expect(t, "address.zip", `"12345"`, string(doc.Address.Unknown.MustGet()["zip"]))
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		blob, err := optional.MarshalYAMLWithUnknown(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `name: Foo
+address:
+    city: Bar
+    zip: "12345"
+big: 12345678901234567890
+extra:
+    z:
+        - 1
+        - two
+    a: true
+version: 2
+`
+		expect(t, "yaml", expected, string(blob))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var doc testDocument
+		if err := optional.UnmarshalYAMLWithUnknown([]byte("name: [Foo"), &doc); err == nil {
+			t.Error("expected failure, but got success")
+		}
+	})
+}
@@ -0,0 +1,336 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UnmarshalYAMLWithUnknown decodes the yaml document data into v with
+// gopkg.in/yaml.v3, setting the capture field (see UnknownFields) of every
+// struct decoded from a mapping to the keys that do not match its fields.
+// Captured values are converted to json, keeping the text of their numbers.
+// A capture field should be hidden from yaml with a `yaml:"-"` tag.
+func UnmarshalYAMLWithUnknown(data []byte, v any) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc.Kind == 0 {
+		// an empty document decodes as nothing
+		return nil
+	}
+	if err := doc.Decode(v); err != nil {
+		return err
+	}
+	return captureUnknownYAML(reflect.ValueOf(v), &doc)
+}
+
+// MarshalYAMLWithUnknown marshals v to yaml with gopkg.in/yaml.v3, writing
+// the keys held by every capture field back into the mapping of its struct,
+// after the struct's own keys, which take precedence.
+func MarshalYAMLWithUnknown(v any) ([]byte, error) {
+	var doc yaml.Node
+	if err := doc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := restoreUnknownYAML(reflect.ValueOf(v), &doc); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(&doc)
+}
+
+// yamlContent skips past the document and alias nodes wrapping node
+func yamlContent(node *yaml.Node) *yaml.Node {
+	for {
+		switch {
+		case node.Kind == yaml.DocumentNode && len(node.Content) == 1:
+			node = node.Content[0]
+		case node.Kind == yaml.AliasNode && node.Alias != nil:
+			node = node.Alias
+		default:
+			return node
+		}
+	}
+}
+
+// yamlFields returns the field indexes of the struct type t by their yaml
+// names, including the fields of inlined structs
+func yamlFields(t reflect.Type, parent []int, fields map[string][]int) map[string][]int {
+	if fields == nil {
+		fields = make(map[string][]int)
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("yaml")
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+		index := append(append([]int{}, parent...), i)
+		name, flags, _ := strings.Cut(tag, ",")
+		if strings.Contains(","+flags+",", ",inline,") {
+			if field.Type.Kind() == reflect.Struct {
+				yamlFields(field.Type, index, fields)
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = index
+	}
+	return fields
+}
+
+// captureUnknownYAML walks rv alongside the node it was decoded from,
+// capturing the unknown keys of its structs
+func captureUnknownYAML(rv reflect.Value, node *yaml.Node) error {
+	node = yamlContent(node)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	if IsOptionalType(rv.Type()) {
+		if !rv.CanAddr() || (node.Kind != yaml.MappingNode && node.Kind != yaml.SequenceNode) {
+			return nil
+		}
+		setter, ok := rv.Addr().Interface().(anySetter)
+		if !ok {
+			return nil
+		}
+		// the value is not addressable, so keys are captured into a copy
+		// which is then stored back
+		opt := rv.Interface().(anyOptional)
+		value, set := opt.AsAny()
+		if !set || value == nil {
+			return nil
+		}
+		elem := reflect.New(opt.elemType()).Elem()
+		elem.Set(reflect.ValueOf(value))
+		if err := captureUnknownYAML(elem, node); err != nil {
+			return err
+		}
+		return setter.setAny(elem.Interface())
+	}
+
+	switch {
+	case rv.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		return captureUnknownYAMLStruct(rv, node)
+	case (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && node.Kind == yaml.SequenceNode:
+		for i := 0; i < rv.Len() && i < len(node.Content); i++ {
+			if err := captureUnknownYAML(rv.Index(i), node.Content[i]); err != nil {
+				return err
+			}
+		}
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := reflect.ValueOf(yamlContent(node.Content[i]).Value).Convert(rv.Type().Key())
+			if !rv.MapIndex(key).IsValid() {
+				continue
+			}
+			// map elements are not addressable, so keys are captured into
+			// a copy which is then stored back
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			elem.Set(rv.MapIndex(key))
+			if err := captureUnknownYAML(elem, node.Content[i+1]); err != nil {
+				return err
+			}
+			rv.SetMapIndex(key, elem)
+		}
+	}
+	return nil
+}
+
+func captureUnknownYAMLStruct(rv reflect.Value, node *yaml.Node) error {
+	fields := yamlFields(rv.Type(), nil, nil)
+	index := captureFieldIndex(rv.Type())
+
+	var unknown map[string]json.RawMessage
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := yamlContent(node.Content[i]), node.Content[i+1]
+		if key.Tag == "!!merge" {
+			// merged mappings are left to yaml.v3
+			continue
+		}
+		if field, ok := fields[key.Value]; ok {
+			if err := captureUnknownYAML(rv.FieldByIndex(field), value); err != nil {
+				return err
+			}
+			continue
+		}
+		if index < 0 {
+			continue
+		}
+		raw, err := yamlNodeJSON(value)
+		if err != nil {
+			return err
+		}
+		if unknown == nil {
+			unknown = make(map[string]json.RawMessage)
+		}
+		unknown[key.Value] = raw
+	}
+
+	if index < 0 || !rv.Field(index).CanSet() {
+		return nil
+	}
+	var captured UnknownFields
+	if unknown != nil {
+		captured.Set(unknown)
+	}
+	rv.Field(index).Set(reflect.ValueOf(captured))
+	return nil
+}
+
+// yamlNodeJSON converts node to json. numbers keep their text when it is
+// valid json, so they are not rounded through a float
+func yamlNodeJSON(node *yaml.Node) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	if err := writeYAMLNodeJSON(&buf, node); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeYAMLNodeJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	node = yamlContent(node)
+	switch node.Kind {
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONKey(buf, yamlContent(node.Content[i]).Value); err != nil {
+				return err
+			}
+			if err := writeYAMLNodeJSON(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, elem := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeYAMLNodeJSON(buf, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	}
+
+	switch node.ShortTag() {
+	case "!!int", "!!float":
+		if json.Valid([]byte(node.Value)) {
+			buf.WriteString(node.Value)
+			return nil
+		}
+	case "!!str":
+		data, err := json.Marshal(node.Value)
+		buf.Write(data)
+		return err
+	}
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+	data, err := json.Marshal(value)
+	buf.Write(data)
+	return err
+}
+
+// restoreUnknownYAML walks rv alongside the node it was encoded to, adding
+// the captured keys to the mappings of its structs
+func restoreUnknownYAML(rv reflect.Value, node *yaml.Node) error {
+	node = yamlContent(node)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.IsValid() && IsOptionalType(rv.Type()) {
+		rv, _ = UnwrapAny(rv)
+	}
+
+	switch {
+	case !rv.IsValid():
+	case rv.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		return restoreUnknownYAMLStruct(rv, node)
+	case (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && node.Kind == yaml.SequenceNode:
+		for i := 0; i < rv.Len() && i < len(node.Content); i++ {
+			if err := restoreUnknownYAML(rv.Index(i), node.Content[i]); err != nil {
+				return err
+			}
+		}
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := reflect.ValueOf(yamlContent(node.Content[i]).Value).Convert(rv.Type().Key())
+			if err := restoreUnknownYAML(rv.MapIndex(key), node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func restoreUnknownYAMLStruct(rv reflect.Value, node *yaml.Node) error {
+	fields := yamlFields(rv.Type(), nil, nil)
+	present := make(map[string]bool, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := yamlContent(node.Content[i]).Value
+		present[key] = true
+		if field, ok := fields[key]; ok {
+			if err := restoreUnknownYAML(rv.FieldByIndex(field), node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+	}
+
+	index := captureFieldIndex(rv.Type())
+	if index < 0 {
+		return nil
+	}
+	unknown := rv.Field(index).Interface().(UnknownFields).GetOrZero()
+	keys := make([]string, 0, len(unknown))
+	for key := range unknown {
+		if _, known := fields[key]; !known && !present[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		// json is yaml, so the captured bytes parse as they were written
+		var value yaml.Node
+		if err := yaml.Unmarshal(unknown[key], &value); err != nil {
+			return err
+		}
+		content := yamlContent(&value)
+		clearYAMLStyle(content)
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+			content)
+	}
+	return nil
+}
+
+// clearYAMLStyle drops the flow and quoting styles json parses with, so the
+// encoder picks the styles it would for the rest of the document
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
@@ -1,6 +1,14 @@
 package optional
 
-import "reflect"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrNotSet is the error reported when an unset value is accessed by an
+// accessor that requires it to be set
+var ErrNotSet = errors.New("optional: value not set")
 
 // Value is an optional value
 type Value[T any] struct {
@@ -15,15 +23,31 @@ func NewValue[T any](value T) Value[T] {
 	return v
 }
 
-// IsZero is used by the yaml marshaller to determine "zero"-ness for omitempty
-// we're using it for the `set` bool
+// NewNonZero constructs a Value structure with value set into it, if value
+// is not T's zero value. otherwise, it returns an unset Value
+func NewNonZero[T comparable](value T) Value[T] {
+	var zero T
+	if value == zero {
+		return Value[T]{}
+	}
+	return NewValue(value)
+}
+
+// IsZero returns true if the value is unset. It is used by encoding/json
+// (for omitzero) and the yaml marshallers (for omitempty) to drop unset values
 func (o Value[T]) IsZero() bool {
+	return !o.set
+}
+
+// hasNonZero returns true if the value is set to something other than
+// T's zero value
+func (o Value[T]) hasNonZero() bool {
 	if !o.set {
 		return false
 	}
 
 	v := reflect.ValueOf(o.value)
-	return !v.IsValid() || v.IsZero()
+	return v.IsValid() && !v.IsZero()
 }
 
 // Reset clears the memory on the value
@@ -39,6 +63,49 @@ func (o *Value[T]) Set(value T) {
 	o.set = true
 }
 
+// Replace updates the value and sets the set flag, returning the value
+// as it was before the update
+func (o *Value[T]) Replace(value T) Value[T] {
+	old := *o
+	o.Set(value)
+	return old
+}
+
+// Take returns the value and its set flag, leaving the value unset
+func (o *Value[T]) Take() (T, bool) {
+	value, set := o.value, o.set
+	o.Reset()
+	return value, set
+}
+
+// GetOrInsert sets the value to def, if it is unset, and returns a pointer
+// to the contained value. The pointer is only valid until the value is next
+// updated or reset
+func (o *Value[T]) GetOrInsert(def T) *T {
+	if !o.set {
+		o.Set(def)
+	}
+	return &o.value
+}
+
+// SetIf updates the value and sets the set flag, if cond is true.
+// otherwise, the value is left unchanged
+func (o *Value[T]) SetIf(cond bool, value T) {
+	if cond {
+		o.Set(value)
+	}
+}
+
+// SetNonZero updates the value and sets the set flag, if value is not
+// T's zero value. otherwise, the value is left unchanged
+func (o *Value[T]) SetNonZero(value T) {
+	v := reflect.ValueOf(&value).Elem()
+	if !v.IsZero() {
+		o.Set(value)
+	}
+}
+
+// IsSet returns true if the value is set
 func (o Value[T]) IsSet() bool {
 	return o.set
 }
@@ -47,3 +114,60 @@ func (o Value[T]) IsSet() bool {
 func (o Value[T]) Get() (T, bool) {
 	return o.value, o.set
 }
+
+// GetOrZero returns the value, if it is set.
+// otherwise, it returns T's zero value
+func (o Value[T]) GetOrZero() T {
+	// an unset value always holds T's zero value, so no branch is needed
+	return o.value
+}
+
+// GetOr returns the value, if it is set.
+// otherwise, it returns def
+func (o Value[T]) GetOr(def T) T {
+	if o.set {
+		return o.value
+	}
+	return def
+}
+
+// GetOrElse returns the value, if it is set.
+// otherwise, it returns the result of calling fn
+func (o Value[T]) GetOrElse(fn func() T) T {
+	if o.set {
+		return o.value
+	}
+	return fn()
+}
+
+// MustGet returns the value, if it is set.
+// otherwise, it panics with an error wrapping ErrNotSet
+func (o Value[T]) MustGet() T {
+	if !o.set {
+		o.panicNotSet()
+	}
+	return o.value
+}
+
+// panicNotSet is kept out of MustGet so that MustGet stays inlinable
+func (o Value[T]) panicNotSet() {
+	panic(fmt.Errorf("%w: Value[%v]", ErrNotSet, reflect.TypeOf((*T)(nil)).Elem()))
+}
+
+// Or returns the value, if it is set.
+// otherwise, it returns other
+func (o Value[T]) Or(other Value[T]) Value[T] {
+	if o.set {
+		return o
+	}
+	return other
+}
+
+// OrValue returns the value, if it is set.
+// otherwise, it returns a Value with value set into it
+func (o Value[T]) OrValue(value T) Value[T] {
+	if o.set {
+		return o
+	}
+	return NewValue(value)
+}
@@ -0,0 +1,196 @@
+package optional_test
+
+import (
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+var (
+	benchSinkInt    int
+	benchSinkBool   bool
+	benchSinkString string
+	benchSinkValue  optional.Value[int]
+)
+
+func benchmarkValues() []optional.Value[int] {
+	values := make([]optional.Value[int], 1024)
+	for i := range values {
+		if i%3 != 0 {
+			values[i].Set(i)
+		}
+	}
+	return values
+}
+
+func BenchmarkValueAccess(b *testing.B) {
+	values := benchmarkValues()
+
+	b.Run("Get", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, v := range values {
+				if value, set := v.Get(); set {
+					benchSinkInt += value
+				}
+			}
+		}
+	})
+	b.Run("IsSet", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, v := range values {
+				benchSinkBool = benchSinkBool != v.IsSet()
+			}
+		}
+	})
+	b.Run("GetOr", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, v := range values {
+				benchSinkInt += v.GetOr(-1)
+			}
+		}
+	})
+	b.Run("GetOrZero", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, v := range values {
+				benchSinkInt += v.GetOrZero()
+			}
+		}
+	})
+	b.Run("MustGet", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, v := range values {
+				if v.IsSet() {
+					benchSinkInt += v.MustGet()
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkValueSet(b *testing.B) {
+	b.Run("Int", func(b *testing.B) {
+		b.ReportAllocs()
+		var v optional.Value[int]
+		for i := 0; i < b.N; i++ {
+			v.Set(i)
+			benchSinkInt += v.GetOrZero()
+			v.Reset()
+		}
+	})
+	b.Run("String", func(b *testing.B) {
+		b.ReportAllocs()
+		var v optional.Value[string]
+		for i := 0; i < b.N; i++ {
+			v.Set("Foo")
+			benchSinkString = v.GetOrZero()
+			v.Reset()
+		}
+	})
+	b.Run("NewValue", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchSinkValue = optional.NewValue(i)
+		}
+	})
+}
+
+func BenchmarkValueMarshalJSON(b *testing.B) {
+	b.Run("Int", func(b *testing.B) {
+		b.ReportAllocs()
+		v := optional.NewValue(123456)
+		for i := 0; i < b.N; i++ {
+			data, _ := v.MarshalJSON()
+			benchSinkInt += len(data)
+		}
+	})
+	b.Run("Float", func(b *testing.B) {
+		b.ReportAllocs()
+		v := optional.NewValue(1234.5678)
+		for i := 0; i < b.N; i++ {
+			data, _ := v.MarshalJSON()
+			benchSinkInt += len(data)
+		}
+	})
+	b.Run("String", func(b *testing.B) {
+		b.ReportAllocs()
+		v := optional.NewValue("Foo Bar")
+		for i := 0; i < b.N; i++ {
+			data, _ := v.MarshalJSON()
+			benchSinkInt += len(data)
+		}
+	})
+	b.Run("Unset", func(b *testing.B) {
+		b.ReportAllocs()
+		var v optional.Value[int]
+		for i := 0; i < b.N; i++ {
+			data, _ := v.MarshalJSON()
+			benchSinkInt += len(data)
+		}
+	})
+}
+
+func BenchmarkValueAppendJSON(b *testing.B) {
+	b.ReportAllocs()
+	v := optional.NewValue(123456)
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf, _ = v.AppendJSON(buf[:0])
+	}
+	benchSinkInt += len(buf)
+}
+
+// TestValueAllocations guards the representation of Value[T] as a T and a
+// flag: setting and reading scalars must not box them
+func TestValueAllocations(t *testing.T) {
+	tests := map[string]func(){
+		"Int": func() {
+			var v optional.Value[int]
+			v.Set(5)
+			benchSinkInt += v.MustGet()
+		},
+		"Float": func() {
+			v := optional.NewValue(1.5)
+			benchSinkBool = v.GetOr(0) > 1
+		},
+		"Bool": func() {
+			v := optional.NewValue(true)
+			value, set := v.Get()
+			benchSinkBool = value && set
+		},
+		"String": func() {
+			var v optional.Value[string]
+			v.Set("Foo")
+			benchSinkString = v.GetOrZero()
+			v.Reset()
+		},
+		"AppendJSON": func() {
+			var buf [32]byte
+			data, _ := optional.NewValue(1.5).AppendJSON(buf[:0])
+			benchSinkInt += len(data)
+		},
+	}
+	for name, fn := range tests {
+		if allocs := testing.AllocsPerRun(100, fn); allocs != 0 {
+			t.Errorf("%s: expected no allocations, got %v", name, allocs)
+		}
+	}
+}
+
+// BenchmarkPointerAccess is the pointer-for-optional baseline the accessors
+// are compared against
+func BenchmarkPointerAccess(b *testing.B) {
+	values := benchmarkValues()
+	ptrs := make([]*int, len(values))
+	for i, v := range values {
+		ptrs[i] = v.Ptr()
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, p := range ptrs {
+			if p != nil {
+				benchSinkInt += *p
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package optional_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/heucuva/optional"
@@ -75,3 +76,158 @@ func TestValue(t *testing.T) {
 		expect(t, "value.ValComplex", expectedValue.ValComplex, encounteredValue.ValComplex)
 	})
 }
+
+func TestValueGetOr(t *testing.T) {
+	t.Run("Set", func(t *testing.T) {
+		target := optional.NewValue(5)
+		expect(t, "GetOr", 5, target.GetOr(10))
+		expect(t, "GetOrElse", 5, target.GetOrElse(func() int {
+			t.Fatal("expected default func not to be called")
+			return 10
+		}))
+	})
+	t.Run("Unset", func(t *testing.T) {
+		var target optional.Value[int]
+		expect(t, "GetOr", 10, target.GetOr(10))
+		expect(t, "GetOrElse", 10, target.GetOrElse(func() int { return 10 }))
+	})
+	t.Run("SetZero", func(t *testing.T) {
+		target := optional.NewValue(0)
+		expect(t, "GetOr", 0, target.GetOr(10))
+	})
+	t.Run("GetOrZero", func(t *testing.T) {
+		expect(t, "set", 5, optional.NewValue(5).GetOrZero())
+		expect(t, "unset", 0, optional.Value[int]{}.GetOrZero())
+		target := optional.NewValue("Foo")
+		target.Reset()
+		expect(t, "reset", "", target.GetOrZero())
+	})
+}
+
+func TestValueMustGet(t *testing.T) {
+	t.Run("Set", func(t *testing.T) {
+		expect(t, "value", "Foo", optional.NewValue("Foo").MustGet())
+	})
+	t.Run("Unset", func(t *testing.T) {
+		defer func() {
+			err, ok := recover().(error)
+			if !ok {
+				t.Fatal("expected panic with an error")
+			}
+			if !errors.Is(err, optional.ErrNotSet) {
+				t.Fatalf("expected ErrNotSet, got %v", err)
+			}
+			expect(t, "message", "optional: value not set: Value[int]", err.Error())
+		}()
+		var target optional.Value[int]
+		target.MustGet()
+	})
+}
+
+func TestValueConditionalSet(t *testing.T) {
+	t.Run("SetIfTrue", func(t *testing.T) {
+		var target optional.Value[int]
+		target.SetIf(true, 5)
+		expect(t, "value", 5, target.MustGet())
+	})
+	t.Run("SetIfFalse", func(t *testing.T) {
+		target := optional.NewValue(1)
+		target.SetIf(false, 5)
+		expect(t, "value", 1, target.MustGet())
+	})
+	t.Run("SetNonZero", func(t *testing.T) {
+		var target optional.Value[string]
+		target.SetNonZero("Foo")
+		expect(t, "value", "Foo", target.MustGet())
+	})
+	t.Run("SetNonZeroWithZero", func(t *testing.T) {
+		var target optional.Value[string]
+		target.SetNonZero("")
+		expect(t, "set", false, target.IsSet())
+	})
+	t.Run("SetNonZeroInterface", func(t *testing.T) {
+		var target optional.Value[error]
+		target.SetNonZero(nil)
+		expect(t, "set", false, target.IsSet())
+	})
+}
+
+func TestNewNonZero(t *testing.T) {
+	t.Run("NonZero", func(t *testing.T) {
+		target := optional.NewNonZero(5)
+		expect(t, "value", 5, target.MustGet())
+	})
+	t.Run("Zero", func(t *testing.T) {
+		expect(t, "set", false, optional.NewNonZero("").IsSet())
+	})
+	t.Run("Struct", func(t *testing.T) {
+		type point struct{ X, Y int }
+		expect(t, "zero set", false, optional.NewNonZero(point{}).IsSet())
+		expect(t, "non-zero set", true, optional.NewNonZero(point{Y: 1}).IsSet())
+	})
+	t.Run("Pointer", func(t *testing.T) {
+		expect(t, "nil set", false, optional.NewNonZero[*int](nil).IsSet())
+		expect(t, "pointer set", true, optional.NewNonZero(new(int)).IsSet())
+	})
+}
+
+func TestValueReplace(t *testing.T) {
+	t.Run("Set", func(t *testing.T) {
+		target := optional.NewValue(1)
+		old := target.Replace(5)
+		expect(t, "old", 1, old.MustGet())
+		expect(t, "value", 5, target.MustGet())
+	})
+	t.Run("Unset", func(t *testing.T) {
+		var target optional.Value[int]
+		old := target.Replace(5)
+		expect(t, "old set", false, old.IsSet())
+		expect(t, "value", 5, target.MustGet())
+	})
+}
+
+func TestValueTake(t *testing.T) {
+	t.Run("Set", func(t *testing.T) {
+		target := optional.NewValue(1)
+		value, set := target.Take()
+		expect(t, "value", 1, value)
+		expect(t, "set", true, set)
+		expect(t, "target set", false, target.IsSet())
+	})
+	t.Run("Unset", func(t *testing.T) {
+		var target optional.Value[int]
+		_, set := target.Take()
+		expect(t, "set", false, set)
+	})
+}
+
+func TestValueGetOrInsert(t *testing.T) {
+	t.Run("Unset", func(t *testing.T) {
+		var target optional.Value[int]
+		p := target.GetOrInsert(5)
+		expect(t, "inserted", 5, *p)
+		*p = 6
+		expect(t, "value", 6, target.MustGet())
+	})
+	t.Run("Set", func(t *testing.T) {
+		target := optional.NewValue(1)
+		expect(t, "existing", 1, *target.GetOrInsert(5))
+	})
+}
+
+func TestValueOr(t *testing.T) {
+	var (
+		cli  optional.Value[string]
+		env  = optional.NewValue("env")
+		file = optional.NewValue("file")
+	)
+	t.Run("Or", func(t *testing.T) {
+		expect(t, "cli > env > file", "env", cli.Or(env).Or(file).MustGet())
+		expect(t, "file > env", "file", file.Or(env).MustGet())
+		expect(t, "unset", false, cli.Or(optional.Value[string]{}).IsSet())
+	})
+	t.Run("OrValue", func(t *testing.T) {
+		expect(t, "unset", "default", cli.OrValue("default").MustGet())
+		expect(t, "set", "env", env.OrValue("default").MustGet())
+	})
+}
@@ -0,0 +1,65 @@
+package optional
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// CBORCodec is a pair of CBOR marshaling functions, such as cbor.Marshal and
+// cbor.Unmarshal from github.com/fxamacker/cbor/v2
+type CBORCodec struct {
+	Marshal   func(v any) ([]byte, error)
+	Unmarshal func(data []byte, v any) error
+}
+
+// ErrNoCBORCodec is returned when a set value is marshaled to or unmarshaled
+// from CBOR before a codec has been registered
+var ErrNoCBORCodec = errors.New("optional: no CBOR codec registered")
+
+var cborCodec atomic.Value // CBORCodec
+
+// RegisterCBORCodec installs the codec used to marshal the contents of set
+// values to and from CBOR. This package has no CBOR implementation of its own;
+// importing github.com/heucuva/optional/optcbor registers the fxamacker codec.
+func RegisterCBORCodec(codec CBORCodec) {
+	cborCodec.Store(codec)
+}
+
+const (
+	cborNull      = 0xf6
+	cborUndefined = 0xf7
+)
+
+// MarshalCBOR outputs the value of the Value, if `set` is set.
+// otherwise, it returns CBOR null
+func (o Value[T]) MarshalCBOR() ([]byte, error) {
+	if !o.set {
+		return []byte{cborNull}, nil
+	}
+	codec, ok := cborCodec.Load().(CBORCodec)
+	if !ok {
+		return nil, ErrNoCBORCodec
+	}
+	return codec.Marshal(&o.value)
+}
+
+// UnmarshalCBOR unmarshals a value out of CBOR and safely into our struct.
+// CBOR null and undefined both reset the value
+func (o *Value[T]) UnmarshalCBOR(data []byte) error {
+	if len(data) == 0 || (len(data) == 1 && (data[0] == cborNull || data[0] == cborUndefined)) {
+		o.Reset()
+		notifyDecode[T](len(data) != 0)
+		return nil
+	}
+	codec, ok := cborCodec.Load().(CBORCodec)
+	if !ok {
+		return ErrNoCBORCodec
+	}
+	var val T
+	if err := codec.Unmarshal(data, &val); err != nil {
+		return err
+	}
+	o.Set(val)
+	notifyDecode[T](false)
+	return nil
+}
@@ -0,0 +1,30 @@
+package optional_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestValueCBORWithoutCodec(t *testing.T) {
+	t.Run("MarshalUnset", func(t *testing.T) {
+		blob, err := optional.Value[int]{}.MarshalCBOR()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "cbor", "\xf6", string(blob))
+	})
+	t.Run("UnmarshalNull", func(t *testing.T) {
+		target := optional.NewValue(5)
+		if err := target.UnmarshalCBOR([]byte{0xf7}); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "set", false, target.IsSet())
+	})
+	t.Run("MarshalSet", func(t *testing.T) {
+		if _, err := optional.NewValue(5).MarshalCBOR(); !errors.Is(err, optional.ErrNoCBORCodec) {
+			t.Fatalf("expected ErrNoCBORCodec, got %v", err)
+		}
+	})
+}
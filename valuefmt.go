@@ -0,0 +1,31 @@
+package optional
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// String renders the value as Some(value), if it is set.
+// otherwise, it returns None
+func (o Value[T]) String() string {
+	if !o.set {
+		return "None"
+	}
+	return fmt.Sprintf("Some(%v)", o.value)
+}
+
+// GoString renders the value as the Go expression that constructs it, on a
+// single line, which is what the %#v verb prints. ToGoLiteral renders the
+// same expression formatted by gofmt
+func (o Value[T]) GoString() string {
+	if lit, err := goLiteral(o); err == nil {
+		return lit
+	}
+
+	// fall back to the default formatting for values with no literal form
+	elem := reflect.TypeOf((*T)(nil)).Elem()
+	if !o.set {
+		return fmt.Sprintf("optional.Value[%v]{}", elem)
+	}
+	return fmt.Sprintf("optional.NewValue[%v](%#v)", elem, o.value)
+}
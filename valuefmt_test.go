@@ -0,0 +1,37 @@
+package optional_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestValueFormat(t *testing.T) {
+	t.Run("String", func(t *testing.T) {
+		expect(t, "set", "Some(42)", fmt.Sprintf("%v", optional.NewValue(42)))
+		expect(t, "set string", "Some(Foo)", optional.NewValue("Foo").String())
+		expect(t, "unset", "None", fmt.Sprintf("%v", optional.Value[int]{}))
+	})
+	t.Run("GoString", func(t *testing.T) {
+		expect(t, "set", "optional.NewValue[int](42)", fmt.Sprintf("%#v", optional.NewValue(42)))
+		expect(t, "set string", `optional.NewValue[string]("Foo")`, fmt.Sprintf("%#v", optional.NewValue("Foo")))
+		expect(t, "unset", "optional.Value[int]{}", fmt.Sprintf("%#v", optional.Value[int]{}))
+	})
+	t.Run("GoStringSingleLine", func(t *testing.T) {
+		type testPoint struct {
+			X, Y int
+		}
+		expect(t, "slice", "optional.NewValue[[]int]([]int{1, 2})", fmt.Sprintf("%#v", optional.NewValue([]int{1, 2})))
+		expect(t, "map", `optional.NewValue[map[string]int](map[string]int{"a": 1, "b": 2})`,
+			fmt.Sprintf("%#v", optional.NewValue(map[string]int{"b": 2, "a": 1})))
+		expect(t, "struct", "optional.NewValue[optional_test.testPoint](optional_test.testPoint{X: 1, Y: 2})",
+			fmt.Sprintf("%#v", optional.NewValue(testPoint{X: 1, Y: 2})))
+		expect(t, "empty", "optional.NewValue[[]int]([]int{})", fmt.Sprintf("%#v", optional.NewValue([]int{})))
+	})
+	t.Run("GoStringFallback", func(t *testing.T) {
+		value := 5
+		observed := fmt.Sprintf("%#v", optional.NewValue(&value))
+		expect(t, "prefix", "optional.NewValue[*int]((*int)(", observed[:len("optional.NewValue[*int]((*int)(")])
+	})
+}
@@ -0,0 +1,44 @@
+package optional
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// GobEncode outputs the set flag of the Value, followed by the value
+// itself if `set` is set
+func (o Value[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	if err := enc.Encode(o.set); err != nil {
+		return nil, err
+	}
+	if o.set {
+		if err := enc.Encode(&o.value); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode decodes a value out of gob and safely into our struct.
+// an encoded unset value is reported to the OnDecode hook as null
+func (o *Value[T]) GobDecode(data []byte) error {
+	dec := gob.NewDecoder(bytes.NewReader(data))
+	var set bool
+	if err := dec.Decode(&set); err != nil {
+		return err
+	}
+	if !set {
+		o.Reset()
+		notifyDecode[T](true)
+		return nil
+	}
+	var val T
+	if err := dec.Decode(&val); err != nil {
+		return err
+	}
+	o.Set(val)
+	notifyDecode[T](false)
+	return nil
+}
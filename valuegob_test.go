@@ -0,0 +1,62 @@
+package optional_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func gobRoundTrip[T any](t *testing.T, source T) T {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(source); err != nil {
+		t.Fatal(err)
+	}
+	var observed T
+	if err := gob.NewDecoder(&buf).Decode(&observed); err != nil {
+		t.Fatal(err)
+	}
+	return observed
+}
+
+func TestGob(t *testing.T) {
+	t.Run("Int", func(t *testing.T) {
+		observed := gobRoundTrip(t, optional.NewValue(42))
+		expect(t, "value", 42, observed.MustGet())
+	})
+	t.Run("ZeroInt", func(t *testing.T) {
+		observed := gobRoundTrip(t, optional.NewValue(0))
+		expect(t, "set", true, observed.IsSet())
+		expect(t, "value", 0, observed.MustGet())
+	})
+	t.Run("String", func(t *testing.T) {
+		observed := gobRoundTrip(t, optional.NewValue("Foo"))
+		expect(t, "value", "Foo", observed.MustGet())
+	})
+	t.Run("Struct", func(t *testing.T) {
+		type testStruct struct {
+			A optional.Value[int]
+			B optional.Value[string]
+			C optional.Value[[]string]
+		}
+		observed := gobRoundTrip(t, testStruct{
+			A: optional.NewValue(0),
+			C: optional.NewValue([]string{"Foo"}),
+		})
+		expect(t, "A set", true, observed.A.IsSet())
+		expect(t, "A", 0, observed.A.MustGet())
+		expect(t, "B set", false, observed.B.IsSet())
+		expect(t, "C[0]", "Foo", observed.C.MustGet()[0])
+	})
+	t.Run("Unset", func(t *testing.T) {
+		type testStruct struct {
+			A optional.Value[int]
+			B int
+		}
+		observed := gobRoundTrip(t, testStruct{B: 5})
+		expect(t, "A set", false, observed.A.IsSet())
+		expect(t, "B", 5, observed.B)
+	})
+}
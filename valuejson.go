@@ -1,14 +1,37 @@
 package optional
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
 
 // MarshalJSON outputs the value of the Value, if `set` is set.
 // otherwise, it returns nil
 func (o Value[T]) MarshalJSON() ([]byte, error) {
 	if o.set {
-		return json.Marshal(o.value)
+		return marshalJSONValue(o.value)
 	}
-	return json.Marshal(nil)
+	return []byte("null"), nil
+}
+
+// AppendJSON appends the json encoding of the Value to dst, as MarshalJSON
+// would output it, and returns the extended buffer. Encoding bools, numbers,
+// and strings into a buffer with enough capacity does not allocate.
+// on error, dst is returned unchanged
+func (o Value[T]) AppendJSON(dst []byte) ([]byte, error) {
+	if !o.set {
+		return append(dst, "null"...), nil
+	}
+	if data, ok := appendJSONPrimitive(dst, o.value); ok {
+		return data, nil
+	}
+	data, err := marshalJSONValue(o.value)
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, data...), nil
 }
 
 // UnmarshalJSON unmarshals a value out of json and safely into our struct
@@ -22,5 +45,103 @@ func (o *Value[T]) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	o.Set(val)
+	notifyDecode[T](string(data) == "null")
 	return nil
 }
+
+// marshalJSONValue marshals a set value out to json.
+// json.RawMessage values are passed through as-is
+func marshalJSONValue[T any](value T) ([]byte, error) {
+	if raw, ok := any(&value).(*json.RawMessage); ok {
+		if *raw == nil {
+			return []byte("null"), nil
+		}
+		return *raw, nil
+	}
+	if data, ok := appendJSONPrimitive(make([]byte, 0, 24), value); ok {
+		return data, nil
+	}
+	// only the copy escapes, so the fast paths above do not allocate value
+	escaped := value
+	return json.Marshal(&escaped)
+}
+
+// appendJSONPrimitive appends the json encoding of value to dst, if value is
+// a bool, string, or number of a predeclared type, which encode exactly as
+// encoding/json would encode them without going through reflection.
+// it returns false for anything else, including named types (which may have
+// their own marshalers), non-finite floats, and strings needing escapes
+func appendJSONPrimitive[T any](dst []byte, value T) ([]byte, bool) {
+	switch v := any(&value).(type) {
+	case *bool:
+		return strconv.AppendBool(dst, *v), true
+	case *string:
+		return appendJSONString(dst, *v)
+	case *int:
+		return strconv.AppendInt(dst, int64(*v), 10), true
+	case *int8:
+		return strconv.AppendInt(dst, int64(*v), 10), true
+	case *int16:
+		return strconv.AppendInt(dst, int64(*v), 10), true
+	case *int32:
+		return strconv.AppendInt(dst, int64(*v), 10), true
+	case *int64:
+		return strconv.AppendInt(dst, *v, 10), true
+	case *uint:
+		return strconv.AppendUint(dst, uint64(*v), 10), true
+	case *uint8:
+		return strconv.AppendUint(dst, uint64(*v), 10), true
+	case *uint16:
+		return strconv.AppendUint(dst, uint64(*v), 10), true
+	case *uint32:
+		return strconv.AppendUint(dst, uint64(*v), 10), true
+	case *uint64:
+		return strconv.AppendUint(dst, *v, 10), true
+	case *uintptr:
+		return strconv.AppendUint(dst, uint64(*v), 10), true
+	case *float32:
+		return appendJSONFloat(dst, float64(*v), 32)
+	case *float64:
+		return appendJSONFloat(dst, *v, 64)
+	}
+	return dst, false
+}
+
+// appendJSONString appends s as a json string, if none of its characters
+// need escaping (including encoding/json's html escapes)
+func appendJSONString(dst []byte, s string) ([]byte, bool) {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c < 0x20, c >= utf8.RuneSelf, c == '"', c == '\\', c == '<', c == '>', c == '&':
+			return dst, false
+		}
+	}
+	dst = append(dst, '"')
+	dst = append(dst, s...)
+	return append(dst, '"'), true
+}
+
+// appendJSONFloat appends f the way encoding/json formats floats, if it is
+// finite
+func appendJSONFloat(dst []byte, f float64, bits int) ([]byte, bool) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return dst, false
+	}
+
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) ||
+			bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	dst = strconv.AppendFloat(dst, f, format, -1, bits)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		if n := len(dst); n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+	return dst, true
+}
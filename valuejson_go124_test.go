@@ -0,0 +1,56 @@
+//go:build go1.24
+
+package optional_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestValueOmitZero(t *testing.T) {
+	type testOmitRequest struct {
+		Name  optional.Value[string] `json:"name,omitzero"`
+		Count optional.Value[int]    `json:"count,omitzero"`
+		Email optional.Value[string] `json:"email,omitzero"`
+		Boxed optional.Boxed[int]    `json:"boxed,omitzero"`
+	}
+	blob, err := json.Marshal(testOmitRequest{
+		Name:  optional.NewValue("Foo"),
+		Count: optional.NewValue(0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, "json", `{"name":"Foo","count":0}`, string(blob))
+}
+
+func TestWrapperOmitZero(t *testing.T) {
+	type testOmitRequest struct {
+		Frozen optional.ReadOnly[int]     `json:"frozen,omitzero"`
+		Strict optional.Strict[int]       `json:"strict,omitzero"`
+		Secret optional.Sensitive[string] `json:"secret,omitzero"`
+	}
+	blob, err := json.Marshal(testOmitRequest{
+		Strict: optional.NewStrict(0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, "json", `{"strict":0}`, string(blob))
+}
+
+func TestEncodedOmitZero(t *testing.T) {
+	type testOmitRequest struct {
+		Null optional.Encoded[int, optional.UnsetNull]           `json:"null,omitzero"`
+		Omit optional.Encoded[int, optional.UnsetOmit]           `json:"omit,omitzero"`
+		Set  optional.Encoded[int, optional.UnsetOmit]           `json:"set,omitzero"`
+		Text optional.Encoded[string, optional.UnsetEmptyString] `json:"text,omitzero"`
+	}
+	blob, err := json.Marshal(testOmitRequest{Set: optional.NewEncoded[int, optional.UnsetOmit](0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, "json", `{"null":null,"set":0,"text":""}`, string(blob))
+}
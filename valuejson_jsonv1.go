@@ -0,0 +1,12 @@
+//go:build !goexperiment.jsonv2
+
+package optional
+
+import "reflect"
+
+// passesJSONOptions returns true if encoding/json passes the options of a
+// field of type t, such as `string`, through to its value. without
+// encoding/json/v2, it never does
+func passesJSONOptions(t reflect.Type) bool {
+	return false
+}
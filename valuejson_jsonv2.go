@@ -0,0 +1,63 @@
+//go:build goexperiment.jsonv2
+
+package optional
+
+import (
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"reflect"
+)
+
+var jsonMarshalerToType = reflect.TypeOf((*jsonv2.MarshalerTo)(nil)).Elem()
+
+// passesJSONOptions returns true if encoding/json passes the options of a
+// field of type t, such as `string`, through to its value
+func passesJSONOptions(t reflect.Type) bool {
+	return t.Implements(jsonMarshalerToType)
+}
+
+// MarshalJSONTo writes the value of the Value to enc with encoding/json/v2,
+// using enc's options, if `set` is set. otherwise, it writes null.
+// unset values are also omitted from fields tagged omitzero (by IsZero) or
+// omitempty (since they encode as null)
+func (o Value[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !o.set {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return jsonv2.MarshalEncode(enc, &o.value)
+}
+
+// UnmarshalJSONFrom reads a value from dec with encoding/json/v2, using
+// dec's options. as with UnmarshalJSON, null sets T's zero value
+func (o *Value[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	wasNull := dec.PeekKind() == 'n'
+	var val T
+	if err := jsonv2.UnmarshalDecode(dec, &val); err != nil {
+		return err
+	}
+	o.Set(val)
+	notifyDecode[T](wasNull)
+	return nil
+}
+
+// MarshalJSONTo writes the value of the Boxed to enc with encoding/json/v2,
+// using enc's options, if it is set. otherwise, it writes null
+func (o Boxed[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if o.value == nil {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return jsonv2.MarshalEncode(enc, o.value)
+}
+
+// UnmarshalJSONFrom reads a value from dec with encoding/json/v2, using
+// dec's options. as with UnmarshalJSON, null sets T's zero value
+func (o *Boxed[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	wasNull := dec.PeekKind() == 'n'
+	var val T
+	if err := jsonv2.UnmarshalDecode(dec, &val); err != nil {
+		return err
+	}
+	o.Set(val)
+	notifyDecode[T](wasNull)
+	return nil
+}
@@ -0,0 +1,74 @@
+//go:build goexperiment.jsonv2
+
+package optional_test
+
+import (
+	jsonv2 "encoding/json/v2"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestJSONv2(t *testing.T) {
+	type record struct {
+		Name     optional.Value[string]   `json:"name,omitzero"`
+		Age      optional.Value[int]      `json:"age,omitempty"`
+		Nickname optional.Value[string]   `json:"nickname"`
+		Count    optional.Boxed[int]      `json:"count,omitzero"`
+		Tags     optional.Value[[]string] `json:"tags"`
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			value    record
+			expected string
+		}{
+			{"Unset", record{}, `{"nickname":null,"tags":null}`},
+			{"Zero", record{
+				Name:  optional.NewValue(""),
+				Age:   optional.NewValue(0),
+				Count: optional.NewBoxed(0),
+				Tags:  optional.NewValue[[]string](nil),
+			}, `{"name":"","age":0,"nickname":null,"count":0,"tags":[]}`},
+			{"Set", record{
+				Name:     optional.NewValue("Foo"),
+				Nickname: optional.NewValue("foo"),
+				Tags:     optional.NewValue([]string{"a"}),
+			}, `{"name":"Foo","nickname":"foo","tags":["a"]}`},
+		}
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				blob, err := jsonv2.Marshal(tc.value)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expect(t, "json", tc.expected, string(blob))
+			})
+		}
+	})
+	t.Run("EncoderOptions", func(t *testing.T) {
+		blob, err := jsonv2.Marshal(optional.NewValue([]string(nil)), jsonv2.FormatNilSliceAsNull(true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", "null", string(blob))
+	})
+	t.Run("Unmarshal", func(t *testing.T) {
+		var observed record
+		observed.Nickname.Set("foo")
+		if err := jsonv2.Unmarshal([]byte(`{"name":"Foo","age":null,"count":3}`), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "name", "Foo", observed.Name.MustGet())
+		expect(t, "age", 0, observed.Age.MustGet())
+		expect(t, "count", 3, observed.Count.Value().MustGet())
+		expect(t, "nickname", "foo", observed.Nickname.MustGet())
+		expect(t, "tags set", false, observed.Tags.IsSet())
+	})
+	t.Run("UnmarshalError", func(t *testing.T) {
+		var observed optional.Value[int]
+		expect(t, "error", true, jsonv2.Unmarshal([]byte(`"Foo"`), &observed) != nil)
+		expect(t, "set", false, observed.IsSet())
+	})
+}
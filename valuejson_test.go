@@ -572,3 +572,107 @@ func TestUnmarshalJSON(t *testing.T) {
 		})
 	})
 }
+
+// TestMarshalJSONFastPath checks that primitives encoded without reflection
+// come out exactly as encoding/json encodes them
+func TestMarshalJSONFastPath(t *testing.T) {
+	values := []any{
+		true, false, 0, -42, int8(-8), int16(1600), int32(-32), int64(math.MinInt64),
+		uint(7), uint8(255), uint16(65535), uint32(math.MaxUint32), uint64(math.MaxUint64), uintptr(9),
+		0.0, math.Copysign(0, -1), 1.5, -0.1, 1e-7, 123456789e-20, 1e20, 1e21, -1e300, math.SmallestNonzeroFloat64,
+		float32(0.1), float32(1e-7), float32(3.4e38),
+		"", "Foo", "with space", "quote\"", "back\\slash", "<b>&amp;", "tab\t", "ünïcode", " ",
+	}
+	for _, value := range values {
+		expected, err := json.Marshal(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var observed []byte
+		switch v := value.(type) {
+		case bool:
+			observed, err = optional.NewValue(v).MarshalJSON()
+		case string:
+			observed, err = optional.NewValue(v).MarshalJSON()
+		case int:
+			observed, err = optional.NewValue(v).MarshalJSON()
+		case int8:
+			observed, err = optional.NewValue(v).MarshalJSON()
+		case int16:
+			observed, err = optional.NewValue(v).MarshalJSON()
+		case int32:
+			observed, err = optional.NewValue(v).MarshalJSON()
+		case int64:
+			observed, err = optional.NewValue(v).MarshalJSON()
+		case uint:
+			observed, err = optional.NewValue(v).MarshalJSON()
+		case uint8:
+			observed, err = optional.NewValue(v).MarshalJSON()
+		case uint16:
+			observed, err = optional.NewValue(v).MarshalJSON()
+		case uint32:
+			observed, err = optional.NewValue(v).MarshalJSON()
+		case uint64:
+			observed, err = optional.NewValue(v).MarshalJSON()
+		case uintptr:
+			observed, err = optional.NewValue(v).MarshalJSON()
+		case float32:
+			observed, err = optional.NewValue(v).MarshalJSON()
+		case float64:
+			observed, err = optional.NewValue(v).MarshalJSON()
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(observed) != string(expected) {
+			t.Errorf("%T %v: expected %s, got %s", value, value, expected, observed)
+		}
+	}
+
+	t.Run("NonFinite", func(t *testing.T) {
+		if _, err := optional.NewValue(math.Inf(1)).MarshalJSON(); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+	t.Run("Unset", func(t *testing.T) {
+		observed, err := optional.Value[int]{}.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", "null", string(observed))
+	})
+}
+
+func TestAppendJSON(t *testing.T) {
+	buf := []byte(`[`)
+	var err error
+	buf, err = optional.NewValue(5).AppendJSON(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf = append(buf, ',')
+	buf, err = optional.Value[string]{}.AppendJSON(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf = append(buf, ',')
+	buf, err = optional.NewValue(map[string]int{"a": 1}).AppendJSON(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf = append(buf, ',')
+	buf, err = optional.NewValue(json.RawMessage(`{"b":2}`)).AppendJSON(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf = append(buf, ']')
+	expect(t, "json", `[5,null,{"a":1},{"b":2}]`, string(buf))
+
+	t.Run("Error", func(t *testing.T) {
+		observed, err := optional.NewValue(math.NaN()).AppendJSON([]byte("Foo"))
+		if err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+		expect(t, "buffer", "Foo", string(observed))
+	})
+}
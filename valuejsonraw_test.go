@@ -0,0 +1,65 @@
+package optional_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestJSONRawMessage(t *testing.T) {
+	type testEnvelope struct {
+		Kind    string                          `json:"kind"`
+		Payload optional.Value[json.RawMessage] `json:"payload"`
+		Extra   optional.Field[json.RawMessage] `json:"extra"`
+		Boxed   optional.Boxed[json.RawMessage] `json:"boxed"`
+	}
+
+	t.Run("Marshal", func(t *testing.T) {
+		raw, err := optional.NewValue(json.RawMessage(`{"a":[1,2]}`)).MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "raw", `{"a":[1,2]}`, string(raw))
+
+		blob, err := json.Marshal(testEnvelope{
+			Kind:    "foo",
+			Payload: optional.NewValue(json.RawMessage(`{"a":[1,2]}`)),
+			Extra:   optional.NullField[json.RawMessage](),
+			Boxed:   optional.NewBoxed(json.RawMessage(`"b"`)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", `{"kind":"foo","payload":{"a":[1,2]},"extra":null,"boxed":"b"}`, string(blob))
+	})
+	t.Run("MarshalNilRaw", func(t *testing.T) {
+		blob, err := json.Marshal(optional.NewValue(json.RawMessage(nil)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", `null`, string(blob))
+	})
+	t.Run("Unmarshal", func(t *testing.T) {
+		var observed testEnvelope
+		data := []byte(`{"kind":"foo","payload":{"a": [1, 2]},"extra":null}`)
+		if err := json.Unmarshal(data, &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "payload", `{"a": [1, 2]}`, string(observed.Payload.MustGet()))
+		expect(t, "extra.IsNull", true, observed.Extra.IsNull())
+		expect(t, "boxed set", false, observed.Boxed.IsSet())
+
+		// the captured payload must not alias the input buffer
+		copy(data, []byte(`{"kind":"bar","payload":{"b"`))
+		expect(t, "payload after reuse", `{"a": [1, 2]}`, string(observed.Payload.MustGet()))
+	})
+	t.Run("UnmarshalAbsent", func(t *testing.T) {
+		var observed testEnvelope
+		if err := json.Unmarshal([]byte(`{"kind":"foo"}`), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "payload set", false, observed.Payload.IsSet())
+		expect(t, "extra.IsAbsent", true, observed.Extra.IsAbsent())
+	})
+}
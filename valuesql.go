@@ -0,0 +1,110 @@
+package optional
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Value outputs the value of the Value as a database/sql driver value,
+// if `set` is set. otherwise, it returns nil (SQL NULL)
+func (o Value[T]) Value() (driver.Value, error) {
+	if !o.set {
+		return nil, nil
+	}
+	if valuer, ok := asInterface[driver.Valuer](&o.value); ok {
+		return valuer.Value()
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
+
+// Scan reads a database/sql column value safely into our struct.
+// SQL NULL resets the value, and anything else is converted into T
+// (by delegating to T, if *T implements sql.Scanner)
+func (o *Value[T]) Scan(src any) error {
+	if src == nil {
+		o.Reset()
+		notifyDecode[T](true)
+		return nil
+	}
+
+	var val T
+	if scanner, ok := any(&val).(sql.Scanner); ok {
+		if err := scanner.Scan(src); err != nil {
+			return err
+		}
+	} else if err := scanAssign(reflect.ValueOf(&val).Elem(), src); err != nil {
+		return err
+	}
+	o.Set(val)
+	notifyDecode[T](false)
+	return nil
+}
+
+// scanAssign converts a driver value (int64, float64, bool, []byte, string,
+// or time.Time) into dst
+func scanAssign(dst reflect.Value, src any) error {
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dst.Type()) {
+		if b, ok := src.([]byte); ok {
+			// drivers may reuse the memory backing a []byte
+			src = append([]byte(nil), b...)
+			sv = reflect.ValueOf(src)
+		}
+		dst.Set(sv)
+		return nil
+	}
+
+	var text string
+	switch s := src.(type) {
+	case string:
+		text = s
+	case []byte:
+		text = string(s)
+	case time.Time:
+		text = s.Format(time.RFC3339Nano)
+	default:
+		text = fmt.Sprint(src)
+	}
+
+	if dst.Type() == timeType {
+		if t, ok := parseSQLTime(text); ok {
+			dst.Set(reflect.ValueOf(t))
+			return nil
+		}
+	}
+
+	handled, err := parseText(dst, text)
+	if handled {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("optional: converting %T %q to %v: %w", src, text, dst.Type(), err)
+	}
+	return fmt.Errorf("optional: unsupported scan, storing driver.Value type %T into type %v", src, dst.Type())
+}
+
+// sqlTimeLayouts are the layouts drivers commonly use for timestamps stored
+// as text, such as SQLite's
+var sqlTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// parseSQLTime parses text in any of sqlTimeLayouts, assuming UTC where the
+// layout has no zone
+func parseSQLTime(text string) (time.Time, bool) {
+	for _, layout := range sqlTimeLayouts {
+		if t, err := time.ParseInLocation(layout, text, time.UTC); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
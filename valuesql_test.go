@@ -0,0 +1,175 @@
+package optional_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heucuva/optional"
+)
+
+var (
+	_ driver.Valuer = optional.Value[int]{}
+	_ sql.Scanner   = (*optional.Value[int])(nil)
+)
+
+type testSQLUpper string
+
+func (u testSQLUpper) Value() (driver.Value, error) {
+	return strings.ToUpper(string(u)), nil
+}
+
+func (u *testSQLUpper) Scan(src any) error {
+	s, ok := src.(string)
+	if !ok {
+		return errors.New("expected string")
+	}
+	*u = testSQLUpper(strings.ToLower(s))
+	return nil
+}
+
+func TestValueSQL(t *testing.T) {
+	t.Run("Unset", func(t *testing.T) {
+		observed, err := optional.Value[int]{}.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if observed != nil {
+			t.Fatalf("expected nil, got %v", observed)
+		}
+	})
+	t.Run("Int", func(t *testing.T) {
+		observed, err := optional.NewValue(5).Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "value", int64(5), observed.(int64))
+	})
+	t.Run("String", func(t *testing.T) {
+		observed, err := optional.NewValue("Foo").Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "value", "Foo", observed.(string))
+	})
+	t.Run("Valuer", func(t *testing.T) {
+		observed, err := optional.NewValue(testSQLUpper("Foo")).Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "value", "FOO", observed.(string))
+	})
+}
+
+func TestScanSQL(t *testing.T) {
+	t.Run("Null", func(t *testing.T) {
+		target := optional.NewValue(5)
+		if err := target.Scan(nil); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "set", false, target.IsSet())
+	})
+	t.Run("Int", func(t *testing.T) {
+		var target optional.Value[int32]
+		if err := target.Scan(int64(42)); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "value", int32(42), target.MustGet())
+	})
+	t.Run("IntOverflow", func(t *testing.T) {
+		var target optional.Value[int8]
+		if err := target.Scan(int64(1000)); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+		expect(t, "set", false, target.IsSet())
+	})
+	t.Run("IntFromBytes", func(t *testing.T) {
+		var target optional.Value[uint64]
+		if err := target.Scan([]byte("42")); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "value", uint64(42), target.MustGet())
+	})
+	t.Run("Float", func(t *testing.T) {
+		var target optional.Value[float64]
+		if err := target.Scan(int64(2)); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "value", 2.0, target.MustGet())
+	})
+	t.Run("Bool", func(t *testing.T) {
+		var target optional.Value[bool]
+		if err := target.Scan(int64(1)); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "value", true, target.MustGet())
+	})
+	t.Run("StringFromBytes", func(t *testing.T) {
+		var target optional.Value[string]
+		if err := target.Scan([]byte("Foo")); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "value", "Foo", target.MustGet())
+	})
+	t.Run("BytesCopied", func(t *testing.T) {
+		var target optional.Value[[]byte]
+		src := []byte("Foo")
+		if err := target.Scan(src); err != nil {
+			t.Fatal(err)
+		}
+		src[0] = 'B'
+		expect(t, "value", "Foo", string(target.MustGet()))
+	})
+	t.Run("Time", func(t *testing.T) {
+		var target optional.Value[time.Time]
+		expected := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+		if err := target.Scan(expected); err != nil {
+			t.Fatal(err)
+		}
+		if !target.MustGet().Equal(expected) {
+			t.Fatalf("expected %v, got %v", expected, target.MustGet())
+		}
+		if err := target.Scan("2022-01-02T03:04:05Z"); err != nil {
+			t.Fatal(err)
+		}
+		if !target.MustGet().Equal(expected) {
+			t.Fatalf("expected %v, got %v", expected, target.MustGet())
+		}
+	})
+	t.Run("TimeLayouts", func(t *testing.T) {
+		expected := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+		for _, src := range []any{
+			"2022-01-02 03:04:05",
+			[]byte("2022-01-02 03:04:05+00:00"),
+			"2022-01-02T05:04:05+02:00",
+		} {
+			var target optional.Value[time.Time]
+			if err := target.Scan(src); err != nil {
+				t.Fatal(err)
+			}
+			if !target.MustGet().Equal(expected) {
+				t.Fatalf("%v: expected %v, got %v", src, expected, target.MustGet())
+			}
+		}
+		var target optional.Value[time.Time]
+		if err := target.Scan("yesterday"); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+	t.Run("Scanner", func(t *testing.T) {
+		var target optional.Value[testSQLUpper]
+		if err := target.Scan("FOO"); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "value", testSQLUpper("foo"), target.MustGet())
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		var target optional.Value[[]int]
+		if err := target.Scan(int64(5)); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package optional
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// MarshalText outputs the value of the Value as text, if `set` is set.
+// otherwise, it returns empty text
+//
+// The value is encoded by T's own MarshalText, if it has one. otherwise,
+// strings, bools, and numbers are formatted with strconv
+func (o Value[T]) MarshalText() ([]byte, error) {
+	if !o.set {
+		return []byte{}, nil
+	}
+	if marshaler, ok := asInterface[encoding.TextMarshaler](&o.value); ok {
+		return marshaler.MarshalText()
+	}
+
+	rv := reflect.ValueOf(&o.value).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		return []byte(rv.String()), nil
+	case reflect.Bool:
+		return strconv.AppendBool(nil, rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(nil, rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(nil, rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(nil, rv.Float(), 'g', -1, rv.Type().Bits()), nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return append([]byte{}, rv.Bytes()...), nil
+		}
+	}
+	return nil, fmt.Errorf("optional: cannot marshal %v as text", rv.Type())
+}
+
+// UnmarshalText unmarshals a value out of text and safely into our struct.
+// empty text resets the value, and is reported to the OnDecode hook as null
+//
+// The value is decoded by T's own UnmarshalText, if *T has one. otherwise,
+// strings, bools, and numbers are parsed with strconv
+func (o *Value[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		o.Reset()
+		notifyDecode[T](true)
+		return nil
+	}
+
+	val, err := parseTextValue[T](text)
+	if err != nil {
+		return err
+	}
+	o.Set(val)
+	notifyDecode[T](false)
+	return nil
+}
+
+// parseTextValue decodes text into a T, using T's own UnmarshalText if *T
+// has one
+func parseTextValue[T any](text []byte) (T, error) {
+	var val T
+	if unmarshaler, ok := any(&val).(encoding.TextUnmarshaler); ok {
+		err := unmarshaler.UnmarshalText(text)
+		return val, err
+	}
+
+	rv := reflect.ValueOf(&val).Elem()
+	handled, err := parseText(rv, string(text))
+	if err != nil {
+		return val, fmt.Errorf("optional: converting %q to %v: %w", text, rv.Type(), err)
+	}
+	if !handled {
+		return val, fmt.Errorf("optional: cannot unmarshal text into %v", rv.Type())
+	}
+	return val, nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// parseText parses text into dst, if dst is a string, []byte, bool, number,
+// or time.Time (RFC 3339).
+// it returns false if dst is not one of the supported types
+func parseText(dst reflect.Value, text string) (bool, error) {
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(text)
+		return true, nil
+	case reflect.Slice:
+		if dst.Type().Elem().Kind() == reflect.Uint8 {
+			dst.SetBytes([]byte(text))
+			return true, nil
+		}
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return false, err
+		}
+		dst.SetBool(b)
+		return true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(text, 10, dst.Type().Bits())
+		if err != nil {
+			return false, err
+		}
+		dst.SetInt(i)
+		return true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(text, 10, dst.Type().Bits())
+		if err != nil {
+			return false, err
+		}
+		dst.SetUint(u)
+		return true, nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, dst.Type().Bits())
+		if err != nil {
+			return false, err
+		}
+		dst.SetFloat(f)
+		return true, nil
+	case reflect.Struct:
+		if dst.Type() == timeType {
+			t, err := time.Parse(time.RFC3339Nano, text)
+			if err != nil {
+				return false, err
+			}
+			dst.Set(reflect.ValueOf(t))
+			return true, nil
+		}
+	}
+	return false, nil
+}
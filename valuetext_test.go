@@ -0,0 +1,107 @@
+package optional_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+var (
+	_ encoding.TextMarshaler   = optional.Value[int]{}
+	_ encoding.TextUnmarshaler = (*optional.Value[int])(nil)
+)
+
+func TestMarshalText(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    encoding.TextMarshaler
+		expected string
+	}{
+		{"Unset", optional.Value[int]{}, ""},
+		{"String", optional.NewValue("Foo"), "Foo"},
+		{"Bool", optional.NewValue(true), "true"},
+		{"Int", optional.NewValue(-42), "-42"},
+		{"Uint8", optional.NewValue[uint8](255), "255"},
+		{"Float32", optional.NewValue[float32](1.5), "1.5"},
+		{"Bytes", optional.NewValue([]byte("Foo")), "Foo"},
+		{"TextMarshaler", optional.NewValue(net.IPv4(127, 0, 0, 1)), "127.0.0.1"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			observed, err := tc.value.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			expect(t, "text", tc.expected, string(observed))
+		})
+	}
+	t.Run("Unsupported", func(t *testing.T) {
+		if _, err := optional.NewValue([]int{1}).MarshalText(); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+}
+
+func TestUnmarshalText(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		target := optional.NewValue(5)
+		if err := target.UnmarshalText(nil); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "set", false, target.IsSet())
+	})
+	t.Run("Int", func(t *testing.T) {
+		var target optional.Value[int16]
+		if err := target.UnmarshalText([]byte("-42")); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "value", int16(-42), target.MustGet())
+	})
+	t.Run("Float", func(t *testing.T) {
+		var target optional.Value[float64]
+		if err := target.UnmarshalText([]byte("1.5")); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "value", 1.5, target.MustGet())
+	})
+	t.Run("Bool", func(t *testing.T) {
+		var target optional.Value[bool]
+		if err := target.UnmarshalText([]byte("true")); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "value", true, target.MustGet())
+	})
+	t.Run("TextUnmarshaler", func(t *testing.T) {
+		var target optional.Value[net.IP]
+		if err := target.UnmarshalText([]byte("127.0.0.1")); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "value", "127.0.0.1", target.MustGet().String())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var target optional.Value[uint8]
+		if err := target.UnmarshalText([]byte("256")); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+		expect(t, "set", false, target.IsSet())
+	})
+	t.Run("JSONMapKey", func(t *testing.T) {
+		source := map[optional.Value[int]]string{
+			optional.NewValue(1): "one",
+		}
+		blob, err := json.Marshal(source)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "json", `{"1":"one"}`, string(blob))
+
+		var observed map[optional.Value[int]]string
+		if err := json.Unmarshal(blob, &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "value", "one", observed[optional.NewValue(1)])
+	})
+}
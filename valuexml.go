@@ -0,0 +1,60 @@
+package optional
+
+import "encoding/xml"
+
+// MarshalXML outputs the value of the Value as an element, if `set` is set.
+// otherwise, it outputs nothing at all
+func (o Value[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !o.set {
+		return nil
+	}
+	value := o.value
+	return e.EncodeElement(&value, start)
+}
+
+// UnmarshalXML unmarshals a value out of an xml element and safely into our struct
+func (o *Value[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var val T
+	if err := d.DecodeElement(&val, &start); err != nil {
+		return err
+	}
+	o.Set(val)
+	notifyDecode[T](false)
+	return nil
+}
+
+// MarshalXMLAttr outputs the value of the Value as an attribute, if `set` is set.
+// otherwise, it returns an empty attribute, which omits it
+func (o Value[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !o.set {
+		return xml.Attr{}, nil
+	}
+	if marshaler, ok := asInterface[xml.MarshalerAttr](&o.value); ok {
+		return marshaler.MarshalXMLAttr(name)
+	}
+	text, err := o.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// UnmarshalXMLAttr unmarshals a value out of an xml attribute and safely into our struct
+func (o *Value[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	var val T
+	if unmarshaler, ok := any(&val).(xml.UnmarshalerAttr); ok {
+		if err := unmarshaler.UnmarshalXMLAttr(attr); err != nil {
+			return err
+		}
+		o.Set(val)
+		notifyDecode[T](false)
+		return nil
+	}
+	val, err := parseTextValue[T]([]byte(attr.Value))
+	if err != nil {
+		return err
+	}
+	o.Set(val)
+	notifyDecode[T](false)
+	return nil
+}
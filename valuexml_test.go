@@ -0,0 +1,67 @@
+package optional_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+type testXMLRequest struct {
+	XMLName xml.Name               `xml:"request"`
+	ID      optional.Value[int]    `xml:"id,attr"`
+	Lang    optional.Value[string] `xml:"lang,attr"`
+	Name    optional.Value[string] `xml:"name"`
+	Count   optional.Value[int]    `xml:"count"`
+}
+
+func TestMarshalXML(t *testing.T) {
+	t.Run("Unset", func(t *testing.T) {
+		blob, err := xml.Marshal(testXMLRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "xml", `<request></request>`, string(blob))
+	})
+	t.Run("Set", func(t *testing.T) {
+		blob, err := xml.Marshal(testXMLRequest{
+			ID:    optional.NewValue(5),
+			Lang:  optional.NewValue(""),
+			Name:  optional.NewValue("Foo"),
+			Count: optional.NewValue(0),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "xml", `<request id="5" lang=""><name>Foo</name><count>0</count></request>`, string(blob))
+	})
+}
+
+func TestUnmarshalXML(t *testing.T) {
+	t.Run("Unset", func(t *testing.T) {
+		var observed testXMLRequest
+		if err := xml.Unmarshal([]byte(`<request></request>`), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "ID set", false, observed.ID.IsSet())
+		expect(t, "Name set", false, observed.Name.IsSet())
+		expect(t, "Count set", false, observed.Count.IsSet())
+	})
+	t.Run("Set", func(t *testing.T) {
+		var observed testXMLRequest
+		data := `<request id="5" lang=""><name>Foo</name><count>0</count></request>`
+		if err := xml.Unmarshal([]byte(data), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "ID", 5, observed.ID.MustGet())
+		expect(t, "Lang set", true, observed.Lang.IsSet())
+		expect(t, "Name", "Foo", observed.Name.MustGet())
+		expect(t, "Count", 0, observed.Count.MustGet())
+	})
+	t.Run("InvalidAttr", func(t *testing.T) {
+		var observed testXMLRequest
+		if err := xml.Unmarshal([]byte(`<request id="x"></request>`), &observed); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+}
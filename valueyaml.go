@@ -1,21 +1,44 @@
 package optional
 
 // MarshalYAML outputs the value of the Value, if `set` is set.
-// otherwise, it returns nil
-func (o Value[T]) MarshalYAML() (T, error) {
+// otherwise, it returns nil, which encodes as an explicit null
+//
+// This satisfies the Marshaler interface of both gopkg.in/yaml.v2 and
+// gopkg.in/yaml.v3, as they share the same signature.
+func (o Value[T]) MarshalYAML() (any, error) {
 	if o.set {
-		return o.value, nil
+		return marshalYAMLValue(o.value)
 	}
-	var empty T
-	return empty, nil
+	return nil, nil
 }
 
 // UnmarshalYAML unmarshals a value out of yaml and safely into our struct
+//
+// gopkg.in/yaml.v3 still honors this yaml.v2-style signature, so Go's lack
+// of overloading (which rules out a second UnmarshalYAML(*yaml.Node) method)
+// is not a problem. neither version calls it for null nodes, so a null leaves
+// the value untouched (i.e. unset, when decoding into a fresh value).
 func (o *Value[T]) UnmarshalYAML(unmarshal func(any) error) error {
 	var val T
 	if err := unmarshal(&val); err != nil {
 		return err
 	}
 	o.Set(val)
+	// yaml never hands null nodes to an unmarshaler
+	notifyDecode[T](false)
 	return nil
 }
+
+// yamlMarshaler matches the Marshaler interface of both yaml.v2 and yaml.v3
+type yamlMarshaler interface {
+	MarshalYAML() (any, error)
+}
+
+// marshalYAMLValue prepares a set value for yaml, calling its MarshalYAML
+// directly when it has one (yaml.v2 will not find one declared on *T)
+func marshalYAMLValue[T any](value T) (any, error) {
+	if marshaler, ok := asInterface[yamlMarshaler](&value); ok {
+		return marshaler.MarshalYAML()
+	}
+	return value, nil
+}
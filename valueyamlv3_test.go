@@ -0,0 +1,107 @@
+package optional_test
+
+import (
+	"testing"
+
+	"github.com/heucuva/optional"
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+type testYAMLDocument struct {
+	Name  optional.Value[string]   `yaml:"name"`
+	Count optional.Value[int]      `yaml:"count"`
+	Tags  optional.Value[[]string] `yaml:"tags"`
+}
+
+func TestMarshalYAMLv3(t *testing.T) {
+	t.Run("Unset", func(t *testing.T) {
+		blob, err := yamlv3.Marshal(testYAMLDocument{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "yaml", "name: null\ncount: null\ntags: null\n", string(blob))
+	})
+	t.Run("Set", func(t *testing.T) {
+		blob, err := yamlv3.Marshal(testYAMLDocument{
+			Name:  optional.NewValue("Foo"),
+			Count: optional.NewValue(0),
+			Tags:  optional.NewValue([]string{"a"}),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "yaml", "name: Foo\ncount: 0\ntags:\n    - a\n", string(blob))
+	})
+	t.Run("v2", func(t *testing.T) {
+		blob, err := yaml.Marshal(testYAMLDocument{Count: optional.NewValue(5)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "yaml", "name: null\ncount: 5\ntags: null\n", string(blob))
+	})
+}
+
+func TestUnmarshalYAMLv3(t *testing.T) {
+	t.Run("Absent", func(t *testing.T) {
+		var observed testYAMLDocument
+		if err := yamlv3.Unmarshal([]byte("name: Foo\n"), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "name", "Foo", observed.Name.MustGet())
+		expect(t, "count set", false, observed.Count.IsSet())
+	})
+	t.Run("Null", func(t *testing.T) {
+		var observed testYAMLDocument
+		if err := yamlv3.Unmarshal([]byte("count: !!null\ntags: ~\n"), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "count set", false, observed.Count.IsSet())
+		expect(t, "tags set", false, observed.Tags.IsSet())
+	})
+	t.Run("Set", func(t *testing.T) {
+		var observed testYAMLDocument
+		if err := yamlv3.Unmarshal([]byte("count: 0\ntags: [a, b]\n"), &observed); err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "count", 0, observed.Count.MustGet())
+		expect(t, "len(tags)", 2, len(observed.Tags.MustGet()))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var observed testYAMLDocument
+		if err := yamlv3.Unmarshal([]byte("count: Foo\n"), &observed); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+}
+
+func TestMarshalYAMLOmitEmpty(t *testing.T) {
+	type testOmitDocument struct {
+		Name   optional.Value[string]     `yaml:"name,omitempty"`
+		Count  optional.Value[int]        `yaml:"count,omitempty"`
+		Boxed  optional.Boxed[int]        `yaml:"boxed,omitempty"`
+		Frozen optional.ReadOnly[int]     `yaml:"frozen,omitempty"`
+		Strict optional.Strict[int]       `yaml:"strict,omitempty"`
+		Secret optional.Sensitive[string] `yaml:"secret,omitempty"`
+	}
+	doc := testOmitDocument{
+		Name:  optional.NewValue("Foo"),
+		Count: optional.NewValue(0),
+	}
+	const expected = "name: Foo\ncount: 0\n"
+
+	t.Run("v2", func(t *testing.T) {
+		blob, err := yaml.Marshal(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "yaml", expected, string(blob))
+	})
+	t.Run("v3", func(t *testing.T) {
+		blob, err := yamlv3.Marshal(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect(t, "yaml", expected, string(blob))
+	})
+}
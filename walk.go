@@ -0,0 +1,86 @@
+package optional
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// WalkPresence calls fn for every optional field found in the struct v (or
+// the struct v points to), with the field's path and whether it is set.
+// Nested structs are walked recursively, with their field names joined by dots.
+func WalkPresence(v any, fn func(path string, set bool)) error {
+	rv, err := structValue(v)
+	if err != nil {
+		return err
+	}
+	return walkOptionals("", rv, func(path string, opt anyOptional) error {
+		fn(path, opt.IsSet())
+		return nil
+	})
+}
+
+// structValue dereferences v down to the struct it holds
+func structValue(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return reflect.Value{}, errors.New("optional: cannot walk nil")
+	}
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}, errors.New("optional: cannot walk a nil pointer")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || IsOptionalType(rv.Type()) {
+		return reflect.Value{}, fmt.Errorf("optional: cannot walk non-struct type %v", rv.Type())
+	}
+	return rv, nil
+}
+
+// isNestedStruct returns true if t is a struct whose fields are handled one
+// by one, rather than as a whole. optionals are not, and neither are structs
+// without exported fields, such as time.Time, whose state is all hidden
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || IsOptionalType(t) {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// walkOptionals calls fn for every optional field in the struct rv
+func walkOptionals(prefix string, rv reflect.Value, fn func(path string, opt anyOptional) error) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		path := prefix + field.Name
+		fv := rv.Field(i)
+		for fv.Kind() == reflect.Pointer && !fv.IsNil() && !IsOptionalType(fv.Type()) {
+			fv = fv.Elem()
+		}
+		if fv.Kind() != reflect.Struct {
+			continue
+		}
+
+		if !IsOptionalType(fv.Type()) {
+			if err := walkOptionals(path+".", fv, fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := fn(path, fv.Interface().(anyOptional)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
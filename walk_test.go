@@ -0,0 +1,45 @@
+package optional_test
+
+import (
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestWalkPresence(t *testing.T) {
+	type testInner struct {
+		Port optional.Value[int]
+	}
+	type testRequest struct {
+		Name  optional.Value[string]
+		Token optional.Sensitive[string]
+		Inner *testInner
+		Plain int
+	}
+
+	observed := make(map[string]bool)
+	err := optional.WalkPresence(&testRequest{
+		Name:  optional.NewValue("Foo"),
+		Inner: &testInner{},
+	}, func(path string, set bool) {
+		observed[path] = set
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, "len", 3, len(observed))
+	expect(t, "Name", true, observed["Name"])
+	expect(t, "Token", false, observed["Token"])
+	expect(t, "Inner.Port", false, observed["Inner.Port"])
+
+	t.Run("NonStruct", func(t *testing.T) {
+		if err := optional.WalkPresence(5, func(string, bool) {}); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+	t.Run("Nil", func(t *testing.T) {
+		if err := optional.WalkPresence(nil, func(string, bool) {}); err == nil {
+			t.Fatal("expected failure, but got success")
+		}
+	})
+}
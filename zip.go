@@ -0,0 +1,32 @@
+package optional
+
+// Pair holds two values, as produced by Zip
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Triple holds three values, as produced by Zip3
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// Zip returns a Value holding a Pair of the values of a and b, if both are
+// set. otherwise, it returns an unset Value
+func Zip[A, B any](a Value[A], b Value[B]) Value[Pair[A, B]] {
+	if !a.set || !b.set {
+		return Value[Pair[A, B]]{}
+	}
+	return NewValue(Pair[A, B]{First: a.value, Second: b.value})
+}
+
+// Zip3 returns a Value holding a Triple of the values of a, b, and c, if all
+// three are set. otherwise, it returns an unset Value
+func Zip3[A, B, C any](a Value[A], b Value[B], c Value[C]) Value[Triple[A, B, C]] {
+	if !a.set || !b.set || !c.set {
+		return Value[Triple[A, B, C]]{}
+	}
+	return NewValue(Triple[A, B, C]{First: a.value, Second: b.value, Third: c.value})
+}
@@ -0,0 +1,30 @@
+package optional_test
+
+import (
+	"testing"
+
+	"github.com/heucuva/optional"
+)
+
+func TestZip(t *testing.T) {
+	host := optional.NewValue("localhost")
+	port := optional.NewValue(8080)
+	var tls optional.Value[bool]
+
+	t.Run("AllSet", func(t *testing.T) {
+		pair := optional.Zip(host, port).MustGet()
+		expect(t, "First", "localhost", pair.First)
+		expect(t, "Second", 8080, pair.Second)
+	})
+	t.Run("OneUnset", func(t *testing.T) {
+		expect(t, "set", false, optional.Zip(host, tls).IsSet())
+		expect(t, "set", false, optional.Zip(tls, port).IsSet())
+	})
+	t.Run("Zip3", func(t *testing.T) {
+		expect(t, "set", false, optional.Zip3(host, port, tls).IsSet())
+		triple := optional.Zip3(host, port, optional.NewValue(false)).MustGet()
+		expect(t, "First", "localhost", triple.First)
+		expect(t, "Second", 8080, triple.Second)
+		expect(t, "Third", false, triple.Third)
+	})
+}